@@ -1,8 +1,10 @@
 package applications
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	datastore "github.com/xmnservices/xmnsuite/datastore"
 	routers "github.com/xmnservices/xmnsuite/routers"
@@ -20,6 +22,12 @@ type application struct {
 	router             routers.Router
 	db                 Database
 	retrieveValidators RetrieveValidators
+	onTrx              OnTransaction
+	onRoute            OnRoute
+	beginBlock         BeginBlock
+	endBlock           EndBlock
+	onCommit           OnCommit
+	legacySignatures   bool
 }
 
 func createApplication(
@@ -29,6 +37,12 @@ func createApplication(
 	db Database,
 	router routers.Router,
 	retrieveValidators RetrieveValidators,
+	onTrx OnTransaction,
+	onRoute OnRoute,
+	beginBlock BeginBlock,
+	endBlock EndBlock,
+	onCommit OnCommit,
+	legacySignatures bool,
 ) (*application, error) {
 	out := application{
 		fromIndex:          fromIndex,
@@ -37,6 +51,12 @@ func createApplication(
 		db:                 db,
 		router:             router,
 		retrieveValidators: retrieveValidators,
+		onTrx:              onTrx,
+		onRoute:            onRoute,
+		beginBlock:         beginBlock,
+		endBlock:           endBlock,
+		onCommit:           onCommit,
+		legacySignatures:   legacySignatures,
 	}
 
 	return &out, nil
@@ -59,9 +79,49 @@ func (app *application) GetBlockIndex() int64 {
 
 // Validators returns the validators
 func (app *application) Validators() ([]Validator, error) {
+	if app.retrieveValidators == nil {
+		return []Validator{}, nil
+	}
+
 	return app.retrieveValidators(app.db.DataStore().DataStore())
 }
 
+// BeginBlock executes the begin block func, if any, before the transactions of the block at the given height are delivered.
+// The func is executed on a copy of the datastore, whose writes are only kept when it succeeds
+func (app *application) BeginBlock(height int64) error {
+	if app.beginBlock == nil {
+		return nil
+	}
+
+	store := app.db.DataStore().DataStore()
+	copied := store.Copy()
+	beginBlockErr := app.beginBlock(copied, height)
+	if beginBlockErr != nil {
+		return beginBlockErr
+	}
+
+	store.Replace(copied)
+	return nil
+}
+
+// EndBlock executes the end block func, if any, after the transactions of the block at the given height are delivered, and returns the validator updates.
+// The func is executed on a copy of the datastore, whose writes are only kept when it succeeds
+func (app *application) EndBlock(height int64) ([]Validator, error) {
+	if app.endBlock == nil {
+		return app.Validators()
+	}
+
+	store := app.db.DataStore().DataStore()
+	copied := store.Copy()
+	vals, valsErr := app.endBlock(copied, height)
+	if valsErr != nil {
+		return nil, valsErr
+	}
+
+	store.Replace(copied)
+	return vals, nil
+}
+
 // Info returns the application's information
 func (app *application) Info(req InfoRequest) InfoResponse {
 	version := req.Version()
@@ -70,10 +130,26 @@ func (app *application) Info(req InfoRequest) InfoResponse {
 	return out
 }
 
-// Transact tries to execute a transaction and return its response
-func (app *application) Transact(req routers.TransactionRequest) routers.TransactionResponse {
-	//execute the transaction:
-	resp := app.execTrx(app.db.DataStore().DataStore(), req)
+// Transact tries to execute a transaction and return its response.  The hash is the hash of the raw transaction.
+// The transaction runs on a full copy of the store, so its cost grows with the size of the store, see
+// BenchmarkApplication_Transact
+func (app *application) Transact(req routers.TransactionRequest, hash []byte) routers.TransactionResponse {
+	//execute the transaction on a copy of the store, then only keep its writes if it succeeded:
+	store := app.db.DataStore().DataStore()
+	copied := store.Copy()
+	resp := app.execTrx(copied, req)
+	if resp != nil && resp.Code() == routers.IsSuccessful {
+		store.Replace(copied)
+	}
+
+	// execute the on transaction func, if any, once the transaction succeeded:
+	if app.onTrx != nil && resp != nil && resp.Code() == routers.IsSuccessful {
+		height := app.db.State(app.version).Height() + 1
+		onTrxErr := app.onTrx(height, hash, req, resp)
+		if onTrxErr != nil {
+			log.Printf("there was an error while executing the on transaction func: %s", onTrxErr.Error())
+		}
+	}
 
 	//increment the state size:
 	app.db.State(app.version).Increment()
@@ -102,16 +178,39 @@ func (app *application) Commit() CommitResponse {
 		panic(stErr)
 	}
 
+	// execute the on commit func, if any.  The state is already hashed and saved, so the func reads a copy of it:
+	if app.onCommit != nil {
+		onCommitErr := app.onCommit(app.db.DataStore().DataStore().Copy(), st.Height(), st.Hash())
+		if onCommitErr != nil {
+			log.Printf("there was an error while executing the on commit func: %s", onCommitErr.Error())
+		}
+	}
+
 	// response:
 	return createCommitResponse(curSt.Hash(), st.Hash(), st.Height())
 }
 
+// Prove creates a proof that the object at key is stored in the datastore, as it was when the last app hash was computed
+func (app *application) Prove(key string) (datastore.Proof, error) {
+	prover := app.db.Prover()
+	if prover == nil {
+		return nil, errors.New("the application has not committed any state that can be proven yet")
+	}
+
+	return prover.Prove(key)
+}
+
 // Query executes a query request on the application
-func (app *application) Query(req routers.QueryRequest) routers.QueryResponse {
+func (app *application) Query(req routers.QueryRequest) (out routers.QueryResponse) {
 
+	// a panic of the query func is returned as a server error, since the abci server reads the code of the response:
 	defer func() {
 		if r := recover(); r != nil {
 			log.Println("There was an error while executing the query:", r)
+			out = routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+				Code: routers.ServerError,
+				Log:  fmt.Sprintf("there was an error while executing the query: %v", r),
+			})
 		}
 	}()
 
@@ -124,6 +223,11 @@ func (app *application) Query(req routers.QueryRequest) routers.QueryResponse {
 		return resp
 	}
 
+	// make sure the query is signed by its resource pointer's public key:
+	if !req.IsAuthenticated() && !(app.legacySignatures && req.IsLegacyAuthenticated()) {
+		return outputErrorFn(routers.IsUnAuthenticated, "the query signature could not be validated by the resource pointer's public key")
+	}
+
 	ptr := req.Pointer()
 	from := ptr.From()
 	prepHandler := app.router.Route(from, ptr.Path(), routers.Retrieve)
@@ -139,7 +243,9 @@ func (app *application) Query(req routers.QueryRequest) routers.QueryResponse {
 	}
 
 	// retrieve the query response:
+	beginsOn := time.Now()
 	queryResponse, queryResponseErr := retrieveFunc(app.db.DataStore().DataStore(), from, prepHandler.Path(), prepHandler.Params(), req.Signature())
+	app.observeRoute(prepHandler.Pattern(), routers.Retrieve, beginsOn)
 	if queryResponseErr != nil {
 		str := fmt.Sprintf("there was an error while executing the query func: %s", queryResponseErr.Error())
 		return outputErrorFn(routers.InvalidRequest, str)
@@ -149,11 +255,16 @@ func (app *application) Query(req routers.QueryRequest) routers.QueryResponse {
 	return queryResponse
 }
 
-func (app *application) execTrx(store datastore.DataStore, req routers.TransactionRequest) routers.TransactionResponse {
+func (app *application) execTrx(store datastore.DataStore, req routers.TransactionRequest) (out routers.TransactionResponse) {
 
+	// a panic of the transaction func is returned as a server error, since the abci server reads the code of the response:
 	defer func() {
 		if r := recover(); r != nil {
 			log.Println("There was an error while executing the transaction:", r)
+			out = routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+				Code: routers.ServerError,
+				Log:  fmt.Sprintf("there was an error while executing the transaction: %v", r),
+			})
 		}
 	}()
 
@@ -166,6 +277,11 @@ func (app *application) execTrx(store datastore.DataStore, req routers.Transacti
 		return trxResp
 	}
 
+	// make sure the transaction is signed by its resource pointer's public key:
+	if !req.IsAuthenticated() && !(app.legacySignatures && req.IsLegacyAuthenticated()) {
+		return outputErrorFn(routers.IsUnAuthenticated, "the transaction signature could not be validated by the resource pointer's public key")
+	}
+
 	// if the transaction is a "save-resource-transaction":
 	res := req.Resource()
 	if res != nil {
@@ -182,7 +298,17 @@ func (app *application) execTrx(store datastore.DataStore, req routers.Transacti
 			return outputErrorFn(routers.InvalidRoute, "the router found a route for the given transaction, but its handler had no save transaction func")
 		}
 
+		if handler.HasSchema() {
+			schemaErr := handler.Schema().Validate(res.Data())
+			if schemaErr != nil {
+				str := fmt.Sprintf("the data of the save transaction does not conform to the schema of the route: %s", schemaErr.Error())
+				return outputErrorFn(routers.InvalidRequest, str)
+			}
+		}
+
+		beginsOn := time.Now()
 		trxResponse, trxResponseErr := saveTrsFunc(store, from, prepHandler.Path(), prepHandler.Params(), res.Data(), req.Signature())
+		app.observeRoute(prepHandler.Pattern(), routers.Save, beginsOn)
 		if trxResponseErr != nil {
 			str := fmt.Sprintf("there was an error while executing the save transaction func: %s", trxResponseErr.Error())
 			return outputErrorFn(routers.InvalidRequest, str)
@@ -204,7 +330,9 @@ func (app *application) execTrx(store datastore.DataStore, req routers.Transacti
 		return outputErrorFn(routers.InvalidRoute, "the router found a route for the given transaction, but its handler had no delete transaction func")
 	}
 
+	beginsOn := time.Now()
 	trsResponse, trsResponseErr := delTrsFunc(store, from, prepHandler.Path(), prepHandler.Params(), req.Signature())
+	app.observeRoute(prepHandler.Pattern(), routers.Delete, beginsOn)
 	if trsResponseErr != nil {
 		str := fmt.Sprintf("there was an error while executing the delete transaction func: %s", trsResponseErr.Error())
 		return outputErrorFn(routers.InvalidRequest, str)
@@ -212,3 +340,11 @@ func (app *application) execTrx(store datastore.DataStore, req routers.Transacti
 
 	return trsResponse
 }
+
+func (app *application) observeRoute(pattern string, method int, beginsOn time.Time) {
+	if app.onRoute == nil {
+		return
+	}
+
+	app.onRoute(pattern, method, time.Since(beginsOn))
+}
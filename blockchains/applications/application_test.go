@@ -0,0 +1,384 @@
+package applications
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	objects "github.com/xmnservices/xmnsuite/datastore/objects"
+	routers "github.com/xmnservices/xmnsuite/routers"
+)
+
+func TestApplication_withBlockHooks_keepsOnlyTheWritesOfTheSuccessfulHooks(t *testing.T) {
+	//variables:
+	id := uuid.NewV4()
+	store := datastore.SDKFunc.CreateMemoryStore()
+
+	// create application:
+	app := SDKFunc.CreateApplication(CreateApplicationParams{
+		Namespace:      "testapp",
+		Name:           "MyTestApp",
+		ID:             &id,
+		FromBlockIndex: 0,
+		ToBlockIndex:   -1,
+		Version:        "2018.04.29",
+		Store:          store,
+		RouterParams: routers.CreateRouterParams{
+			DataStore:  datastore.SDKFunc.Create(),
+			RoleKey:    "router-role-key",
+			RtesParams: []routers.CreateRouteParams{},
+		},
+		BeginBlock: func(ds datastore.DataStore, height int64) error {
+			ds.Keys().Save("begin-block", "saved")
+			return nil
+		},
+		EndBlock: func(ds datastore.DataStore, height int64) ([]Validator, error) {
+			ds.Keys().Save("end-block", "saved")
+			return nil, errors.New("the end block failed")
+		},
+		OnCommit: func(ds datastore.DataStore, height int64, appHash []byte) error {
+			ds.Keys().Save("on-commit", "saved")
+			return nil
+		},
+	})
+
+	beginBlockErr := app.BeginBlock(1)
+	if beginBlockErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", beginBlockErr.Error())
+		return
+	}
+
+	_, endBlockErr := app.EndBlock(1)
+	if endBlockErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	app.Commit()
+
+	if store.DataStore().Keys().Exists("begin-block") != 1 {
+		t.Errorf("the writes of the successful begin block func were expected to be kept")
+		return
+	}
+
+	if store.DataStore().Keys().Exists("end-block") != 0 {
+		t.Errorf("the writes of the failed end block func were expected to be discarded")
+		return
+	}
+
+	if store.DataStore().Keys().Exists("on-commit") != 0 {
+		t.Errorf("the writes of the on commit func were expected to be discarded")
+		return
+	}
+}
+
+func TestApplication_withFailedTransaction_keepsOnlyTheWritesOfTheSuccessfulTransactions(t *testing.T) {
+	//variables:
+	id := uuid.NewV4()
+	fromPrivKey := crypto.SDKFunc.GenPK()
+	fromPubKey := fromPrivKey.PublicKey()
+	store := datastore.SDKFunc.CreateMemoryStore()
+
+	// enable our user to write on the right routes:
+	routerRoleKey := "router-role-key"
+	routerDS := datastore.SDKFunc.Create()
+	routerDS.Users().Insert(fromPubKey)
+	routerDS.Roles().Add(routerRoleKey, fromPubKey)
+	routerDS.Roles().EnableWriteAccess(routerRoleKey, "/writes")
+
+	// create application:
+	app := SDKFunc.CreateApplication(CreateApplicationParams{
+		Namespace:      "testapp",
+		Name:           "MyTestApp",
+		ID:             &id,
+		FromBlockIndex: 0,
+		ToBlockIndex:   -1,
+		Version:        "2018.04.29",
+		Store:          store,
+		RouterParams: routers.CreateRouterParams{
+			DataStore: routerDS,
+			RoleKey:   routerRoleKey,
+			RtesParams: []routers.CreateRouteParams{
+				routers.CreateRouteParams{
+					Pattern: "/writes",
+					SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+						// the handler writes before deciding whether it fails:
+						store.Keys().Save(string(data), "saved")
+						if string(data) == "failed-write" {
+							return nil, errors.New("the transaction failed")
+						}
+
+						return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+							Code: routers.IsSuccessful,
+							Log:  "success",
+						}), nil
+					},
+				},
+			},
+		},
+	})
+
+	for _, oneData := range []string{"failed-write", "successful-write"} {
+		res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+			ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+				From: fromPubKey,
+				Path: "/writes",
+			}),
+			Data: []byte(oneData),
+		})
+
+		app.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+			Res: res,
+			Sig: fromPrivKey.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+		}), []byte(res.Hash()))
+	}
+
+	if store.DataStore().Keys().Exists("failed-write") != 0 {
+		t.Errorf("the writes of the failed transaction were expected to be discarded")
+		return
+	}
+
+	if store.DataStore().Keys().Exists("successful-write") != 1 {
+		t.Errorf("the writes of the successful transaction were expected to be kept")
+		return
+	}
+}
+
+func TestApplication_withPanickingHandlers_returnsServerError(t *testing.T) {
+	//variables:
+	id := uuid.NewV4()
+	fromPrivKey := crypto.SDKFunc.GenPK()
+	fromPubKey := fromPrivKey.PublicKey()
+	store := datastore.SDKFunc.CreateMemoryStore()
+
+	// enable our user to write on the right routes:
+	routerRoleKey := "router-role-key"
+	routerDS := datastore.SDKFunc.Create()
+	routerDS.Users().Insert(fromPubKey)
+	routerDS.Roles().Add(routerRoleKey, fromPubKey)
+	routerDS.Roles().EnableWriteAccess(routerRoleKey, "/panics")
+
+	// create application:
+	app := SDKFunc.CreateApplication(CreateApplicationParams{
+		Namespace:      "testapp",
+		Name:           "MyTestApp",
+		ID:             &id,
+		FromBlockIndex: 0,
+		ToBlockIndex:   -1,
+		Version:        "2018.04.29",
+		Store:          store,
+		RouterParams: routers.CreateRouterParams{
+			DataStore: routerDS,
+			RoleKey:   routerRoleKey,
+			RtesParams: []routers.CreateRouteParams{
+				routers.CreateRouteParams{
+					Pattern: "/panics",
+					SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+						store.Keys().Save("panicked-write", "saved")
+						panic(errors.New("the transaction panicked"))
+					},
+				},
+				routers.CreateRouteParams{
+					Pattern: "/panics",
+					QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+						panic(errors.New("the query panicked"))
+					},
+				},
+			},
+		},
+	})
+
+	res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: fromPubKey,
+			Path: "/panics",
+		}),
+		Data: []byte("data"),
+	})
+
+	trxReq := routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+		Res: res,
+		Sig: fromPrivKey.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+	})
+
+	checkResp := app.CheckTransact(trxReq)
+	if checkResp == nil || checkResp.Code() != routers.ServerError {
+		t.Errorf("the check transaction response was expected to be a server error")
+		return
+	}
+
+	trxResp := app.Transact(trxReq, []byte(res.Hash()))
+	if trxResp == nil || trxResp.Code() != routers.ServerError {
+		t.Errorf("the transaction response was expected to be a server error")
+		return
+	}
+
+	if store.DataStore().Keys().Exists("panicked-write") != 0 {
+		t.Errorf("the writes of the panicked transaction were expected to be discarded")
+		return
+	}
+
+	queryPtr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+		From: fromPubKey,
+		Path: "/panics",
+	})
+
+	queryResp := app.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+		Ptr: queryPtr,
+		Sig: fromPrivKey.SignBytes(routers.QueryDomain, []byte(queryPtr.Hash())),
+	}))
+
+	if queryResp == nil || queryResp.Code() != routers.ServerError {
+		t.Errorf("the query response was expected to be a server error")
+		return
+	}
+}
+
+func TestApplication_isLoadedAgain_provesTheCommittedState_Success(t *testing.T) {
+	//variables:
+	id := uuid.NewV4()
+	fromPrivKey := crypto.SDKFunc.GenPK()
+	fromPubKey := fromPrivKey.PublicKey()
+	store := datastore.SDKFunc.CreateMemoryStore()
+	key := "/messages/first"
+
+	// enable our user to write on the right routes:
+	routerRoleKey := "router-role-key"
+	routerDS := datastore.SDKFunc.Create()
+	routerDS.Users().Insert(fromPubKey)
+	routerDS.Roles().Add(routerRoleKey, fromPubKey)
+	routerDS.Roles().EnableWriteAccess(routerRoleKey, "/messages")
+
+	createAppFn := func() Application {
+		return SDKFunc.CreateApplication(CreateApplicationParams{
+			Namespace:      "testapp",
+			Name:           "MyTestApp",
+			ID:             &id,
+			FromBlockIndex: 0,
+			ToBlockIndex:   -1,
+			Version:        "2018.04.29",
+			Store:          store,
+			RouterParams: routers.CreateRouterParams{
+				DataStore: routerDS,
+				RoleKey:   routerRoleKey,
+				RtesParams: []routers.CreateRouteParams{
+					routers.CreateRouteParams{
+						Pattern: "/messages",
+						SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+							store.Objects().Save(&objects.ObjInKey{
+								Key: key,
+								Obj: &data,
+							})
+
+							return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+								Code: routers.IsSuccessful,
+								Log:  "success",
+							}), nil
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// save an object, then commit:
+	app := createAppFn()
+	res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: fromPubKey,
+			Path: "/messages",
+		}),
+		Data: []byte("this is a message"),
+	})
+
+	app.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+		Res: res,
+		Sig: fromPrivKey.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+	}), []byte(res.Hash()))
+
+	commitResp := app.Commit()
+
+	// load the application again, as a node that restarts:
+	loadedApp := createAppFn()
+	prf, prfErr := loadedApp.Prove(key)
+	if prfErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", prfErr.Error())
+		return
+	}
+
+	verifyErr := prf.Verify(commitResp.AppHash())
+	if verifyErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", verifyErr.Error())
+		return
+	}
+}
+
+func BenchmarkApplication_Transact(b *testing.B) {
+	for _, oneAmount := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("keys-%d", oneAmount), func(b *testing.B) {
+			//variables:
+			id := uuid.NewV4()
+			fromPrivKey := crypto.SDKFunc.GenPK()
+			fromPubKey := fromPrivKey.PublicKey()
+			store := datastore.SDKFunc.CreateMemoryStore()
+			for i := 0; i < oneAmount; i++ {
+				store.DataStore().Keys().Save(fmt.Sprintf("key-%d", i), "this is some data")
+			}
+
+			// enable our user to write on the right routes:
+			routerRoleKey := "router-role-key"
+			routerDS := datastore.SDKFunc.Create()
+			routerDS.Users().Insert(fromPubKey)
+			routerDS.Roles().Add(routerRoleKey, fromPubKey)
+			routerDS.Roles().EnableWriteAccess(routerRoleKey, "/writes")
+
+			// create application:
+			app := SDKFunc.CreateApplication(CreateApplicationParams{
+				Namespace:      "testapp",
+				Name:           "MyTestApp",
+				ID:             &id,
+				FromBlockIndex: 0,
+				ToBlockIndex:   -1,
+				Version:        "2018.04.29",
+				Store:          store,
+				RouterParams: routers.CreateRouterParams{
+					DataStore: routerDS,
+					RoleKey:   routerRoleKey,
+					RtesParams: []routers.CreateRouteParams{
+						routers.CreateRouteParams{
+							Pattern: "/writes",
+							SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+								store.Keys().Save(string(data), "saved")
+								return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+									Code: routers.IsSuccessful,
+									Log:  "success",
+								}), nil
+							},
+						},
+					},
+				},
+			})
+
+			res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+				ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+					From: fromPubKey,
+					Path: "/writes",
+				}),
+				Data: []byte("write"),
+			})
+
+			req := routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+				Res: res,
+				Sig: fromPrivKey.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+			})
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				app.Transact(req, []byte(res.Hash()))
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package applications
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
@@ -77,6 +78,7 @@ type database struct {
 	stateKey string
 	states   map[string]State
 	ds       datastore.StoredDataStore
+	prover   datastore.Prover
 }
 
 func createDatabase(states map[string]State, ds datastore.StoredDataStore, stateKey string) Database {
@@ -128,7 +130,12 @@ func retrieveOrCreateState(currVersion string, stateKey string, ds datastore.Sto
 		mapStatesVersion[newSt.Version()] = newSt
 	}
 
-	storedState := createDatabase(mapStatesVersion, ds, stateKey)
+	storedState := createDatabase(mapStatesVersion, ds, stateKey).(*database)
+	proverErr := storedState.restoreProver(currVersion)
+	if proverErr != nil {
+		return nil, proverErr
+	}
+
 	return storedState, nil
 }
 
@@ -150,9 +157,15 @@ func (app *database) Update(version string) (State, error) {
 	// get the hash from state:
 	appHash := st.Hash()
 
-	//if the size is bigger than 0, use the store head hash:
+	//if the size is bigger than 0, use the store head hash, and keep a prover of the store as it is now:
 	if size > 0 {
-		appHash = app.ds.DataStore().Head().Head().Get()
+		prover, proverErr := app.ds.DataStore().Prover()
+		if proverErr != nil {
+			return nil, proverErr
+		}
+
+		appHash = prover.Head().Get()
+		app.prover = prover
 	}
 
 	//create the updated state:
@@ -189,7 +202,43 @@ func (app *database) Update(version string) (State, error) {
 	return app.states[version], nil
 }
 
+// restoreProver rebuilds the prover of a loaded database.  The state of the last update is saved in the store after its
+// app hash is computed, so the prover is rebuilt on a copy of the store without it:
+func (app *database) restoreProver(version string) error {
+	st := app.State(version)
+	if st == nil || st.Size() <= 0 {
+		return nil
+	}
+
+	hashAsString := hex.EncodeToString(st.Hash())
+	copied := app.ds.DataStore().Copy()
+	copied.Sets().Del(app.stateKey, st.Hash())
+	if copied.Sets().Len(app.stateKey) <= 0 {
+		copied.Sets().Objects().Keys().Delete(app.stateKey)
+	}
+
+	copied.Objects().Keys().Delete(fmt.Sprintf("%s:%s", app.stateKey, hashAsString))
+	prover, proverErr := copied.Prover()
+	if proverErr != nil {
+		return proverErr
+	}
+
+	// when the store does not hash to the app hash of the state, the proofs are only available after the next update:
+	if !bytes.Equal(prover.Head().Get(), st.Hash()) {
+		log.Printf("the prover of the state (hash: %s) could not be rebuilt, the proofs will be available after the next update", hashAsString)
+		return nil
+	}
+
+	app.prover = prover
+	return nil
+}
+
 // DataStore returns the datastore
 func (app *database) DataStore() datastore.StoredDataStore {
 	return app.ds
 }
+
+// Prover returns the prover of the datastore, as it was when the last app hash was computed
+func (app *database) Prover() datastore.Prover {
+	return app.prover
+}
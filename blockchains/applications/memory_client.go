@@ -0,0 +1,126 @@
+package applications
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	routers "github.com/xmnservices/xmnsuite/routers"
+)
+
+/*
+ * Memory Client
+ */
+
+type memoryClient struct {
+	lock      *sync.Mutex
+	ipAddress string
+	apps      Applications
+	blkHeight int64
+}
+
+func createMemoryClient(ipAddress string, apps Applications) Client {
+	out := memoryClient{
+		lock:      new(sync.Mutex),
+		ipAddress: ipAddress,
+		apps:      apps,
+		blkHeight: apps.RetrieveBlockIndex(),
+	}
+
+	return &out
+}
+
+// IP returns the client IP
+func (app *memoryClient) IP() string {
+	return app.ipAddress
+}
+
+// Query executes a query on the current application and returns its response
+func (app *memoryClient) Query(req routers.QueryRequest) (routers.QueryResponse, error) {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+
+	// retrieve the app:
+	curApp, curAppErr := app.apps.RetrieveByBlockIndex(app.blkHeight)
+	if curAppErr != nil {
+		return nil, curAppErr
+	}
+
+	// execute the query on the application:
+	resp := curApp.Query(req)
+	if resp == nil {
+		str := fmt.Sprintf("the query (path: %s) did not return any response", req.Pointer().Path())
+		return nil, errors.New(str)
+	}
+
+	return resp, nil
+}
+
+// Transact checks, delivers then commits a transaction in its own block, and returns its response
+func (app *memoryClient) Transact(req routers.TransactionRequest) (ClientTransactionResponse, error) {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+
+	// encode the transaction, like the node would receive it:
+	reqJS, reqJSErr := cdc.MarshalJSON(req)
+	if reqJSErr != nil {
+		return nil, reqJSErr
+	}
+
+	// decode the transaction, like the abci application would:
+	tx := routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+		JSData: reqJS,
+	})
+
+	// retrieve the app:
+	curApp, curAppErr := app.apps.RetrieveByBlockIndex(app.blkHeight)
+	if curAppErr != nil {
+		return nil, curAppErr
+	}
+
+	// check the transaction:
+	hash := tmhash.Sum(reqJS)
+	chkResponse := curApp.CheckTransact(tx)
+	if chkResponse == nil {
+		return nil, errors.New("the check transaction did not return any response")
+	}
+
+	// if the check failed, the transaction never reaches a block:
+	if chkResponse.Code() != routers.IsSuccessful {
+		return createClientTransactionResponse(chkResponse, createEmptyTransactionResponse(), 0, hash), nil
+	}
+
+	// begin the block:
+	height := app.blkHeight + 1
+	beginBlockErr := curApp.BeginBlock(height)
+	if beginBlockErr != nil {
+		log.Printf("there was an error while beginning the block (height: %d): %s", height, beginBlockErr.Error())
+	}
+
+	// deliver the transaction:
+	trxResponse := curApp.Transact(tx, hash)
+	if trxResponse == nil {
+		return nil, errors.New("the transaction did not return any response")
+	}
+
+	// end the block, the validator updates are ignored since there is no consensus:
+	_, endBlockErr := curApp.EndBlock(height)
+	if endBlockErr != nil {
+		log.Printf("there was an error while ending the block (height: %d): %s", height, endBlockErr.Error())
+	}
+
+	// commit the block:
+	commitResponse := curApp.Commit()
+	app.blkHeight = commitResponse.BlockHeight()
+
+	// return the response:
+	return createClientTransactionResponse(chkResponse, trxResponse, app.blkHeight, hash), nil
+}
+
+func createEmptyTransactionResponse() routers.TransactionResponse {
+	return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+		Code: routers.IsSuccessful,
+	})
+}
@@ -0,0 +1,307 @@
+package applications
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	objects "github.com/xmnservices/xmnsuite/datastore/objects"
+	routers "github.com/xmnservices/xmnsuite/routers"
+)
+
+type messageForTest struct {
+	ID          *uuid.UUID `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+}
+
+func TestMemoryClient_transactThenQuery_Success(t *testing.T) {
+	//variables:
+	id := uuid.NewV4()
+	fromPrivKey := crypto.SDKFunc.GenPK()
+	fromPubKey := fromPrivKey.PublicKey()
+
+	// enable our user to write on the right routes:
+	routerRoleKey := "router-role-key"
+	routerDS := datastore.SDKFunc.Create()
+	routerDS.Users().Insert(fromPubKey)
+	routerDS.Roles().Add(routerRoleKey, fromPubKey)
+	routerDS.Roles().EnableWriteAccess(routerRoleKey, "/messages")
+
+	// create application:
+	app := SDKFunc.CreateApplication(CreateApplicationParams{
+		Namespace:      "testapp",
+		Name:           "MyTestApp",
+		ID:             &id,
+		FromBlockIndex: 0,
+		ToBlockIndex:   -1,
+		Version:        "2018.04.29",
+		Store:          datastore.SDKFunc.CreateMemoryStore(),
+		RetrieveValidators: func(ds datastore.DataStore) ([]Validator, error) {
+			return []Validator{}, nil
+		},
+		RouterParams: routers.CreateRouterParams{
+			DataStore: routerDS,
+			RoleKey:   routerRoleKey,
+			RtesParams: []routers.CreateRouteParams{
+				routers.CreateRouteParams{
+					Pattern: "/messages",
+					SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+						msg := new(messageForTest)
+						jsErr := cdc.UnmarshalJSON(data, msg)
+						if jsErr != nil {
+							return nil, jsErr
+						}
+
+						msgPath := filepath.Join(path, msg.ID.String())
+						amountSaved := store.Objects().Save(&objects.ObjInKey{
+							Key: msgPath,
+							Obj: msg,
+						})
+
+						if amountSaved != 1 {
+							return nil, errors.New("there was an error while saving the message")
+						}
+
+						return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+							Code:    routers.IsSuccessful,
+							Log:     "success",
+							GazUsed: 1205,
+							Tags: map[string][]byte{
+								msgPath: data,
+							},
+						}), nil
+					},
+				},
+				routers.CreateRouteParams{
+					Pattern: "/messages/<id|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}>",
+					QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+						obj := objects.ObjInKey{
+							Key: path,
+							Obj: new(messageForTest),
+						}
+
+						amount := store.Objects().Retrieve(&obj)
+						if amount != 1 {
+							str := fmt.Sprintf("there is no message on path: %s", path)
+							return nil, errors.New(str)
+						}
+
+						js, jsErr := cdc.MarshalJSON(obj.Obj)
+						if jsErr != nil {
+							return nil, jsErr
+						}
+
+						return routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+							Code:  routers.IsSuccessful,
+							Log:   "success",
+							Key:   path,
+							Value: js,
+						}), nil
+					},
+				},
+			},
+		},
+	})
+
+	// create the client:
+	client := SDKFunc.CreateMemoryClient(CreateMemoryClientParams{
+		Apps: SDKFunc.CreateApplications(CreateApplicationsParams{
+			Apps: []Application{
+				app,
+			},
+		}),
+	})
+
+	// save two messages, each transaction must be committed in its own block:
+	msgs := []messageForTest{}
+	for index := 0; index < 2; index++ {
+		msgID := uuid.NewV4()
+		msg := messageForTest{
+			ID:          &msgID,
+			Title:       fmt.Sprintf("this is the title %d", index),
+			Description: fmt.Sprintf("this is the description %d", index),
+		}
+
+		jsMsg, jsMsgErr := cdc.MarshalJSON(msg)
+		if jsMsgErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", jsMsgErr.Error())
+			return
+		}
+
+		res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+			ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+				From: fromPubKey,
+				Path: "/messages",
+			}),
+			Data: jsMsg,
+		})
+
+		trxResp, trxRespErr := client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+			Res: res,
+			Sig: fromPrivKey.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+		}))
+
+		if trxRespErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", trxRespErr.Error())
+			return
+		}
+
+		if trxResp.Check().Code() != routers.IsSuccessful {
+			t.Errorf("the check transaction was expected to be successful, log: %s", trxResp.Check().Log())
+			return
+		}
+
+		if trxResp.Transaction().Code() != routers.IsSuccessful {
+			t.Errorf("the transaction was expected to be successful, log: %s", trxResp.Transaction().Log())
+			return
+		}
+
+		expectedHeight := int64(index + 1)
+		if trxResp.Height() != expectedHeight {
+			t.Errorf("the returned height was expected to be %d, returned: %d", expectedHeight, trxResp.Height())
+			return
+		}
+
+		if len(trxResp.Hash()) <= 0 {
+			t.Errorf("the returned hash was expected to be non-empty")
+			return
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	// execute a query:
+	queryPath := fmt.Sprintf("/messages/%s", msgs[0].ID.String())
+	queryResPtr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+		From: fromPubKey,
+		Path: queryPath,
+	})
+
+	queryResp, queryRespErr := client.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+		Ptr: queryResPtr,
+		Sig: fromPrivKey.SignBytes(routers.QueryDomain, []byte(queryResPtr.Hash())),
+	}))
+
+	if queryRespErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", queryRespErr.Error())
+		return
+	}
+
+	if queryResp.Code() != routers.IsSuccessful {
+		t.Errorf("the query was expected to be successful, log: %s", queryResp.Log())
+		return
+	}
+
+	queryMsg := new(messageForTest)
+	jsErr := cdc.UnmarshalJSON(queryResp.Value(), queryMsg)
+	if jsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", jsErr.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(queryMsg, &msgs[0]) {
+		t.Errorf("the returned message is invalid")
+		return
+	}
+}
+
+func TestMemoryClient_withLegacySignature_isOnlyAuthenticatedByLegacyApplications(t *testing.T) {
+	//variables:
+	fromPrivKey := crypto.SDKFunc.GenPK()
+	fromPubKey := fromPrivKey.PublicKey()
+
+	transactFn := func(legacySignatures bool) ClientTransactionResponse {
+		// enable our user to write on the right routes:
+		id := uuid.NewV4()
+		routerRoleKey := "router-role-key"
+		routerDS := datastore.SDKFunc.Create()
+		routerDS.Users().Insert(fromPubKey)
+		routerDS.Roles().Add(routerRoleKey, fromPubKey)
+		routerDS.Roles().EnableWriteAccess(routerRoleKey, "/messages")
+
+		// create application:
+		app := SDKFunc.CreateApplication(CreateApplicationParams{
+			Namespace:        "testapp",
+			Name:             "MyTestApp",
+			ID:               &id,
+			FromBlockIndex:   0,
+			ToBlockIndex:     -1,
+			Version:          "2018.04.29",
+			Store:            datastore.SDKFunc.CreateMemoryStore(),
+			LegacySignatures: legacySignatures,
+			RouterParams: routers.CreateRouterParams{
+				DataStore: routerDS,
+				RoleKey:   routerRoleKey,
+				RtesParams: []routers.CreateRouteParams{
+					routers.CreateRouteParams{
+						Pattern: "/messages",
+						SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+							store.Keys().Save(path, string(data))
+							return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+								Code:    routers.IsSuccessful,
+								Log:     "success",
+								GazUsed: int64(len(data)),
+							}), nil
+						},
+					},
+				},
+			},
+		})
+
+		// create the client:
+		client := SDKFunc.CreateMemoryClient(CreateMemoryClientParams{
+			Apps: SDKFunc.CreateApplications(CreateApplicationsParams{
+				Apps: []Application{
+					app,
+				},
+			}),
+		})
+
+		// the resource is signed without domain:
+		res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+			ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+				From: fromPubKey,
+				Path: "/messages",
+			}),
+			Data: []byte("this is a message"),
+		})
+
+		trxResp, trxRespErr := client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+			Res: res,
+			Sig: fromPrivKey.Sign(res.Hash()),
+		}))
+
+		if trxRespErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", trxRespErr.Error())
+			return nil
+		}
+
+		return trxResp
+	}
+
+	legacyResp := transactFn(true)
+	if legacyResp == nil {
+		return
+	}
+
+	if legacyResp.Transaction().Code() != routers.IsSuccessful {
+		t.Errorf("the transaction was expected to be successful, log: %s", legacyResp.Transaction().Log())
+		return
+	}
+
+	resp := transactFn(false)
+	if resp == nil {
+		return
+	}
+
+	if resp.Check().Code() != routers.IsUnAuthenticated {
+		t.Errorf("the check transaction was expected to be unauthenticated, code: %d", resp.Check().Code())
+		return
+	}
+}
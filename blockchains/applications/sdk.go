@@ -1,7 +1,9 @@
 package applications
 
 import (
+	"errors"
 	"net"
+	"time"
 
 	uuid "github.com/satori/go.uuid"
 	"github.com/tendermint/tendermint/crypto"
@@ -12,6 +14,26 @@ import (
 // RetrieveValidators is a func that retrieve validators
 type RetrieveValidators func(ds datastore.DataStore) ([]Validator, error)
 
+// OnTransaction is a func executed after a transaction has been successfully delivered, in the block of the given height, with the hash
+// of the raw transaction.  Can be used to index the transaction.  It does not receive the datastore of the application, so that what it
+// records stays outside of the consensus state
+type OnTransaction func(height int64, hash []byte, req routers.TransactionRequest, resp routers.TransactionResponse) error
+
+// BeginBlock is a func executed when a block begins, before its transactions are delivered.  Can be used to run periodic logic.
+// When it fails, its writes are discarded and the block continues: every node discards the same writes, so the chain does not halt
+type BeginBlock func(store datastore.DataStore, height int64) error
+
+// EndBlock is a func executed when a block ends, after its transactions are delivered.  Returns the validator updates, if any.
+// When it fails, its writes are discarded and the validators are not updated, but the chain does not halt
+type EndBlock func(store datastore.DataStore, height int64) ([]Validator, error)
+
+// OnCommit is a func executed after a block has been committed, with the app hash of the committed state.  The committed
+// state cannot be modified anymore, so the func receives a copy of the datastore whose writes are discarded
+type OnCommit func(store datastore.DataStore, height int64, appHash []byte) error
+
+// OnRoute is a func executed after a request has been executed by the handler of a route.  Can be used to measure the latency of the routes
+type OnRoute func(pattern string, method int, duration time.Duration)
+
 // InfoRequest represents an info request
 type InfoRequest interface {
 	Version() string
@@ -43,11 +65,14 @@ type Application interface {
 	FromBlockIndex() int64
 	ToBlockIndex() int64
 	Validators() ([]Validator, error)
+	BeginBlock(height int64) error
+	EndBlock(height int64) ([]Validator, error)
 	Info(req InfoRequest) InfoResponse
-	Transact(req routers.TransactionRequest) routers.TransactionResponse
+	Transact(req routers.TransactionRequest, hash []byte) routers.TransactionResponse
 	CheckTransact(req routers.TransactionRequest) routers.TransactionResponse
 	Commit() CommitResponse
 	Query(req routers.QueryRequest) routers.QueryResponse
+	Prove(key string) (datastore.Proof, error)
 }
 
 // Applications represents an application
@@ -93,6 +118,7 @@ type Database interface {
 	State(version string) State
 	Update(version string) (State, error)
 	DataStore() datastore.StoredDataStore
+	Prover() datastore.Prover
 }
 
 /*
@@ -104,7 +130,9 @@ type CreateInfoRequestParams struct {
 	Version string
 }
 
-// CreateApplicationParams represents the CreateApplication params
+// CreateApplicationParams represents the CreateApplication params.  When the Router is set, it is used instead of the RouterParams.
+// When LegacySignatures is true, the requests signed without domain are also authenticated, which the application versions
+// that were deployed before the domain separated signatures need in order to replay their blocks
 type CreateApplicationParams struct {
 	Namespace          string
 	Name               string
@@ -115,7 +143,14 @@ type CreateApplicationParams struct {
 	Version            string
 	Store              datastore.StoredDataStore
 	RouterParams       routers.CreateRouterParams
+	Router             routers.Router
 	RetrieveValidators RetrieveValidators
+	OnTransaction      OnTransaction
+	OnRoute            OnRoute
+	BeginBlock         BeginBlock
+	EndBlock           EndBlock
+	OnCommit           OnCommit
+	LegacySignatures   bool
 }
 
 // CreateApplicationsParams represents the CreateApplications params
@@ -131,6 +166,12 @@ type CreateClientTransactionResponseParams struct {
 	Hash   []byte
 }
 
+// CreateMemoryClientParams represents the CreateMemoryClient params
+type CreateMemoryClientParams struct {
+	IP   string
+	Apps Applications
+}
+
 // CreateValidatorParams represents the CreateValidator params
 type CreateValidatorParams struct {
 	IP     net.IP
@@ -145,6 +186,7 @@ var SDKFunc = struct {
 	CreateApplication               func(params CreateApplicationParams) Application
 	CreateApplications              func(params CreateApplicationsParams) Applications
 	CreateClientTransactionResponse func(params CreateClientTransactionResponseParams) ClientTransactionResponse
+	CreateMemoryClient              func(params CreateMemoryClientParams) Client
 }{
 	CreateValidator: func(params CreateValidatorParams) Validator {
 		out := createValidator(params.IP, params.PubKey, params.Power)
@@ -155,8 +197,11 @@ var SDKFunc = struct {
 		return out
 	},
 	CreateApplication: func(params CreateApplicationParams) Application {
-		//create the router:
-		rter := routers.SDKFunc.CreateRouter(params.RouterParams)
+		//create the router, unless one is given:
+		rter := params.Router
+		if rter == nil {
+			rter = routers.SDKFunc.CreateRouter(params.RouterParams)
+		}
 
 		// set some constant:
 		stateKey := "state-key"
@@ -168,7 +213,21 @@ var SDKFunc = struct {
 		}
 
 		//create the application:
-		app, appErr := createApplication(params.FromBlockIndex, params.ToBlockIndex, params.Version, db, rter, params.RetrieveValidators)
+		app, appErr := createApplication(
+			params.FromBlockIndex,
+			params.ToBlockIndex,
+			params.Version,
+			db,
+			rter,
+			params.RetrieveValidators,
+			params.OnTransaction,
+			params.OnRoute,
+			params.BeginBlock,
+			params.EndBlock,
+			params.OnCommit,
+			params.LegacySignatures,
+		)
+
 		if appErr != nil {
 			panic(appErr)
 		}
@@ -183,4 +242,16 @@ var SDKFunc = struct {
 		out := createClientTransactionResponse(params.Chk, params.Trx, params.Height, params.Hash)
 		return out
 	},
+	CreateMemoryClient: func(params CreateMemoryClientParams) Client {
+		if params.Apps == nil {
+			panic(errors.New("the applications are mandatory in order to create a memory client"))
+		}
+
+		if params.IP == "" {
+			params.IP = "memory://127.0.0.1"
+		}
+
+		out := createMemoryClient(params.IP, params.Apps)
+		return out
+	},
 }
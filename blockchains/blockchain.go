@@ -27,6 +27,7 @@ func createBlockchain(
 	peers []string,
 	genTrs genesis.Genesis,
 	met meta.Meta,
+	metricsAddress string,
 ) (Blockchain, error) {
 	configParams := commands.CreateParams{
 		Constants: commands.CreateConstantsParams{
@@ -65,8 +66,9 @@ func createBlockchain(
 
 	// execute the start command:
 	startCmd := commands.SDKFunc.CreateStart(commands.CreateStartParams{
-		Configs: configParams,
-		Peers:   peers,
+		Configs:        configParams,
+		Peers:          peers,
+		MetricsAddress: metricsAddress,
 	})
 
 	out := blockchain{
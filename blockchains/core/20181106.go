@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"unsafe"
@@ -15,13 +16,16 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/genesis"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/affiliates"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/user"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/validator"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/fees"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request"
 	active_request "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active/vote"
 	active_vote "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active/vote/active"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/completed"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token"
+	"github.com/xmnservices/xmnsuite/blockchains/history"
 	"github.com/xmnservices/xmnsuite/crypto"
 	"github.com/xmnservices/xmnsuite/datastore"
 	"github.com/xmnservices/xmnsuite/routers"
@@ -30,11 +34,12 @@ import (
 const maxAmountOfEntitiesToRetrieve = 500
 
 type incomingVote struct {
-	ID         string `json:"id"`
-	UserID     string `json:"user_id"`
-	Reason     string `json:"reason"`
-	IsNeutral  bool   `json:"is_neutral"`
-	IsApproved bool   `json:"is_approved"`
+	ID            string `json:"id"`
+	UserID        string `json:"user_id"`
+	RingSignature string `json:"ring_signature"`
+	Reason        string `json:"reason"`
+	IsNeutral     bool   `json:"is_neutral"`
+	IsApproved    bool   `json:"is_approved"`
 }
 
 type incomingRequest struct {
@@ -45,6 +50,18 @@ type incomingRequest struct {
 	DeleteEntityJSON []byte `json:"delete_entity_json"`
 }
 
+type incomingMultiSigRequest struct {
+	RequestJSON []byte `json:"request_json"`
+	MultiSig    string `json:"multisig"`
+}
+
+type parsedRequest struct {
+	req            request.Request
+	wal            wallet.Wallet
+	entityReq      meta.EntityRequest
+	representation entity.Representation
+}
+
 type core20181108 struct {
 	routerRoleKey string
 	meta          meta.Meta
@@ -71,6 +88,7 @@ func create20181106WithRootPubKey(
 	ds datastore.StoredDataStore,
 	met meta.Meta,
 	rootPubKey crypto.PublicKey,
+	onRoute applications.OnRoute,
 ) applications.Application {
 	// enable the root user to have write access to the genesis route:
 	store := ds.DataStore()
@@ -78,7 +96,7 @@ func create20181106WithRootPubKey(
 	store.Roles().Add(routerRoleKey, rootPubKey)
 	store.Roles().EnableWriteAccess(routerRoleKey, "/genesis")
 
-	return create20181106(namespace, name, id, fromBlockIndex, toBlockIndex, rootDir, routerRoleKey, ds, met)
+	return create20181106(namespace, name, id, fromBlockIndex, toBlockIndex, rootDir, routerRoleKey, ds, met, onRoute)
 }
 
 func create20181106(
@@ -91,10 +109,19 @@ func create20181106(
 	routerRoleKey string,
 	ds datastore.StoredDataStore,
 	met meta.Meta,
+	onRoute applications.OnRoute,
 ) applications.Application {
 	// create core:
 	core := createCore20181108(met, routerRoleKey)
 
+	// the history is indexed in its own datastore, outside of the consensus state:
+	historyStore := datastore.SDKFunc.CreateMemoryStore()
+	if rootDir != "" {
+		historyStore = datastore.SDKFunc.CreateStoredDataStore(datastore.StoredDataStoreParams{
+			FilePath: filepath.Join(rootDir, "history.xmn"),
+		})
+	}
+
 	// create application:
 	version := "2018.11.06"
 	app := applications.SDKFunc.CreateApplication(applications.CreateApplicationParams{
@@ -140,10 +167,20 @@ func create20181106(
 
 			return appVals, nil
 		},
+		OnTransaction: history.SDKFunc.CreateIndexer(history.CreateIndexerParams{
+			Datastore: historyStore.DataStore(),
+		}),
+		OnCommit: func(store datastore.DataStore, height int64, appHash []byte) error {
+			return historyStore.Save()
+		},
+		OnRoute:          onRoute,
+		LegacySignatures: true,
 		RouterParams: routers.CreateRouterParams{
 			DataStore: ds.DataStore(),
 			RoleKey:   routerRoleKey,
-			RtesParams: []routers.CreateRouteParams{
+			RtesParams: append(history.SDKFunc.CreateRoutes(history.CreateRoutesParams{
+				Datastore: historyStore.DataStore(),
+			}),
 				core.saveGenesis(),
 				core.saveEntity(),
 				core.retrieveEntityByID(),
@@ -151,8 +188,9 @@ func create20181106(
 				core.retrieveSetByIntersectKeynames(),
 				core.deleteEntityByID(),
 				core.saveRequest(),
+				core.saveRequestWithMultiSig(),
 				core.saveEntityRequestVote(),
-			},
+			),
 		},
 	})
 
@@ -189,6 +227,9 @@ func (app *core20181108) saveGenesis() routers.CreateRouteParams {
 				// enable the route to save requests:
 				store.Roles().EnableWriteAccess(app.routerRoleKey, "/[a-z-]+/requests")
 
+				// enable the route to save requests approved by a multi-signature:
+				store.Roles().EnableWriteAccess(app.routerRoleKey, "/[a-z-]+/requests/multisig")
+
 				// convert to json:
 				normalized, normalizedErr := app.meta.Genesis().MetaData().Normalize()(gen)
 				if normalizedErr != nil {
@@ -624,187 +665,342 @@ func (app *core20181108) saveRequest() routers.CreateRouteParams {
 				return nil, errors.New(str)
 			}
 
-			// convert the data to the incoming request:
-			ptr := new(incomingRequest)
-			jsErr := cdc.UnmarshalJSON(data, ptr)
-			if jsErr != nil {
-				return nil, jsErr
+			// create the request:
+			parsed, parsedErr := app.createRequest(dep, from, params, data)
+			if parsedErr != nil {
+				return nil, parsedErr
+			}
+
+			// create the active request:
+			var activeReq active_request.Request
+			keyname := parsed.entityReq.RequestedBy().MetaData().Keyname()
+			if keyname == token.SDKFunc.CreateMetaData().Keyname() {
+				activeReq = active_request.SDKFunc.Create(active_request.CreateParams{
+					Request:         parsed.req,
+					ConcensusNeeded: gen.Info().ConcensusNeeded(),
+				})
+			}
+
+			if keyname == wallet.SDKFunc.CreateMetaData().Keyname() {
+				activeReq = active_request.SDKFunc.Create(active_request.CreateParams{
+					Request:         parsed.req,
+					ConcensusNeeded: parsed.wal.ConcensusNeeded(),
+				})
+			}
+
+			// save the active request:
+			activeRepresentation := active_request.SDKFunc.CreateRepresentation()
+			saveActiveReqErr := dep.entityService.Save(activeReq, activeRepresentation)
+			if saveActiveReqErr != nil {
+				return nil, saveActiveReqErr
+			}
+
+			// enable the voting on the request:
+			store.Roles().EnableWriteAccess(app.routerRoleKey, fmt.Sprintf("/%s/requests/%s", parsed.req.Keyname().Name(), activeReq.ID().String()))
+
+			// convert to json:
+			storable, storableErr := activeRepresentation.ToStorable()(activeReq)
+			if storableErr != nil {
+				return nil, storableErr
+			}
+
+			jsData, jsDataErr := cdc.MarshalJSON(storable)
+			if jsDataErr != nil {
+				return nil, jsDataErr
 			}
 
-			// parse the walletID:
-			walletID, walletIDErr := uuid.FromString(ptr.WalletID)
-			if walletIDErr != nil {
-				str := fmt.Sprintf("the given walletID (%s) is invalid: %s", ptr.WalletID, walletIDErr.Error())
+			// charge the fees, then return the response:
+			return app.chargeFees(dep, gen, parsed.req.From(), path, jsData)
+		},
+	}
+}
+
+func (app *core20181108) saveRequestWithMultiSig() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: "/<keyname|[a-z-]+>/requests/multisig",
+		SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+
+			// create the dependencies:
+			dep := createDependencies(store)
+
+			// retrieve the genesis:
+			gen, genErr := dep.genesisRepository.Retrieve()
+			if genErr != nil {
+				str := fmt.Sprintf("there was an error while retrieving the Genesis instance: %s", genErr.Error())
 				return nil, errors.New(str)
 			}
 
-			// retrieve the wallet:
-			walIns, walInsErr := dep.entityRepository.RetrieveByID(app.meta.Wallet().MetaData(), &walletID)
-			if walInsErr != nil {
-				return nil, walInsErr
+			// convert the data to the incoming multisig request:
+			multiSigPtr := new(incomingMultiSigRequest)
+			multiSigJSErr := cdc.UnmarshalJSON(data, multiSigPtr)
+			if multiSigJSErr != nil {
+				return nil, multiSigJSErr
 			}
 
-			// parse the requestID:
-			reqID, reqIDErr := uuid.FromString(ptr.ID)
-			if reqIDErr != nil {
-				str := fmt.Sprintf("the given requestID (%s) is invalid: %s", ptr.ID, reqIDErr.Error())
+			// create the multi-signature:
+			multiSig, multiSigErr := fromStringToMultiSig(multiSigPtr.MultiSig)
+			if multiSigErr != nil {
+				return nil, multiSigErr
+			}
+
+			// create the request:
+			parsed, parsedErr := app.createRequest(dep, from, params, multiSigPtr.RequestJSON)
+			if parsedErr != nil {
+				return nil, parsedErr
+			}
+
+			// only the requests voted by the users of a wallet can be approved by a multi-signature:
+			req := parsed.req
+			wal := parsed.wal
+			if parsed.entityReq.RequestedBy().MetaData().Keyname() != wallet.SDKFunc.CreateMetaData().Keyname() {
+				str := fmt.Sprintf("the keyname (%s) cannot be approved by a multi-signature, since its requests are not voted by the users of a wallet", req.Keyname().Name())
 				return nil, errors.New(str)
 			}
 
-			// retrieve the user:
-			usr, usrErr := dep.userRepository.RetrieveByPubKey(from)
-			if usrErr != nil {
-				str := fmt.Sprintf("the requester PublicKey (%s) is not a valid user", from.String())
+			// retrieve the users of the wallet:
+			usersPS, usersPSErr := dep.userRepository.RetrieveSetByWallet(wal, 0, -1)
+			if usersPSErr != nil {
+				return nil, usersPSErr
+			}
+
+			pubKeys := []crypto.PublicKey{}
+			shares := map[string]int{}
+			for _, oneUserIns := range usersPS.Instances() {
+				if oneUser, ok := oneUserIns.(user.User); ok {
+					pubKeys = append(pubKeys, oneUser.PubKey())
+					shares[oneUser.PubKey().String()] = oneUser.Shares()
+				}
+			}
+
+			// retrieve the signers of the request, which must be users of the wallet:
+			msg := request.SDKFunc.CreateMultiSigMessage(request.CreateMultiSigMessageParams{
+				Keyname:     req.Keyname().Name(),
+				RequestJSON: multiSigPtr.RequestJSON,
+			})
+
+			signers, signersErr := multiSig.Signers(request.MultiSigDomain, msg, pubKeys)
+			if signersErr != nil {
+				str := fmt.Sprintf("the multi-signature of the request (ID: %s) is invalid, or one of its co-signers is not a user of the wallet (ID: %s): %s", req.ID().String(), wal.ID().String(), signersErr.Error())
 				return nil, errors.New(str)
 			}
 
-			if wal, ok := walIns.(wallet.Wallet); ok {
-				if keynameName, ok := params["keyname"]; ok {
-					// retrieve the keyname by name:
-					kname, knameErr := dep.keynameRepository.RetrieveByName(keynameName)
-					if knameErr != nil {
-						str := fmt.Sprintf("the keyname (name: %s) is invalid: %s", keynameName, knameErr.Error())
-						return nil, errors.New(str)
-					}
+			// the power of each signer is its amount of shares in the wallet:
+			approved := 0
+			for _, oneSigner := range signers {
+				approved += shares[oneSigner.String()]
+			}
 
-					// retrieve the representation:
-					wrOnEntityReq := app.meta.WriteOnEntityRequest()
-					if wrReq, ok := wrOnEntityReq[kname.Group().Name()]; ok {
-						mp := wrReq.Map()
-						if representation, ok := mp[kname.Name()]; ok {
-
-							// instances:
-							var toSaveIns entity.Entity
-							var toDelIns entity.Entity
-
-							// if the request is a save entity:
-							if ptr.SaveEntityJSON != nil {
-								saveIns, saveInsErr := representation.MetaData().ToEntity()(dep.entityRepository, ptr.SaveEntityJSON)
-								if saveInsErr != nil {
-									return nil, saveInsErr
-								}
+			neededConcensus := wal.ConcensusNeeded()
+			if approved < neededConcensus {
+				str := fmt.Sprintf("the co-signers of the request (ID: %s) have %d shares, but the wallet (ID: %s) needs a concensus of %d shares", req.ID().String(), approved, wal.ID().String(), neededConcensus)
+				return nil, errors.New(str)
+			}
 
-								toSaveIns = saveIns
-							}
+			// save the request, which fails if the request already exists:
+			saveReqErr := dep.entityService.Save(req, request.SDKFunc.CreateRepresentation())
+			if saveReqErr != nil {
+				return nil, saveReqErr
+			}
 
-							// if the request is a delete entity:
-							if ptr.DeleteEntityJSON != nil {
-								delIns, delInsErr := representation.MetaData().ToEntity()(dep.entityRepository, ptr.DeleteEntityJSON)
-								if delInsErr != nil {
-									return nil, delInsErr
-								}
+			// if we must save the entity:
+			if req.HasSave() {
+				saveErr := dep.entityService.Save(req.Save(), parsed.representation)
+				if saveErr != nil {
+					str := fmt.Sprintf("there was an error while saving the Entity instance (ID: %s): %s", req.Save().ID().String(), saveErr.Error())
+					return nil, errors.New(str)
+				}
+			}
 
-								toDelIns = delIns
-							}
+			// if we must delete the entity:
+			if req.HasDelete() {
+				delErr := dep.entityService.Delete(req.Delete(), parsed.representation)
+				if delErr != nil {
+					str := fmt.Sprintf("there was an error while deleting the Entity instance (ID: %s): %s", req.Delete().ID().String(), delErr.Error())
+					return nil, errors.New(str)
+				}
+			}
 
-							// create the request:
-							req := request.SDKFunc.Create(request.CreateParams{
-								ID:           &reqID,
-								FromUser:     usr,
-								SaveEntity:   toSaveIns,
-								DeleteEntity: toDelIns,
-								Reason:       ptr.Reason,
-								Keyname:      kname,
-							})
+			// create then save the completed request:
+			completedReq := completed.SDKFunc.Create(completed.CreateParams{
+				Request:         req,
+				ConcensusNeeded: neededConcensus,
+				Approved:        approved,
+			})
 
-							// create the active request:
-							var activeReq active_request.Request
-							keyname := wrReq.RequestedBy().MetaData().Keyname()
-							if keyname == token.SDKFunc.CreateMetaData().Keyname() {
-								activeReq = active_request.SDKFunc.Create(active_request.CreateParams{
-									Request:         req,
-									ConcensusNeeded: gen.Info().ConcensusNeeded(),
-								})
-							}
+			completedRepresentation := completed.SDKFunc.CreateRepresentation()
+			saveCompletedReqErr := dep.entityService.Save(completedReq, completedRepresentation)
+			if saveCompletedReqErr != nil {
+				str := fmt.Sprintf("there was an error while saving a completed request: %s", saveCompletedReqErr.Error())
+				return nil, errors.New(str)
+			}
 
-							if keyname == wallet.SDKFunc.CreateMetaData().Keyname() {
-								activeReq = active_request.SDKFunc.Create(active_request.CreateParams{
-									Request:         req,
-									ConcensusNeeded: wal.ConcensusNeeded(),
-								})
-							}
+			// convert to json:
+			storable, storableErr := completedRepresentation.ToStorable()(completedReq)
+			if storableErr != nil {
+				return nil, storableErr
+			}
 
-							// save the active request:
-							activeRepresentation := active_request.SDKFunc.CreateRepresentation()
-							saveActiveReqErr := dep.entityService.Save(activeReq, activeRepresentation)
-							if saveActiveReqErr != nil {
-								return nil, saveActiveReqErr
-							}
+			jsData, jsDataErr := cdc.MarshalJSON(storable)
+			if jsDataErr != nil {
+				return nil, jsDataErr
+			}
 
-							// enable the voting on the request:
-							store.Roles().EnableWriteAccess(app.routerRoleKey, fmt.Sprintf("/%s/requests/%s", kname.Name(), activeReq.ID().String()))
+			// charge the fees, then return the response:
+			return app.chargeFees(dep, gen, req.From(), path, jsData)
+		},
+	}
+}
 
-							// convert to json:
-							storable, storableErr := activeRepresentation.ToStorable()(activeReq)
-							if storableErr != nil {
-								return nil, storableErr
-							}
+// createRequest converts the request JSON, made by the given PublicKey, to a request on the keyname of the route params
+func (app *core20181108) createRequest(dep *dependencies, from crypto.PublicKey, params map[string]string, requestJSON []byte) (*parsedRequest, error) {
+	// convert the data to the incoming request:
+	ptr := new(incomingRequest)
+	jsErr := cdc.UnmarshalJSON(requestJSON, ptr)
+	if jsErr != nil {
+		return nil, jsErr
+	}
 
-							jsData, jsDataErr := cdc.MarshalJSON(storable)
-							if jsDataErr != nil {
-								return nil, jsDataErr
-							}
+	// parse the walletID:
+	walletID, walletIDErr := uuid.FromString(ptr.WalletID)
+	if walletIDErr != nil {
+		str := fmt.Sprintf("the given walletID (%s) is invalid: %s", ptr.WalletID, walletIDErr.Error())
+		return nil, errors.New(str)
+	}
 
-							// retrieve the client:
-							client, clientErr := dep.userRepository.RetrieveByPubKey(from)
-							if clientErr != nil {
-								return nil, clientErr
-							}
+	// retrieve the wallet:
+	walIns, walInsErr := dep.entityRepository.RetrieveByID(app.meta.Wallet().MetaData(), &walletID)
+	if walInsErr != nil {
+		return nil, walInsErr
+	}
 
-							// retrieve the affiliate:
-							var aff affiliates.Affiliate
-							if client.HasBeenReferred() {
-								aff, _ = dep.affiliateRepository.RetrieveByWallet(client.Referral())
-							}
+	// parse the requestID:
+	reqID, reqIDErr := uuid.FromString(ptr.ID)
+	if reqIDErr != nil {
+		str := fmt.Sprintf("the given requestID (%s) is invalid: %s", ptr.ID, reqIDErr.Error())
+		return nil, errors.New(str)
+	}
 
-							vals, valsErr := dep.validatorRepository.RetrieveSetOrderedByPledgeAmount(0, gen.Info().MaxAmountOfValidators())
-							if valsErr != nil {
-								return nil, valsErr
-							}
+	// retrieve the user:
+	usr, usrErr := dep.userRepository.RetrieveByPubKey(from)
+	if usrErr != nil {
+		str := fmt.Sprintf("the requester PublicKey (%s) is not a valid user", from.String())
+		return nil, errors.New(str)
+	}
 
-							// create the fees:
-							fee := fees.SDKFunc.Create(fees.CreateParams{
-								Gen:        gen,
-								StoredData: jsData,
-								Client:     client,
-								Affiliate:  aff,
-								Validators: vals,
-							})
+	wal, ok := walIns.(wallet.Wallet)
+	if !ok {
+		str := fmt.Sprintf("the entity (ID: %s) was expected to be a wallet instance", walIns.ID().String())
+		return nil, errors.New(str)
+	}
 
-							// save the fees:
-							saveFeesErr := dep.entityService.Save(fee, fees.SDKFunc.CreateRepresentation())
-							if saveFeesErr != nil {
-								return nil, saveFeesErr
-							}
+	keynameName, ok := params["keyname"]
+	if !ok {
+		return nil, errors.New("the keyname is mandatory")
+	}
 
-							// return the response:
-							resp := routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
-								Code:    routers.IsSuccessful,
-								Log:     "success",
-								GazUsed: int64(fee.Client().Amount()),
-								Tags: map[string][]byte{
-									path: jsData,
-								},
-							})
+	// retrieve the keyname by name:
+	kname, knameErr := dep.keynameRepository.RetrieveByName(keynameName)
+	if knameErr != nil {
+		str := fmt.Sprintf("the keyname (name: %s) is invalid: %s", keynameName, knameErr.Error())
+		return nil, errors.New(str)
+	}
 
-							return resp, nil
-						}
+	// retrieve the representation:
+	wrReq, ok := app.meta.WriteOnEntityRequest()[kname.Group().Name()]
+	if !ok {
+		str := fmt.Sprintf("the group (%s) is not supported for requests", kname.Group().Name())
+		return nil, errors.New(str)
+	}
 
-						str := fmt.Sprintf("the keyname (%s) is not supported on the group (%s) for requests", kname.Name(), kname.Group().Name())
-						return nil, errors.New(str)
-					}
+	representation, ok := wrReq.Map()[kname.Name()]
+	if !ok {
+		str := fmt.Sprintf("the keyname (%s) is not supported on the group (%s) for requests", kname.Name(), kname.Group().Name())
+		return nil, errors.New(str)
+	}
 
-					str := fmt.Sprintf("the group (%s) is not supported for requests", kname.Group().Name())
-					return nil, errors.New(str)
-				}
+	// instances:
+	var toSaveIns entity.Entity
+	var toDelIns entity.Entity
 
-				return nil, errors.New("the keyname is mandatory")
-			}
+	// if the request is a save entity:
+	if ptr.SaveEntityJSON != nil {
+		saveIns, saveInsErr := representation.MetaData().ToEntity()(dep.entityRepository, ptr.SaveEntityJSON)
+		if saveInsErr != nil {
+			return nil, saveInsErr
+		}
 
-			str := fmt.Sprintf("the entity (ID: %s) was expected to be a wallet instance", walIns.ID().String())
-			return nil, errors.New(str)
-		},
+		toSaveIns = saveIns
+	}
+
+	// if the request is a delete entity:
+	if ptr.DeleteEntityJSON != nil {
+		delIns, delInsErr := representation.MetaData().ToEntity()(dep.entityRepository, ptr.DeleteEntityJSON)
+		if delInsErr != nil {
+			return nil, delInsErr
+		}
+
+		toDelIns = delIns
+	}
+
+	// create the request:
+	req := request.SDKFunc.Create(request.CreateParams{
+		ID:           &reqID,
+		FromUser:     usr,
+		SaveEntity:   toSaveIns,
+		DeleteEntity: toDelIns,
+		Reason:       ptr.Reason,
+		Keyname:      kname,
+	})
+
+	out := parsedRequest{
+		req:            req,
+		wal:            wal,
+		entityReq:      wrReq,
+		representation: representation,
+	}
+
+	return &out, nil
+}
+
+// chargeFees saves the fees that the client pays for the stored data, then returns the successful response
+func (app *core20181108) chargeFees(dep *dependencies, gen genesis.Genesis, client user.User, path string, jsData []byte) (routers.TransactionResponse, error) {
+	// retrieve the affiliate:
+	var aff affiliates.Affiliate
+	if client.HasBeenReferred() {
+		aff, _ = dep.affiliateRepository.RetrieveByWallet(client.Referral())
+	}
+
+	vals, valsErr := dep.validatorRepository.RetrieveSetOrderedByPledgeAmount(0, gen.Info().MaxAmountOfValidators())
+	if valsErr != nil {
+		return nil, valsErr
 	}
+
+	// create the fees:
+	fee := fees.SDKFunc.Create(fees.CreateParams{
+		Gen:        gen,
+		StoredData: jsData,
+		Client:     client,
+		Affiliate:  aff,
+		Validators: vals,
+	})
+
+	// save the fees:
+	saveFeesErr := dep.entityService.Save(fee, fees.SDKFunc.CreateRepresentation())
+	if saveFeesErr != nil {
+		return nil, saveFeesErr
+	}
+
+	// return the response:
+	resp := routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+		Code:    routers.IsSuccessful,
+		Log:     "success",
+		GazUsed: int64(fee.Client().Amount()),
+		Tags: map[string][]byte{
+			path: jsData,
+		},
+	})
+
+	return resp, nil
 }
 
 func (app *core20181108) saveEntityRequestVote() routers.CreateRouteParams {
@@ -845,55 +1041,113 @@ func (app *core20181108) saveEntityRequestVote() routers.CreateRouteParams {
 							return nil, jsErr
 						}
 
-						voterID, voterIDErr := uuid.FromString(ptr.UserID)
-						if voterIDErr != nil {
-							return nil, voterIDErr
-						}
-
-						// retrieve the voter:
-						voter, voterErr := dep.userRepository.RetrieveByID(&voterID)
-						if voterErr != nil {
-							return nil, voterErr
-						}
-
 						voteID, voteIDErr := uuid.FromString(ptr.ID)
 						if voteIDErr != nil {
 							str := fmt.Sprintf("the given voteID (%s) is invalid: %s", ptr.ID, voteIDErr.Error())
 							return nil, errors.New(str)
 						}
 
-						// create the vote:
-						voteIns := vote.SDKFunc.Create(vote.CreateParams{
-							ID:         &voteID,
-							Request:    req,
-							Voter:      voter,
-							Reason:     ptr.Reason,
-							IsNeutral:  ptr.IsNeutral,
-							IsApproved: ptr.IsApproved,
-						})
-
 						representations := entityRequest.Map()
 						if representation, ok := representations[keynameName]; ok {
 							// create the active vote:
 							var activeVote active_vote.Vote
 							keyname := entityRequest.RequestedBy().MetaData().Keyname()
-							if keyname == token.SDKFunc.CreateMetaData().Keyname() {
-								balance, balanceErr := dep.balanceRepository.RetrieveByWallet(voter.Wallet())
-								if balanceErr != nil {
-									return nil, balanceErr
+
+							// if the vote is anonymous:
+							if ptr.RingSignature != "" {
+								// only the requests voted by the users of a wallet can be voted on anonymously:
+								if keyname != wallet.SDKFunc.CreateMetaData().Keyname() {
+									str := fmt.Sprintf("the keyname (name: %s) cannot be voted on anonymously, since its requests are not voted by the users of a wallet", keynameName)
+									return nil, errors.New(str)
+								}
+
+								// create the anonymous vote, which verifies its ring signature:
+								voteIns := vote.SDKFunc.Create(vote.CreateParams{
+									ID:      &voteID,
+									Request: req,
+									RingSignature: crypto.SDKFunc.CreateLinkableRingSig(crypto.CreateLinkableRingSigParams{
+										LinkableRingSigAsString: ptr.RingSignature,
+									}),
+									Reason:     ptr.Reason,
+									IsNeutral:  ptr.IsNeutral,
+									IsApproved: ptr.IsApproved,
+								})
+
+								// retrieve the users of the wallet:
+								wal := req.Request().From().Wallet()
+								usersPS, usersPSErr := dep.userRepository.RetrieveSetByWallet(wal, 0, -1)
+								if usersPSErr != nil {
+									return nil, usersPSErr
+								}
+
+								// every member of the ring must be a user of the wallet.  Since the voter cannot be identified,
+								// the power of the vote is the lowest amount of shares of the members of the ring:
+								power := -1
+								for _, oneMember := range voteIns.RingSignature().Ring() {
+									isUser := false
+									for _, oneUserIns := range usersPS.Instances() {
+										if oneUser, ok := oneUserIns.(user.User); ok && oneUser.PubKey().Equals(oneMember) {
+											if power == -1 || oneUser.Shares() < power {
+												power = oneUser.Shares()
+											}
+
+											isUser = true
+											break
+										}
+									}
+
+									if !isUser {
+										str := fmt.Sprintf("the ring member (PubKey: %s) is not a user of the wallet (ID: %s)", oneMember.String(), wal.ID().String())
+										return nil, errors.New(str)
+									}
 								}
 
 								activeVote = active_vote.SDKFunc.Create(active_vote.CreateParams{
 									Vote:  voteIns,
-									Power: balance.Amount(),
+									Power: power,
 								})
 							}
 
-							if keyname == wallet.SDKFunc.CreateMetaData().Keyname() {
-								activeVote = active_vote.SDKFunc.Create(active_vote.CreateParams{
-									Vote:  voteIns,
-									Power: voter.Shares(),
+							if ptr.RingSignature == "" {
+								voterID, voterIDErr := uuid.FromString(ptr.UserID)
+								if voterIDErr != nil {
+									return nil, voterIDErr
+								}
+
+								// retrieve the voter:
+								voter, voterErr := dep.userRepository.RetrieveByID(&voterID)
+								if voterErr != nil {
+									return nil, voterErr
+								}
+
+								// create the vote:
+								voteIns := vote.SDKFunc.Create(vote.CreateParams{
+									ID:         &voteID,
+									Request:    req,
+									Voter:      voter,
+									Reason:     ptr.Reason,
+									IsNeutral:  ptr.IsNeutral,
+									IsApproved: ptr.IsApproved,
 								})
+
+								if keyname == token.SDKFunc.CreateMetaData().Keyname() {
+									balance, balanceErr := dep.balanceRepository.RetrieveByWallet(voter.Wallet())
+									if balanceErr != nil {
+										return nil, balanceErr
+									}
+
+									activeVote = active_vote.SDKFunc.Create(active_vote.CreateParams{
+										Vote:  voteIns,
+										Power: balance.Amount(),
+									})
+								}
+
+								if keyname == wallet.SDKFunc.CreateMetaData().Keyname() {
+									activeVote = active_vote.SDKFunc.Create(active_vote.CreateParams{
+										Vote:  voteIns,
+										Power: voter.Shares(),
+									})
+								}
 							}
 
 							saveErr := dep.voteService.Save(activeVote, representation)
@@ -973,3 +1227,19 @@ func (app *core20181108) saveEntityRequestVote() routers.CreateRouteParams {
 		},
 	}
 }
+
+// fromStringToMultiSig parses the multi-signature of a request, which is given by a user and can therefore be malformed:
+func fromStringToMultiSig(str string) (out crypto.MultiSignature, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the multi-signature of the request is invalid: %v", r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreateMultiSig(crypto.CreateMultiSigParams{
+		MultiSigAsString: str,
+	})
+
+	return out, nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request"
 	active_request "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active/vote"
+	active_vote "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active/vote/active"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/keyname"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/deposit"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token/entities/category"
@@ -53,6 +54,33 @@ func TestSaveGenesis_Success(t *testing.T) {
 	defer node.Stop()
 }
 
+func TestSaveGenesis_withMemoryClient_Success(t *testing.T) {
+	// variables:
+	pk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
+	genIns := genesis.CreateGenesisWithPubKeyForTests(pk.PublicKey())
+	representation := genesis.SDKFunc.CreateRepresentation()
+
+	// create the in-memory blockchain:
+	_, service, repository := createMemoryBlockchainForTests(pk)
+
+	// save the genesis:
+	saveErr := service.Save(genIns, representation)
+	if saveErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", saveErr.Error())
+		return
+	}
+
+	// retrieve the genesis:
+	retGen, retGenErr := repository.RetrieveByID(representation.MetaData(), genIns.ID())
+	if retGenErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retGenErr.Error())
+		return
+	}
+
+	// compare the genesis instances:
+	genesis.CompareGenesisForTests(t, genIns, retGen.(genesis.Genesis))
+}
+
 func TestSaveGenesis_thenRespawnBlockchain_Success(t *testing.T) {
 	// variables:
 	pk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
@@ -437,6 +465,330 @@ func TestSaveGenesis_addUserToWallet_increaseTheNeededConcensus_voteUsingTwoUser
 	})
 }
 
+func TestSaveGenesis_addUserToWallet_increaseTheNeededConcensus_approveWithMultiSig_Success(t *testing.T) {
+	// variables:
+	pk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
+	pubKey := pk.PublicKey()
+	genIns := genesis.CreateGenesisWithPubKeyForTests(pubKey)
+
+	wal := genIns.User().Wallet()
+	userPK := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
+	userIns := user.CreateUserWithWalletAndPublicKeyForTests(wal, userPK.PublicKey())
+	rootPath := filepath.Join("./test_files_TestSaveGenesis_addUserToWallet_increaseTheNeededConcensus_approveWithMultiSig_Success")
+	defer func() {
+		os.RemoveAll(rootPath)
+	}()
+
+	// create the representations:
+	userRepresentation := user.SDKFunc.CreateRepresentation()
+	walletRepresentation := wallet.SDKFunc.CreateRepresentation()
+
+	// spawn bockchain with genesis instance:
+	node, client, _, repository := spawnBlockchainWithGenesisForTests(t, pk, rootPath, genIns)
+	defer node.Stop()
+
+	// retrieve the keynames:
+	knameRepository := keyname.SDKFunc.CreateRepository(keyname.CreateRepositoryParams{
+		EntityRepository: repository,
+	})
+
+	kname, knameErr := knameRepository.RetrieveByName(user.SDKFunc.CreateMetaData().Keyname())
+	if knameErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", knameErr.Error())
+		return
+	}
+
+	walletKname, walletKnameErr := knameRepository.RetrieveByName(wallet.SDKFunc.CreateMetaData().Keyname())
+	if walletKnameErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", walletKnameErr.Error())
+		return
+	}
+
+	// add the user in the wallet:
+	saveRequestThenSaveVotesForTests(t, client, pk, repository, userRepresentation, request.SDKFunc.Create(request.CreateParams{
+		FromUser:   genIns.User(),
+		SaveEntity: userIns,
+		Reason:     "TEST",
+		Keyname:    kname,
+	}), []crypto.PrivateKey{pk}, []*simpleRequestVote{
+		&simpleRequestVote{
+			Voter:      genIns.User(),
+			IsApproved: true,
+		},
+	})
+
+	// increase the concensus, so that both users are needed:
+	saveRequestThenSaveVotesForTests(t, client, pk, repository, walletRepresentation, request.SDKFunc.Create(request.CreateParams{
+		FromUser: genIns.User(),
+		SaveEntity: wallet.SDKFunc.Create(wallet.CreateParams{
+			ID:              wal.ID(),
+			Creator:         wal.Creator(),
+			ConcensusNeeded: genIns.User().Shares() + userIns.Shares(),
+		}),
+		Reason:  "TEST",
+		Keyname: walletKname,
+	}), []crypto.PrivateKey{pk}, []*simpleRequestVote{
+		&simpleRequestVote{
+			Voter:      genIns.User(),
+			IsApproved: true,
+		},
+	})
+
+	// decrease the concensus, in a single transaction approved by both users:
+	decreasedWallet := wallet.SDKFunc.Create(wallet.CreateParams{
+		ID:              wal.ID(),
+		Creator:         wal.Creator(),
+		ConcensusNeeded: genIns.User().Shares(),
+	})
+
+	updateWalletRequest := request.SDKFunc.Create(request.CreateParams{
+		FromUser:   genIns.User(),
+		SaveEntity: decreasedWallet,
+		Reason:     "TEST",
+		Keyname:    walletKname,
+	})
+
+	requestService := request.SDKFunc.CreateSDKService(request.CreateSDKServiceParams{
+		PK:     pk,
+		Client: client,
+	})
+
+	msg, msgErr := requestService.MultiSigMessage(updateWalletRequest, walletRepresentation)
+	if msgErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", msgErr.Error())
+		return
+	}
+
+	// the genesis user alone does not have enough shares:
+	genMultiSig := crypto.SDKFunc.CreateMultiSig(crypto.CreateMultiSigParams{
+		Sigs: []crypto.Signature{
+			pk.SignBytes(request.MultiSigDomain, msg),
+		},
+	})
+
+	notEnoughErr := requestService.SaveWithMultiSig(updateWalletRequest, walletRepresentation, genMultiSig)
+	if notEnoughErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// both users have enough shares:
+	multiSig := genMultiSig.Add(userPK.SignBytes(request.MultiSigDomain, msg))
+	saveErr := requestService.SaveWithMultiSig(updateWalletRequest, walletRepresentation, multiSig)
+	if saveErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", saveErr.Error())
+		return
+	}
+
+	// the wallet should be updated:
+	retWallet, retWalletErr := repository.RetrieveByID(walletRepresentation.MetaData(), wal.ID())
+	if retWalletErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retWalletErr.Error())
+		return
+	}
+
+	wallet.CompareWalletsForTests(t, decreasedWallet, retWallet.(wallet.Wallet))
+
+	// the same request cannot be replayed:
+	replayErr := requestService.SaveWithMultiSig(updateWalletRequest, walletRepresentation, multiSig)
+	if replayErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestSaveGenesis_addUserToWallet_increaseTheNeededConcensus_voteAnonymously_Success(t *testing.T) {
+	// variables:
+	pk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
+	pubKey := pk.PublicKey()
+	genIns := genesis.CreateGenesisWithPubKeyForTests(pubKey)
+
+	wal := genIns.User().Wallet()
+	userPK := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
+	userIns := user.CreateUserWithWalletAndPublicKeyAndSharesForTests(wal, userPK.PublicKey(), genIns.User().Shares())
+	outsiderPK := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
+	rootPath := filepath.Join("./test_files_TestSaveGenesis_addUserToWallet_increaseTheNeededConcensus_voteAnonymously_Success")
+	defer func() {
+		os.RemoveAll(rootPath)
+	}()
+
+	// create the representations:
+	userRepresentation := user.SDKFunc.CreateRepresentation()
+	walletRepresentation := wallet.SDKFunc.CreateRepresentation()
+
+	// spawn bockchain with genesis instance:
+	node, client, _, repository := spawnBlockchainWithGenesisForTests(t, pk, rootPath, genIns)
+	defer node.Stop()
+
+	// retrieve the keynames:
+	knameRepository := keyname.SDKFunc.CreateRepository(keyname.CreateRepositoryParams{
+		EntityRepository: repository,
+	})
+
+	kname, knameErr := knameRepository.RetrieveByName(user.SDKFunc.CreateMetaData().Keyname())
+	if knameErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", knameErr.Error())
+		return
+	}
+
+	walletKname, walletKnameErr := knameRepository.RetrieveByName(wallet.SDKFunc.CreateMetaData().Keyname())
+	if walletKnameErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", walletKnameErr.Error())
+		return
+	}
+
+	// add the user in the wallet:
+	saveRequestThenSaveVotesForTests(t, client, pk, repository, userRepresentation, request.SDKFunc.Create(request.CreateParams{
+		FromUser:   genIns.User(),
+		SaveEntity: userIns,
+		Reason:     "TEST",
+		Keyname:    kname,
+	}), []crypto.PrivateKey{pk}, []*simpleRequestVote{
+		&simpleRequestVote{
+			Voter:      genIns.User(),
+			IsApproved: true,
+		},
+	})
+
+	// increase the concensus, so that both users are needed:
+	saveRequestThenSaveVotesForTests(t, client, pk, repository, walletRepresentation, request.SDKFunc.Create(request.CreateParams{
+		FromUser: genIns.User(),
+		SaveEntity: wallet.SDKFunc.Create(wallet.CreateParams{
+			ID:              wal.ID(),
+			Creator:         wal.Creator(),
+			ConcensusNeeded: genIns.User().Shares() + userIns.Shares(),
+		}),
+		Reason:  "TEST",
+		Keyname: walletKname,
+	}), []crypto.PrivateKey{pk}, []*simpleRequestVote{
+		&simpleRequestVote{
+			Voter:      genIns.User(),
+			IsApproved: true,
+		},
+	})
+
+	// decrease the concensus, using anonymous votes:
+	decreasedWallet := wallet.SDKFunc.Create(wallet.CreateParams{
+		ID:              wal.ID(),
+		Creator:         wal.Creator(),
+		ConcensusNeeded: genIns.User().Shares(),
+	})
+
+	updateWalletRequest := request.SDKFunc.Create(request.CreateParams{
+		FromUser:   genIns.User(),
+		SaveEntity: decreasedWallet,
+		Reason:     "TEST",
+		Keyname:    walletKname,
+	})
+
+	requestService := request.SDKFunc.CreateSDKService(request.CreateSDKServiceParams{
+		PK:     pk,
+		Client: client,
+	})
+
+	saveRequestErr := requestService.Save(updateWalletRequest, walletRepresentation)
+	if saveRequestErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", saveRequestErr.Error())
+		return
+	}
+
+	requestRepository := active_request.SDKFunc.CreateRepository(active_request.CreateRepositoryParams{
+		EntityRepository: repository,
+	})
+
+	retRequest, retRequestErr := requestRepository.RetrieveByRequest(updateWalletRequest)
+	if retRequestErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retRequestErr.Error())
+		return
+	}
+
+	// the votes are relayed by the genesis user:
+	voteService := vote.SDKFunc.CreateSDKService(vote.CreateSDKServiceParams{
+		PK:     pk,
+		Client: client,
+	})
+
+	voteRepository := active_vote.SDKFunc.CreateRepository(active_vote.CreateRepositoryParams{
+		EntityRepository: repository,
+	})
+
+	msg := vote.SDKFunc.CreateAnonymousMessage(vote.CreateAnonymousMessageParams{
+		Request:    retRequest,
+		Reason:     "TEST",
+		IsApproved: true,
+	})
+
+	createAnonymousVote := func(votePK crypto.PrivateKey, ring []crypto.PublicKey) vote.Vote {
+		ringSig, ringSigErr := votePK.LinkableRingSignBytes(vote.AnonymousDomain, []byte(msg), ring)
+		if ringSigErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", ringSigErr.Error())
+			return nil
+		}
+
+		return vote.SDKFunc.Create(vote.CreateParams{
+			Request:       retRequest,
+			RingSignature: ringSig,
+			Reason:        "TEST",
+			IsApproved:    true,
+		})
+	}
+
+	ring := []crypto.PublicKey{
+		pubKey,
+		userPK.PublicKey(),
+	}
+
+	// the newly added user votes anonymously:
+	userVote := createAnonymousVote(userPK, ring)
+	saveUserVoteErr := voteService.Save(userVote, walletRepresentation)
+	if saveUserVoteErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", saveUserVoteErr.Error())
+		return
+	}
+
+	retVote, retVoteErr := voteRepository.RetrieveByVote(userVote)
+	if retVoteErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retVoteErr.Error())
+		return
+	}
+
+	vote.CompareVoteForTests(t, userVote, retVote.Vote())
+
+	// the newly added user cannot vote twice, even anonymously:
+	doubleVoteErr := voteService.Save(createAnonymousVote(userPK, ring), walletRepresentation)
+	if doubleVoteErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// a ring containing a PublicKey that is not a user of the wallet cannot vote:
+	outsiderVoteErr := voteService.Save(createAnonymousVote(pk, []crypto.PublicKey{
+		pubKey,
+		outsiderPK.PublicKey(),
+	}), walletRepresentation)
+
+	if outsiderVoteErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// the genesis user votes anonymously, which reaches the concensus:
+	saveGenVoteErr := voteService.Save(createAnonymousVote(pk, ring), walletRepresentation)
+	if saveGenVoteErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", saveGenVoteErr.Error())
+		return
+	}
+
+	// the wallet should be updated:
+	retWallet, retWalletErr := repository.RetrieveByID(walletRepresentation.MetaData(), wal.ID())
+	if retWalletErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retWalletErr.Error())
+		return
+	}
+
+	wallet.CompareWalletsForTests(t, decreasedWallet, retWallet.(wallet.Wallet))
+}
+
 func TestSaveGenesis_createNewWallet_createPledge_transferPledgeTokens_returnsError(t *testing.T) {
 	// variables:
 	pk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{})
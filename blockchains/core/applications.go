@@ -16,12 +16,13 @@ func createApplications(
 	routerRoleKey string,
 	ds datastore.StoredDataStore,
 	met meta.Meta,
+	onRoute applications.OnRoute,
 ) applications.Applications {
 
 	// create the applications:
 	apps := applications.SDKFunc.CreateApplications(applications.CreateApplicationsParams{
 		Apps: []applications.Application{
-			create20181106(namespace, name, id, 0, -1, rootDir, routerRoleKey, ds, met),
+			create20181106(namespace, name, id, 0, -1, rootDir, routerRoleKey, ds, met, onRoute),
 		},
 	})
 
@@ -37,12 +38,13 @@ func createApplicationsWithRootPubKey(
 	ds datastore.StoredDataStore,
 	met meta.Meta,
 	rootPubKey crypto.PublicKey,
+	onRoute applications.OnRoute,
 ) applications.Applications {
 
 	// create the applications:
 	apps := applications.SDKFunc.CreateApplications(applications.CreateApplicationsParams{
 		Apps: []applications.Application{
-			create20181106WithRootPubKey(namespace, name, id, 0, -1, rootDir, routerRoleKey, ds, met, rootPubKey),
+			create20181106WithRootPubKey(namespace, name, id, 0, -1, rootDir, routerRoleKey, ds, met, rootPubKey, onRoute),
 		},
 	})
 
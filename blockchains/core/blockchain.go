@@ -60,7 +60,7 @@ func spawnBlockchain(
 
 	// create the applications:
 	routerRoleKey := "router-role"
-	apps := createApplications(namespace, name, id, rootDirPath, routerRoleKey, store, met)
+	apps := createApplications(namespace, name, id, rootDirPath, routerRoleKey, store, met, nil)
 
 	// create the application service:
 	appService := tendermint.SDKFunc.CreateApplicationService()
@@ -108,7 +108,7 @@ func saveThenSpawnBlockchain(
 
 	// create the applications:
 	routerRoleKey := "router-role"
-	apps := createApplicationsWithRootPubKey(namespace, name, id, rootDirPath, routerRoleKey, store, met, rootPubKey)
+	apps := createApplicationsWithRootPubKey(namespace, name, id, rootDirPath, routerRoleKey, store, met, rootPubKey, nil)
 
 	// create the application service:
 	appService := tendermint.SDKFunc.CreateApplicationService()
@@ -10,17 +10,13 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/keyname"
 	"github.com/xmnservices/xmnsuite/blockchains/tendermint"
-	"github.com/xmnservices/xmnsuite/configs"
 )
 
 func processWalletRequest(c *cliapp.Context, representation entity.Representation, storable interface{}) (request.Normalized, error) {
-	// retrieve the configurations:
-	fileAsString := c.String("file")
-	confRepository := configs.SDKFunc.CreateRepository()
-	conf, confErr := confRepository.Retrieve(fileAsString, c.String("pass"))
-	if confErr != nil {
-		str := fmt.Sprintf("the given file (%s) either does not exist or the given password is invalid", fileAsString)
-		return nil, errors.New(str)
+	// retrieve the signer:
+	sig, sigErr := retrieveSigner(c)
+	if sigErr != nil {
+		return nil, sigErr
 	}
 
 	// metadata:
@@ -33,7 +29,7 @@ func processWalletRequest(c *cliapp.Context, representation entity.Representatio
 
 	// create the repositories:
 	entityRepository := entity.SDKFunc.CreateSDKRepository(entity.CreateSDKRepositoryParams{
-		PK:     conf.WalletPK(),
+		Signer: sig,
 		Client: client,
 	})
 
@@ -45,12 +41,6 @@ func processWalletRequest(c *cliapp.Context, representation entity.Representatio
 		EntityRepository: entityRepository,
 	})
 
-	// create the services:
-	requestService := request.SDKFunc.CreateSDKService(request.CreateSDKServiceParams{
-		PK:     conf.WalletPK(),
-		Client: client,
-	})
-
 	// convert the storable to an entity:
 	ent, entErr := metaData.ToEntity()(entityRepository, storable)
 	if entErr != nil {
@@ -59,7 +49,11 @@ func processWalletRequest(c *cliapp.Context, representation entity.Representatio
 	}
 
 	// retrieve the from user:
-	pubKey := conf.WalletPK().PublicKey()
+	pubKey, pubKeyErr := retrieveFromPubKey(c, sig)
+	if pubKeyErr != nil {
+		return nil, pubKeyErr
+	}
+
 	fromUser, fromUserErr := userRepository.RetrieveByPubKey(pubKey)
 	if fromUserErr != nil {
 		str := fmt.Sprintf("there was an error while retrieving a user (pubKey: %s): %s", pubKey.String(), fromUserErr.Error())
@@ -82,7 +76,7 @@ func processWalletRequest(c *cliapp.Context, representation entity.Representatio
 	})
 
 	// save the request:
-	saveErr := requestService.Save(req, representation)
+	saveErr := submitRequest(c, sig, client, req, representation)
 	if saveErr != nil {
 		str := fmt.Sprintf("there was an error while saving a request instance: %s", saveErr.Error())
 		return nil, errors.New(str)
@@ -1,23 +1,55 @@
 package helpers
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 
 	cliapp "github.com/urfave/cli"
 	"github.com/xmnservices/xmnsuite/blockchains/applications"
 	"github.com/xmnservices/xmnsuite/blockchains/tendermint"
 	"github.com/xmnservices/xmnsuite/configs"
+	"github.com/xmnservices/xmnsuite/keystore"
 )
 
 func retrieveConfWithClient(c *cliapp.Context) (configs.Configs, applications.Client, error) {
 	// retrieve the configurations:
-	fileAsString := c.String("file")
-	confRepository := configs.SDKFunc.CreateRepository()
-	conf, confErr := confRepository.Retrieve(fileAsString, c.String("pass"))
+	conf, confErr := retrieveConf(c)
 	if confErr != nil {
-		str := fmt.Sprintf("the given file (%s) either does not exist or the given password is invalid", fileAsString)
-		return nil, nil, errors.New(str)
+		return nil, nil, confErr
+	}
+
+	// create the blockchain client:
+	client := createClient(c)
+	return conf, client, nil
+}
+
+func retrieveConf(c *cliapp.Context) (conf configs.Configs, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			conf = nil
+			err = fmt.Errorf("the key (%s) could not be retrieved from the keystore: %v", c.String("key"), r)
+		}
+	}()
+
+	conf = keystore.SDKFunc.RetrieveConfigs(keystore.RetrieveConfigsParams{
+		Dir:  c.String("keystore"),
+		Name: c.String("key"),
+		Pass: c.String("pass"),
+	})
+
+	return conf, nil
+}
+
+func createClient(c *cliapp.Context) applications.Client {
+	// if there is more than one host, create a multi client:
+	hosts := strings.Split(c.String("host"), ",")
+	if len(hosts) > 1 {
+		client := tendermint.SDKFunc.CreateMultiClient(tendermint.CreateMultiClientParams{
+			Addresses: hosts,
+			Strategy:  tendermint.LowestLatency,
+		})
+
+		return client
 	}
 
 	// create the blockchain client:
@@ -25,5 +57,5 @@ func retrieveConfWithClient(c *cliapp.Context) (configs.Configs, applications.Cl
 		IPAsString: c.String("host"),
 	})
 
-	return conf, client, nil
+	return client
 }
@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/signer"
+)
+
+func retrieveSignerWithClient(c *cliapp.Context) (crypto.Signer, applications.Client, error) {
+	// retrieve the signer:
+	sig, sigErr := retrieveSigner(c)
+	if sigErr != nil {
+		return nil, nil, sigErr
+	}
+
+	// create the blockchain client:
+	client := createClient(c)
+	return sig, client, nil
+}
+
+func retrieveSigner(c *cliapp.Context) (crypto.Signer, error) {
+	// an unsigned transaction is built without any key, the queries are signed by a throwaway key:
+	if c.String("unsigned") != "" {
+		out := crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+			PK: crypto.SDKFunc.GenPK(),
+		})
+
+		return out, nil
+	}
+
+	// without a signer daemon, the key is retrieved from the keystore:
+	address := c.String("signer")
+	if address == "" {
+		conf, confErr := retrieveConf(c)
+		if confErr != nil {
+			return nil, confErr
+		}
+
+		out := crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+			PK: conf.WalletPK(),
+		})
+
+		return out, nil
+	}
+
+	return retrieveRemoteSigner(c, address)
+}
+
+func retrieveRemoteSigner(c *cliapp.Context, address string) (out crypto.Signer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the key (%s) could not be retrieved from the signer daemon (%s): %v", c.String("key"), address, r)
+		}
+	}()
+
+	out = signer.SDKFunc.CreateRemote(signer.CreateRemoteParams{
+		Address: address,
+		Token:   c.String("signertoken"),
+		Key:     c.String("key"),
+	})
+
+	return out, nil
+}
+
+func retrieveFromPubKey(c *cliapp.Context, sig crypto.Signer) (out crypto.PublicKey, err error) {
+	// the PublicKey of an unsigned transaction is the one of the key that will sign it offline:
+	if c.String("unsigned") == "" {
+		return sig.PublicKey(), nil
+	}
+
+	from := c.String("from")
+	if from == "" {
+		return nil, errors.New("the from PublicKey is mandatory in order to create an unsigned transaction")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the from PublicKey (%s) is invalid: %v", from, r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+		PubKeyAsString: from,
+	})
+
+	return out, nil
+}
@@ -12,22 +12,16 @@ import (
 )
 
 func saveRequest(c *cliapp.Context, entityRepresentation entity.Representation, saveIns entity.Entity, delIns entity.Entity) (request.Request, error) {
-	// retrieve conf with client:
-	conf, client, confErr := retrieveConfWithClient(c)
-	if confErr != nil {
-		str := fmt.Sprintf("there was an error while retrieving the conf instance: %s", confErr.Error())
+	// retrieve signer with client:
+	sig, client, sigErr := retrieveSignerWithClient(c)
+	if sigErr != nil {
+		str := fmt.Sprintf("there was an error while retrieving the signer instance: %s", sigErr.Error())
 		return nil, errors.New(str)
 	}
 
-	// create the request service:
-	reqService := request.SDKFunc.CreateSDKService(request.CreateSDKServiceParams{
-		PK:     conf.WalletPK(),
-		Client: client,
-	})
-
 	// create the repositories:
 	entityRepository := entity.SDKFunc.CreateSDKRepository(entity.CreateSDKRepositoryParams{
-		PK:     conf.WalletPK(),
+		Signer: sig,
 		Client: client,
 	})
 
@@ -40,9 +34,14 @@ func saveRequest(c *cliapp.Context, entityRepresentation entity.Representation,
 	})
 
 	// retrieve my user:
-	fromUser, fromUserErr := userRepository.RetrieveByPubKey(conf.WalletPK().PublicKey())
+	fromPubKey, fromPubKeyErr := retrieveFromPubKey(c, sig)
+	if fromPubKeyErr != nil {
+		return nil, fromPubKeyErr
+	}
+
+	fromUser, fromUserErr := userRepository.RetrieveByPubKey(fromPubKey)
 	if fromUserErr != nil {
-		str := fmt.Sprintf("there was an error while retrieving the user (PubKey: %s): %s", conf.WalletPK().PublicKey(), fromUserErr.Error())
+		str := fmt.Sprintf("there was an error while retrieving the user (PubKey: %s): %s", fromPubKey, fromUserErr.Error())
 		return nil, errors.New(str)
 	}
 
@@ -62,7 +61,7 @@ func saveRequest(c *cliapp.Context, entityRepresentation entity.Representation,
 		Keyname:      kname,
 	})
 
-	saveErr := reqService.Save(newReq, entityRepresentation)
+	saveErr := submitRequest(c, sig, client, newReq, entityRepresentation)
 	if saveErr != nil {
 		str := fmt.Sprintf("there was an error while saving the request: %s", saveErr.Error())
 		return nil, errors.New(str)
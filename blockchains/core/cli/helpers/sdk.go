@@ -9,6 +9,7 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request"
 	"github.com/xmnservices/xmnsuite/configs"
+	"github.com/xmnservices/xmnsuite/crypto"
 )
 
 // ProcessWalletRequestParams represents the proces wallet request params
@@ -23,6 +24,16 @@ type RetrieveConfWithClientParams struct {
 	CLIContext *cliapp.Context
 }
 
+// RetrieveSignerWithClientParams represents the retrieveSignerWithClient params
+type RetrieveSignerWithClientParams struct {
+	CLIContext *cliapp.Context
+}
+
+// RetrieveSignerParams represents the retrieveSigner params
+type RetrieveSignerParams struct {
+	CLIContext *cliapp.Context
+}
+
 // SaveRequestParams represents the SaveRequest params
 type SaveRequestParams struct {
 	CLIContext           *cliapp.Context
@@ -46,6 +57,8 @@ type PrintErrorParams struct {
 var SDKFunc = struct {
 	ProcessWalletRequest     func(params ProcessWalletRequestParams) request.Normalized
 	RetrieveConfWithClient   func(params RetrieveConfWithClientParams) (configs.Configs, applications.Client)
+	RetrieveSignerWithClient func(params RetrieveSignerWithClientParams) (crypto.Signer, applications.Client)
+	RetrieveSigner           func(params RetrieveSignerParams) crypto.Signer
 	SaveRequest              func(params SaveRequestParams) request.Request
 	PrintSuccessWithInstance func(params PrintSuccessWithInstanceParams)
 	PrintError               func(params PrintErrorParams)
@@ -66,6 +79,22 @@ var SDKFunc = struct {
 
 		return conf, client
 	},
+	RetrieveSignerWithClient: func(params RetrieveSignerWithClientParams) (crypto.Signer, applications.Client) {
+		sig, client, err := retrieveSignerWithClient(params.CLIContext)
+		if err != nil {
+			panic(err)
+		}
+
+		return sig, client
+	},
+	RetrieveSigner: func(params RetrieveSignerParams) crypto.Signer {
+		sig, err := retrieveSigner(params.CLIContext)
+		if err != nil {
+			panic(err)
+		}
+
+		return sig
+	},
 	SaveRequest: func(params SaveRequestParams) request.Request {
 		if params.SaveEntity != nil {
 			req, reqErr := saveRequest(params.CLIContext, params.EntityRepresentation, params.SaveEntity, nil)
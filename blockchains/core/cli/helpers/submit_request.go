@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request"
+	"github.com/xmnservices/xmnsuite/blockchains/offline"
+	"github.com/xmnservices/xmnsuite/crypto"
+)
+
+func submitRequest(c *cliapp.Context, sig crypto.Signer, client applications.Client, req request.Request, representation entity.Representation) error {
+	// without an unsigned transaction file, the request is signed and saved right away:
+	filePath := c.String("unsigned")
+	if filePath == "" {
+		reqService := request.SDKFunc.CreateSDKService(request.CreateSDKServiceParams{
+			Signer: sig,
+			Client: client,
+		})
+
+		return reqService.Save(req, representation)
+	}
+
+	trx, trxErr := createUnsignedTransaction(req, representation)
+	if trxErr != nil {
+		return trxErr
+	}
+
+	js, jsErr := json.MarshalIndent(trx, "", "    ")
+	if jsErr != nil {
+		return jsErr
+	}
+
+	writeErr := ioutil.WriteFile(filePath, js, 0644)
+	if writeErr != nil {
+		str := fmt.Sprintf("there was an error while writing the unsigned transaction file (%s): %s", filePath, writeErr.Error())
+		return errors.New(str)
+	}
+
+	return nil
+}
+
+func createUnsignedTransaction(req request.Request, representation entity.Representation) (out offline.Transaction, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("there was an error while creating the unsigned transaction: %v", r)
+		}
+	}()
+
+	res := request.SDKFunc.CreateResource(request.CreateResourceParams{
+		From:                 req.From().PubKey(),
+		Request:              req,
+		EntityRepresentation: representation,
+	})
+
+	out = offline.SDKFunc.CreateSave(offline.CreateSaveParams{
+		Resource: res,
+	})
+
+	return out, nil
+}
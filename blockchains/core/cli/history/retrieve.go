@@ -0,0 +1,90 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/helpers"
+	"github.com/xmnservices/xmnsuite/blockchains/history"
+	core_helpers "github.com/xmnservices/xmnsuite/helpers"
+)
+
+func retrieve() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "retrieve",
+		Aliases: []string{"r"},
+		Usage:   "Retrieves an indexed transaction by its hash",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "host",
+				Value: "",
+				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
+			},
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "hash",
+				Value: "",
+				Usage: "This is the hash of the transaction we want to retrieve",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					core_helpers.Print(str)
+				}
+			}()
+
+			// retrieve signer with client:
+			signer, client := helpers.SDKFunc.RetrieveSignerWithClient(helpers.RetrieveSignerWithClientParams{
+				CLIContext: c,
+			})
+
+			// create the repository:
+			historyRepository := history.SDKFunc.CreateRepository(history.CreateRepositoryParams{
+				Signer: signer,
+				Client: client,
+			})
+
+			// retrieve the transaction:
+			hash := c.String("hash")
+			trx, trxErr := historyRepository.RetrieveByHash(hash)
+			if trxErr != nil {
+				str := fmt.Sprintf("there was an error while retrieving the transaction (hash: %s): %s", hash, trxErr.Error())
+				panic(errors.New(str))
+			}
+
+			helpers.SDKFunc.PrintSuccessWithInstance(helpers.PrintSuccessWithInstanceParams{
+				Ins: trx,
+			})
+
+			// returns:
+			return nil
+		},
+	}
+}
@@ -0,0 +1,147 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/helpers"
+	"github.com/xmnservices/xmnsuite/blockchains/history"
+	"github.com/xmnservices/xmnsuite/crypto"
+	core_helpers "github.com/xmnservices/xmnsuite/helpers"
+)
+
+func retrieveList() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "retrieve-list",
+		Aliases: []string{"s"},
+		Usage:   "Retrieves a list of indexed transactions by signer, path, tag or resource",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "host",
+				Value: "",
+				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
+			},
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the public key of the signer of the transactions",
+			},
+			cliapp.StringFlag{
+				Name:  "path",
+				Value: "",
+				Usage: "This is the path of the transactions",
+			},
+			cliapp.StringFlag{
+				Name:  "tag",
+				Value: "",
+				Usage: "This is a tag of the transactions",
+			},
+			cliapp.StringFlag{
+				Name:  "resource",
+				Value: "",
+				Usage: "This is the ID of a resource (wallet, user, entity, etc) touched by the transactions",
+			},
+			cliapp.IntFlag{
+				Name:  "index",
+				Value: 0,
+				Usage: "The index of the transaction list",
+			},
+			cliapp.IntFlag{
+				Name:  "amount",
+				Value: 20,
+				Usage: "The amount of transactions to retrieve",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					core_helpers.Print(str)
+				}
+			}()
+
+			// retrieve signer with client:
+			signer, client := helpers.SDKFunc.RetrieveSignerWithClient(helpers.RetrieveSignerWithClientParams{
+				CLIContext: c,
+			})
+
+			// create the repository:
+			historyRepository := history.SDKFunc.CreateRepository(history.CreateRepositoryParams{
+				Signer: signer,
+				Client: client,
+			})
+
+			// retrieve the partial set:
+			index := c.Int("index")
+			amount := c.Int("amount")
+			ps, psErr := func() (history.PartialSet, error) {
+				if signerAsString := c.String("signer"); signerAsString != "" {
+					signer := crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+						PubKeyAsString: signerAsString,
+					})
+
+					return historyRepository.RetrieveSetBySigner(signer, index, amount)
+				}
+
+				if path := c.String("path"); path != "" {
+					return historyRepository.RetrieveSetByPath(path, index, amount)
+				}
+
+				if tag := c.String("tag"); tag != "" {
+					return historyRepository.RetrieveSetByTag(tag, index, amount)
+				}
+
+				if resourceAsString := c.String("resource"); resourceAsString != "" {
+					id, idErr := uuid.FromString(resourceAsString)
+					if idErr != nil {
+						str := fmt.Sprintf("the given resource (ID: %s) is not a valid id", resourceAsString)
+						return nil, errors.New(str)
+					}
+
+					return historyRepository.RetrieveSetByResource(&id, index, amount)
+				}
+
+				return nil, errors.New("the signer, path, tag or resource is mandatory in order to retrieve a list of transactions")
+			}()
+
+			if psErr != nil {
+				str := fmt.Sprintf("there was an error while retrieving the transaction list: %s", psErr.Error())
+				panic(errors.New(str))
+			}
+
+			helpers.SDKFunc.PrintSuccessWithInstance(helpers.PrintSuccessWithInstanceParams{
+				Ins: ps,
+			})
+
+			// returns:
+			return nil
+		},
+	}
+}
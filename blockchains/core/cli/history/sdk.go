@@ -0,0 +1,18 @@
+package history
+
+import (
+	cliapp "github.com/urfave/cli"
+)
+
+// SDKFunc represents the history SDK func
+var SDKFunc = struct {
+	Retrieve     func() *cliapp.Command
+	RetrieveList func() *cliapp.Command
+}{
+	Retrieve: func() *cliapp.Command {
+		return retrieve()
+	},
+	RetrieveList: func() *cliapp.Command {
+		return retrieveList()
+	},
+}
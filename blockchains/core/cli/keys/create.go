@@ -0,0 +1,90 @@
+package keys
+
+import (
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/core/commands"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/helpers"
+)
+
+func create() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "create",
+		Aliases: []string{"c"},
+		Usage:   "Generates a new mnemonic phrase, then derives the keys from it and saves them in the keystore",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of the keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of the key to save in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "label",
+				Value: "",
+				Usage: "This is the optional label of the key",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to encrypt the key",
+			},
+			cliapp.StringFlag{
+				Name:  "retypedpass",
+				Value: "",
+				Usage: "This is the password, retyped",
+			},
+			cliapp.BoolFlag{
+				Name:  "master",
+				Usage: "If set, the key is encrypted with the master password of the keystore instead of its own password",
+			},
+			cliapp.StringFlag{
+				Name:  "passphrase",
+				Value: "",
+				Usage: "This is the optional passphrase combined with the mnemonic phrase to derive the keys",
+			},
+			cliapp.IntFlag{
+				Name:  "bits",
+				Value: 256,
+				Usage: "This is the entropy of the mnemonic phrase, in bits: 128 bits for 12 words, 256 bits for 24 words",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// generate the mnemonic:
+			mnemonic := crypto.SDKFunc.GenerateMnemonic(crypto.GenerateMnemonicParams{
+				BitSize: c.Int("bits"),
+			})
+
+			// derive and save the configs:
+			conf := commands.SDKFunc.GenerateConfigsFromMnemonic(commands.GenerateConfigsFromMnemonicParams{
+				Mnemonic:      mnemonic,
+				Passphrase:    c.String("passphrase"),
+				Keystore:      c.String("keystore"),
+				Name:          c.String("key"),
+				Label:         c.String("label"),
+				Pass:          c.String("pass"),
+				RetypedPass:   c.String("retypedpass"),
+				UseMasterPass: c.Bool("master"),
+			})
+
+			str := fmt.Sprintf("Key saved in the keystore (wallet PublicKey: %s)\nWrite down your mnemonic phrase and keep it safe, it is the only way to restore your keys:\n%s", conf.WalletPK().PublicKey().String(), mnemonic)
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
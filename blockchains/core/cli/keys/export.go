@@ -0,0 +1,68 @@
+package keys
+
+import (
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/helpers"
+)
+
+func export() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "export",
+		Aliases: []string{"e"},
+		Usage:   "Derives a key from a mnemonic phrase, then prints it",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "mnemonic",
+				Value: "",
+				Usage: "This is the mnemonic phrase, its words separated by spaces",
+			},
+			cliapp.StringFlag{
+				Name:  "passphrase",
+				Value: "",
+				Usage: "This is the optional passphrase combined with the mnemonic phrase to derive the keys",
+			},
+			cliapp.StringFlag{
+				Name:  "path",
+				Value: crypto.WalletKeyPath,
+				Usage: "This is the derivation path of the key",
+			},
+			cliapp.IntFlag{
+				Name:  "app",
+				Value: -1,
+				Usage: "This is the index of the application whose key is derived.  If set, the path is ignored",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// find the derivation path:
+			path := c.String("path")
+			if appIndex := c.Int("app"); appIndex >= 0 {
+				path = crypto.SDKFunc.CreateAppKeyPath(crypto.CreateAppKeyPathParams{
+					Index: appIndex,
+				})
+			}
+
+			// derive the key:
+			pk := crypto.SDKFunc.CreatePKFromMnemonic(crypto.CreatePKFromMnemonicParams{
+				Mnemonic:   c.String("mnemonic"),
+				Passphrase: c.String("passphrase"),
+				Path:       path,
+			})
+
+			str := fmt.Sprintf("Path: %s\nPrivateKey: %s\nPublicKey: %s", path, pk.String(), pk.PublicKey().String())
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package keys
+
+import (
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/core/commands"
+	"github.com/xmnservices/xmnsuite/helpers"
+)
+
+func restore() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "restore",
+		Aliases: []string{"r"},
+		Usage:   "Derives the keys from a mnemonic phrase, then saves them in the keystore",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "mnemonic",
+				Value: "",
+				Usage: "This is the mnemonic phrase, its words separated by spaces",
+			},
+			cliapp.StringFlag{
+				Name:  "passphrase",
+				Value: "",
+				Usage: "This is the optional passphrase combined with the mnemonic phrase to derive the keys",
+			},
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of the keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of the key to save in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "label",
+				Value: "",
+				Usage: "This is the optional label of the key",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to encrypt the key",
+			},
+			cliapp.StringFlag{
+				Name:  "retypedpass",
+				Value: "",
+				Usage: "This is the password, retyped",
+			},
+			cliapp.BoolFlag{
+				Name:  "master",
+				Usage: "If set, the key is encrypted with the master password of the keystore instead of its own password",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// derive and save the configs:
+			conf := commands.SDKFunc.GenerateConfigsFromMnemonic(commands.GenerateConfigsFromMnemonicParams{
+				Mnemonic:      c.String("mnemonic"),
+				Passphrase:    c.String("passphrase"),
+				Keystore:      c.String("keystore"),
+				Name:          c.String("key"),
+				Label:         c.String("label"),
+				Pass:          c.String("pass"),
+				RetypedPass:   c.String("retypedpass"),
+				UseMasterPass: c.Bool("master"),
+			})
+
+			str := fmt.Sprintf("Key restored in the keystore (wallet PublicKey: %s)", conf.WalletPK().PublicKey().String())
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
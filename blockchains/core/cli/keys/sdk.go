@@ -0,0 +1,22 @@
+package keys
+
+import (
+	cliapp "github.com/urfave/cli"
+)
+
+// SDKFunc represents the keys SDK func
+var SDKFunc = struct {
+	Create  func() *cliapp.Command
+	Restore func() *cliapp.Command
+	Export  func() *cliapp.Command
+}{
+	Create: func() *cliapp.Command {
+		return create()
+	},
+	Restore: func() *cliapp.Command {
+		return restore()
+	},
+	Export: func() *cliapp.Command {
+		return export()
+	},
+}
@@ -0,0 +1,72 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	term "github.com/nsf/termbox-go"
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func agent() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "agent",
+		Aliases: []string{"a"},
+		Usage:   "Runs an agent that keeps the unlocked keys of the keystore for a session",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.IntFlag{
+				Name:  "ttl",
+				Value: int(keystore.DefaultAgentTTL / time.Minute),
+				Usage: "This is the amount of minutes a key stays unlocked",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+
+			// start the agent:
+			ag := keystore.SDKFunc.CreateAgent(keystore.CreateAgentParams{
+				Dir: c.String("keystore"),
+				TTL: time.Duration(c.Int("ttl")) * time.Minute,
+			})
+
+			startErr := ag.Start()
+			if startErr != nil {
+				str := fmt.Sprintf("there was an error while starting the agent: %s", startErr.Error())
+				return errors.New(str)
+			}
+			defer ag.Stop()
+
+			termErr := term.Init()
+			if termErr != nil {
+				str := fmt.Sprintf("there was an error while enabling the keyboard listening: %s", termErr.Error())
+				return errors.New(str)
+			}
+			defer term.Close()
+
+			// agent started, loop until we stop:
+			helpers.Print("XMN keystore agent started, unlock keys using the unlock command\nPress Esc to stop...")
+
+		keyPressListenerLoop:
+			for {
+				switch ev := term.PollEvent(); ev.Type {
+				case term.EventKey:
+					switch ev.Key {
+					case term.KeyEsc:
+						break keyPressListenerLoop
+					}
+					break
+				}
+			}
+
+			// returns:
+			return nil
+		},
+	}
+}
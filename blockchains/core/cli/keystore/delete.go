@@ -0,0 +1,58 @@
+package keystore
+
+import (
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func deleteKey() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "delete",
+		Aliases: []string{"d"},
+		Usage:   "Deletes a key from the keystore",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock your key",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// delete the key:
+			ks := keystore.SDKFunc.CreateKeystore(keystore.CreateKeystoreParams{
+				Dir: c.String("keystore"),
+			})
+
+			delErr := ks.Delete(c.String("key"), c.String("pass"))
+			if delErr != nil {
+				panic(delErr)
+			}
+
+			str := fmt.Sprintf("The key (%s) has been deleted", c.String("key"))
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package keystore
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func export() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "export",
+		Aliases: []string{"e"},
+		Usage:   "Exports a key of the keystore in a file, encrypted with an export password",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock your key",
+			},
+			cliapp.StringFlag{
+				Name:  "file",
+				Value: "",
+				Usage: "This is the path of the file to export the key to",
+			},
+			cliapp.StringFlag{
+				Name:  "exportpass",
+				Value: "",
+				Usage: "This is the password used to encrypt the exported key",
+			},
+			cliapp.StringFlag{
+				Name:  "retypedexportpass",
+				Value: "",
+				Usage: "This is the export password, retyped",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// export the key:
+			ks := keystore.SDKFunc.CreateKeystore(keystore.CreateKeystoreParams{
+				Dir: c.String("keystore"),
+			})
+
+			exported, exportedErr := ks.Export(c.String("key"), c.String("pass"), c.String("exportpass"), c.String("retypedexportpass"))
+			if exportedErr != nil {
+				panic(exportedErr)
+			}
+
+			// write the exported key:
+			writeErr := ioutil.WriteFile(c.String("file"), []byte(exported), 0600)
+			if writeErr != nil {
+				panic(writeErr)
+			}
+
+			str := fmt.Sprintf("The key (%s) has been exported to the file (%s)", c.String("key"), c.String("file"))
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package keystore
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func importKey() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "import",
+		Aliases: []string{"i"},
+		Usage:   "Imports an exported key, or an encrypted configuration file, in the keystore",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "file",
+				Value: "",
+				Usage: "This is the path of the exported key, or of the encrypted configuration file",
+			},
+			cliapp.StringFlag{
+				Name:  "exportpass",
+				Value: "",
+				Usage: "This is the password used to decrypt the exported key",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of the key to save in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "label",
+				Value: "",
+				Usage: "This is the optional label of the key",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to encrypt the key",
+			},
+			cliapp.StringFlag{
+				Name:  "retypedpass",
+				Value: "",
+				Usage: "This is the password, retyped",
+			},
+			cliapp.BoolFlag{
+				Name:  "master",
+				Usage: "If set, the key is encrypted with the master password of the keystore instead of its own password",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// read the exported key:
+			exported, exportedErr := ioutil.ReadFile(c.String("file"))
+			if exportedErr != nil {
+				panic(exportedErr)
+			}
+
+			// import the key:
+			ks := keystore.SDKFunc.CreateKeystore(keystore.CreateKeystoreParams{
+				Dir: c.String("keystore"),
+			})
+
+			key, keyErr := ks.Import(
+				c.String("key"),
+				c.String("label"),
+				string(exported),
+				c.String("exportpass"),
+				c.String("pass"),
+				c.String("retypedpass"),
+				c.Bool("master"),
+			)
+
+			if keyErr != nil {
+				panic(keyErr)
+			}
+
+			str := fmt.Sprintf("The key (%s) has been imported (wallet PublicKey: %s)", key.Name(), key.Configs().WalletPK().PublicKey().String())
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
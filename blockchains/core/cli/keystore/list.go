@@ -0,0 +1,65 @@
+package keystore
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func list() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "list",
+		Aliases: []string{"l"},
+		Usage:   "Lists the keys of the keystore",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// list the keys:
+			ks := keystore.SDKFunc.CreateKeystore(keystore.CreateKeystoreParams{
+				Dir: c.String("keystore"),
+			})
+
+			infos, infosErr := ks.List()
+			if infosErr != nil {
+				panic(infosErr)
+			}
+
+			if len(infos) <= 0 {
+				helpers.Print("The keystore does not contain any key")
+				return nil
+			}
+
+			lines := []string{}
+			for _, oneInfo := range infos {
+				pass := "own password"
+				if oneInfo.UsesMasterPass() {
+					pass = "master password"
+				}
+
+				line := fmt.Sprintf("%s\t%s\t%s\t%s", oneInfo.Name(), oneInfo.CreatedOn().Format(time.RFC3339), pass, oneInfo.Label())
+				lines = append(lines, line)
+			}
+
+			helpers.Print(strings.Join(lines, "\n"))
+
+			// returns:
+			return nil
+		},
+	}
+}
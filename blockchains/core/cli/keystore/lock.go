@@ -0,0 +1,65 @@
+package keystore
+
+import (
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func lock() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "lock",
+		Aliases: []string{"lo"},
+		Usage:   "Locks a key, or every key if no key is given, in the running agent",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			client := keystore.SDKFunc.CreateAgentClient(keystore.CreateAgentClientParams{
+				Dir: c.String("keystore"),
+			})
+
+			// lock every key:
+			name := c.String("key")
+			if name == "" {
+				lockErr := client.LockAll()
+				if lockErr != nil {
+					panic(lockErr)
+				}
+
+				helpers.Print("Every key has been locked")
+				return nil
+			}
+
+			// lock the key:
+			lockErr := client.Lock(name)
+			if lockErr != nil {
+				panic(lockErr)
+			}
+
+			str := fmt.Sprintf("The key (%s) has been locked", name)
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
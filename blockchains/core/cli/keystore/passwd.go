@@ -0,0 +1,121 @@
+package keystore
+
+import (
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func changePass() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "passwd",
+		Aliases: []string{"p"},
+		Usage:   "Changes the password of a key that does not use the master password",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the current password of the key",
+			},
+			cliapp.StringFlag{
+				Name:  "newpass",
+				Value: "",
+				Usage: "This is the new password of the key",
+			},
+			cliapp.StringFlag{
+				Name:  "retypednewpass",
+				Value: "",
+				Usage: "This is the new password, retyped",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// change the password:
+			ks := keystore.SDKFunc.CreateKeystore(keystore.CreateKeystoreParams{
+				Dir: c.String("keystore"),
+			})
+
+			changeErr := ks.ChangePass(c.String("key"), c.String("pass"), c.String("newpass"), c.String("retypednewpass"))
+			if changeErr != nil {
+				panic(changeErr)
+			}
+
+			str := fmt.Sprintf("The password of the key (%s) has been changed", c.String("key"))
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
+
+func changeMaster() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "masterpasswd",
+		Aliases: []string{"mp"},
+		Usage:   "Changes the master password, then re-encrypts every key that uses it",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the current master password",
+			},
+			cliapp.StringFlag{
+				Name:  "newpass",
+				Value: "",
+				Usage: "This is the new master password",
+			},
+			cliapp.StringFlag{
+				Name:  "retypednewpass",
+				Value: "",
+				Usage: "This is the new master password, retyped",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// change the master password:
+			ks := keystore.SDKFunc.CreateKeystore(keystore.CreateKeystoreParams{
+				Dir: c.String("keystore"),
+			})
+
+			changeErr := ks.ChangeMasterPass(c.String("pass"), c.String("newpass"), c.String("retypednewpass"))
+			if changeErr != nil {
+				panic(changeErr)
+			}
+
+			helpers.Print("The master password has been changed")
+
+			// returns:
+			return nil
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package keystore
+
+import (
+	cliapp "github.com/urfave/cli"
+)
+
+// SDKFunc represents the keystore SDK func
+var SDKFunc = struct {
+	List         func() *cliapp.Command
+	ChangePass   func() *cliapp.Command
+	ChangeMaster func() *cliapp.Command
+	Export       func() *cliapp.Command
+	Import       func() *cliapp.Command
+	Delete       func() *cliapp.Command
+	Agent        func() *cliapp.Command
+	Unlock       func() *cliapp.Command
+	Lock         func() *cliapp.Command
+}{
+	List: func() *cliapp.Command {
+		return list()
+	},
+	ChangePass: func() *cliapp.Command {
+		return changePass()
+	},
+	ChangeMaster: func() *cliapp.Command {
+		return changeMaster()
+	},
+	Export: func() *cliapp.Command {
+		return export()
+	},
+	Import: func() *cliapp.Command {
+		return importKey()
+	},
+	Delete: func() *cliapp.Command {
+		return deleteKey()
+	},
+	Agent: func() *cliapp.Command {
+		return agent()
+	},
+	Unlock: func() *cliapp.Command {
+		return unlock()
+	},
+	Lock: func() *cliapp.Command {
+		return lock()
+	},
+}
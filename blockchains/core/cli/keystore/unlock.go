@@ -0,0 +1,58 @@
+package keystore
+
+import (
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+)
+
+func unlock() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "unlock",
+		Aliases: []string{"u"},
+		Usage:   "Unlocks a key in the running agent, so that the other commands can use it without its password",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock your key",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// unlock the key:
+			client := keystore.SDKFunc.CreateAgentClient(keystore.CreateAgentClientParams{
+				Dir: c.String("keystore"),
+			})
+
+			unlockErr := client.Unlock(c.String("key"), c.String("pass"))
+			if unlockErr != nil {
+				panic(unlockErr)
+			}
+
+			str := fmt.Sprintf("The key (%s) has been unlocked", c.String("key"))
+			helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
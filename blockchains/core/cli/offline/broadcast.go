@@ -0,0 +1,72 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/offline"
+	"github.com/xmnservices/xmnsuite/blockchains/tendermint"
+	core_helpers "github.com/xmnservices/xmnsuite/helpers"
+)
+
+func broadcast() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "broadcast",
+		Aliases: []string{"b"},
+		Usage:   "Broadcasts a signed transaction file to the blockchain",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "host",
+				Value: "",
+				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
+			},
+			cliapp.StringFlag{
+				Name:  "in",
+				Value: "",
+				Usage: "This is the path of the signed transaction file",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					core_helpers.Print(str)
+				}
+			}()
+
+			// read the signed transaction:
+			js, jsErr := ioutil.ReadFile(c.String("in"))
+			if jsErr != nil {
+				panic(jsErr)
+			}
+
+			trx := offline.SDKFunc.Create(offline.CreateParams{
+				JSON: js,
+			})
+
+			if !trx.IsSigned() {
+				str := fmt.Sprintf("the transaction file (%s) must be signed before being broadcasted", c.String("in"))
+				panic(errors.New(str))
+			}
+
+			// create the blockchain client:
+			client := tendermint.SDKFunc.CreateClient(tendermint.CreateClientParams{
+				IPAsString: c.String("host"),
+			})
+
+			// broadcast:
+			resp := offline.SDKFunc.Broadcast(offline.BroadcastParams{
+				Transaction: trx,
+				Client:      client,
+			})
+
+			str := fmt.Sprintf("The transaction (Hash: %s) has been broadcasted (Height: %d, Route: %s)", trx.Hash(), resp.Height(), trx.Pointer().Path())
+			core_helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
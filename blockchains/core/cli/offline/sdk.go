@@ -0,0 +1,18 @@
+package offline
+
+import (
+	cliapp "github.com/urfave/cli"
+)
+
+// SDKFunc represents the offline SDK func
+var SDKFunc = struct {
+	Sign      func() *cliapp.Command
+	Broadcast func() *cliapp.Command
+}{
+	Sign: func() *cliapp.Command {
+		return sign()
+	},
+	Broadcast: func() *cliapp.Command {
+		return broadcast()
+	},
+}
@@ -0,0 +1,109 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/helpers"
+	"github.com/xmnservices/xmnsuite/blockchains/offline"
+	core_helpers "github.com/xmnservices/xmnsuite/helpers"
+)
+
+func sign() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "sign",
+		Aliases: []string{"s"},
+		Usage:   "Signs an unsigned transaction file, without any connection to the blockchain",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "in",
+				Value: "",
+				Usage: "This is the path of the unsigned transaction file",
+			},
+			cliapp.StringFlag{
+				Name:  "out",
+				Value: "",
+				Usage: "This is the path of the signed transaction file to write (default: the unsigned transaction file)",
+			},
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					core_helpers.Print(str)
+				}
+			}()
+
+			// read the unsigned transaction:
+			inPath := c.String("in")
+			js, jsErr := ioutil.ReadFile(inPath)
+			if jsErr != nil {
+				panic(jsErr)
+			}
+
+			trx := offline.SDKFunc.Create(offline.CreateParams{
+				JSON: js,
+			})
+
+			// retrieve the signer, then sign:
+			sig := helpers.SDKFunc.RetrieveSigner(helpers.RetrieveSignerParams{
+				CLIContext: c,
+			})
+
+			signed, signedErr := trx.Sign(sig)
+			if signedErr != nil {
+				panic(signedErr)
+			}
+
+			// write the signed transaction:
+			signedJS, signedJSErr := json.MarshalIndent(signed, "", "    ")
+			if signedJSErr != nil {
+				panic(signedJSErr)
+			}
+
+			outPath := c.String("out")
+			if outPath == "" {
+				outPath = inPath
+			}
+
+			writeErr := ioutil.WriteFile(outPath, signedJS, 0644)
+			if writeErr != nil {
+				panic(writeErr)
+			}
+
+			// print what has been signed, so that it can be verified:
+			str := fmt.Sprintf("The transaction has been signed and written to the file (%s):\n\n%s", outPath, signedJS)
+			core_helpers.Print(str)
+
+			// returns:
+			return nil
+		},
+	}
+}
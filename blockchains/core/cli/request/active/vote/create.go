@@ -28,14 +28,29 @@ func create(met meta.Meta) *cliapp.Command {
 				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
 			},
 			cliapp.StringFlag{
-				Name:  "file",
+				Name:  "keystore",
 				Value: "",
-				Usage: "This is the path of your encrypted configuration file",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
 			},
 			cliapp.StringFlag{
 				Name:  "pass",
 				Value: "",
-				Usage: "This is the password used to decrypt the encrypted configuration file",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
 			},
 			cliapp.StringFlag{
 				Name:  "requestid",
@@ -69,14 +84,14 @@ func create(met meta.Meta) *cliapp.Command {
 				}
 			}()
 
-			// retrieve conf with client:
-			conf, client := helpers.SDKFunc.RetrieveConfWithClient(helpers.RetrieveConfWithClientParams{
+			// retrieve signer with client:
+			signer, client := helpers.SDKFunc.RetrieveSignerWithClient(helpers.RetrieveSignerWithClientParams{
 				CLIContext: c,
 			})
 
 			// create the repositories:
 			entityRepository := entity.SDKFunc.CreateSDKRepository(entity.CreateSDKRepositoryParams{
-				PK:     conf.WalletPK(),
+				Signer: signer,
 				Client: client,
 			})
 
@@ -89,7 +104,7 @@ func create(met meta.Meta) *cliapp.Command {
 			})
 
 			voteService := vote.SDKFunc.CreateSDKService(vote.CreateSDKServiceParams{
-				PK:     conf.WalletPK(),
+				Signer: signer,
 				Client: client,
 			})
 
@@ -113,9 +128,9 @@ func create(met meta.Meta) *cliapp.Command {
 			}
 
 			// retrieve the user:
-			fromUser, fromUserErr := userRepository.RetrieveByPubKey(conf.WalletPK().PublicKey())
+			fromUser, fromUserErr := userRepository.RetrieveByPubKey(signer.PublicKey())
 			if fromUserErr != nil {
-				str := fmt.Sprintf("there was an error while retrieving the user (PubKey: %s): %s", conf.WalletPK().PublicKey().String(), fromUserErr.Error())
+				str := fmt.Sprintf("there was an error while retrieving the user (PubKey: %s): %s", signer.PublicKey().String(), fromUserErr.Error())
 				panic(errors.New(str))
 			}
 
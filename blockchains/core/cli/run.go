@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	uuid "github.com/satori/go.uuid"
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+	"github.com/xmnservices/xmnsuite/modules/run"
+	"github.com/xmnservices/xmnsuite/modules/sandbox"
+)
+
+func runScript() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "run",
+		Aliases: []string{"r"},
+		Usage:   "Runs an independent blockchain scripted in lua",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "script",
+				Value: "",
+				Usage: "this is the path of the lua script that describes the blockchain",
+			},
+			cliapp.IntFlag{
+				Name:  "port",
+				Value: 26657,
+				Usage: "this is the blockchain port",
+			},
+			cliapp.StringFlag{
+				Name:  "dir",
+				Value: "./chain",
+				Usage: "this is the blockchain database path",
+			},
+			cliapp.StringFlag{
+				Name:  "roots",
+				Value: "",
+				Usage: "this is the comma separated list of the root PublicKeys, that have write access to every route",
+			},
+			cliapp.StringFlag{
+				Name:  "id",
+				Value: "",
+				Usage: "this is the instance ID of the blockchain, the one of the database path is used, or generated, if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "this is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "this is the name of the key, in the keystore, that contains the node key",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "this is the password used to unlock your key, the key unlocked in the agent is used if empty",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			scriptPath := c.String("script")
+			if scriptPath == "" {
+				panic(errors.New("the script is mandatory in order to run a blockchain"))
+			}
+
+			// retrieve the configs:
+			conf := keystore.SDKFunc.RetrieveConfigs(keystore.RetrieveConfigsParams{
+				Dir:  c.String("keystore"),
+				Name: c.String("key"),
+				Pass: c.String("pass"),
+			})
+
+			// parse the root PublicKeys:
+			rootPubKeys := []crypto.PublicKey{}
+			for _, oneRoot := range strings.Split(c.String("roots"), ",") {
+				trimmed := strings.TrimSpace(oneRoot)
+				if trimmed == "" {
+					continue
+				}
+
+				rootPubKeys = append(rootPubKeys, crypto.SDKFunc.ParsePubKey(crypto.CreatePubKeyParams{
+					PubKeyAsString: trimmed,
+				}))
+			}
+
+			// parse the instance ID:
+			var id *uuid.UUID
+			if idAsString := c.String("id"); idAsString != "" {
+				parsedID, parsedIDErr := uuid.FromString(idAsString)
+				if parsedIDErr != nil {
+					str := fmt.Sprintf("the given id (ID: %s) is not a valid id", idAsString)
+					panic(errors.New(str))
+				}
+
+				id = &parsedID
+			}
+
+			// create the sandboxed lua state:
+			context := sandbox.SDKFunc.Create(sandbox.CreateParams{})
+			defer context.Close()
+
+			// execute the script and spawn the node:
+			node := run.SDKFunc.Execute(run.ExecuteParams{
+				Context:     context,
+				ScriptPath:  scriptPath,
+				DBPath:      c.String("dir"),
+				Port:        c.Int("port"),
+				ID:          id,
+				RootPubKeys: rootPubKeys,
+				NodePK:      conf.NodePK(),
+			})
+
+			// node spawned, wait until we stop:
+			str := fmt.Sprintf("XMN lua blockchain spawned from the script (%s), address: %s\nPress Ctrl+C to stop...", scriptPath, node.GetAddress())
+			helpers.Print(str)
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+			<-stop
+
+			stopErr := node.Stop()
+			if stopErr != nil {
+				str := fmt.Sprintf("there was an error while stopping the blockchain: %s", stopErr.Error())
+				panic(errors.New(str))
+			}
+
+			// returns:
+			return nil
+		},
+	}
+}
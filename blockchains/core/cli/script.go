@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	cliapp "github.com/urfave/cli"
+	core_helpers "github.com/xmnservices/xmnsuite/blockchains/core/cli/helpers"
+	"github.com/xmnservices/xmnsuite/blockchains/core/meta"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/modules/run"
+	"github.com/xmnservices/xmnsuite/modules/sandbox"
+)
+
+func script() *cliapp.Command {
+	return &cliapp.Command{
+		Name:      "script",
+		Aliases:   []string{"sc"},
+		Usage:     "Runs a lua script against a core blockchain node, using the lua bindings of the core SDK",
+		ArgsUsage: "<script>",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "script",
+				Value: "",
+				Usage: "this is the path of the lua script to run, the first argument is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "host",
+				Value: "",
+				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
+			},
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
+			},
+		},
+		Action: func(c *cliapp.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+					err = cliapp.NewExitError("", 1)
+				}
+			}()
+
+			scriptPath := c.String("script")
+			if scriptPath == "" {
+				scriptPath = c.Args().First()
+			}
+
+			if scriptPath == "" {
+				panic(errors.New("the script is mandatory in order to run a script"))
+			}
+
+			// retrieve signer with client:
+			signer, client := core_helpers.SDKFunc.RetrieveSignerWithClient(core_helpers.RetrieveSignerWithClientParams{
+				CLIContext: c,
+			})
+
+			// create the sandboxed lua state:
+			context := sandbox.SDKFunc.Create(sandbox.CreateParams{})
+			defer context.Close()
+
+			// execute the script:
+			run.SDKFunc.Script(run.ScriptParams{
+				Context:    context,
+				ScriptPath: scriptPath,
+				Signer:     signer,
+				Client:     client,
+				Meta:       meta.SDKFunc.Create(meta.CreateParams{}),
+			})
+
+			// returns:
+			return nil
+		},
+	}
+}
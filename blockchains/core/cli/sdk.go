@@ -3,6 +3,11 @@ package cli
 import (
 	term "github.com/nsf/termbox-go"
 	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/history"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/keys"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/keystore"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/offline"
+	"github.com/xmnservices/xmnsuite/blockchains/core/cli/signer"
 )
 
 func reset() {
@@ -11,9 +16,88 @@ func reset() {
 
 // SDKFunc represents the CLI sdk func
 var SDKFunc = struct {
-	Spawn func() *cliapp.Command
+	Spawn    func() *cliapp.Command
+	Run      func() *cliapp.Command
+	Test     func() *cliapp.Command
+	Script   func() *cliapp.Command
+	History  func() *cliapp.Command
+	Keys     func() *cliapp.Command
+	Keystore func() *cliapp.Command
+	Signer   func() *cliapp.Command
+	Offline  func() *cliapp.Command
 }{
 	Spawn: func() *cliapp.Command {
 		return spawn()
 	},
+	Run: func() *cliapp.Command {
+		return runScript()
+	},
+	Test: func() *cliapp.Command {
+		return testScript()
+	},
+	Script: func() *cliapp.Command {
+		return script()
+	},
+	History: func() *cliapp.Command {
+		return &cliapp.Command{
+			Name:    "history",
+			Aliases: []string{"hi"},
+			Usage:   "This is the group of commands to work with the transaction history",
+			Subcommands: []cliapp.Command{
+				*history.SDKFunc.Retrieve(),
+				*history.SDKFunc.RetrieveList(),
+			},
+		}
+	},
+	Keys: func() *cliapp.Command {
+		return &cliapp.Command{
+			Name:    "keys",
+			Aliases: []string{"k"},
+			Usage:   "This is the group of commands to create, restore and export keys using a mnemonic phrase",
+			Subcommands: []cliapp.Command{
+				*keys.SDKFunc.Create(),
+				*keys.SDKFunc.Restore(),
+				*keys.SDKFunc.Export(),
+			},
+		}
+	},
+	Keystore: func() *cliapp.Command {
+		return &cliapp.Command{
+			Name:    "keystore",
+			Aliases: []string{"ks"},
+			Usage:   "This is the group of commands to manage the keys of the keystore and its agent",
+			Subcommands: []cliapp.Command{
+				*keystore.SDKFunc.List(),
+				*keystore.SDKFunc.ChangePass(),
+				*keystore.SDKFunc.ChangeMaster(),
+				*keystore.SDKFunc.Export(),
+				*keystore.SDKFunc.Import(),
+				*keystore.SDKFunc.Delete(),
+				*keystore.SDKFunc.Agent(),
+				*keystore.SDKFunc.Unlock(),
+				*keystore.SDKFunc.Lock(),
+			},
+		}
+	},
+	Signer: func() *cliapp.Command {
+		return &cliapp.Command{
+			Name:    "signer",
+			Aliases: []string{"si"},
+			Usage:   "This is the group of commands to run a signer daemon that holds the keys in a separate process",
+			Subcommands: []cliapp.Command{
+				*signer.SDKFunc.Start(),
+			},
+		}
+	},
+	Offline: func() *cliapp.Command {
+		return &cliapp.Command{
+			Name:    "offline",
+			Aliases: []string{"off"},
+			Usage:   "This is the group of commands to sign transaction files offline and broadcast them later",
+			Subcommands: []cliapp.Command{
+				*offline.SDKFunc.Sign(),
+				*offline.SDKFunc.Broadcast(),
+			},
+		}
+	},
 }
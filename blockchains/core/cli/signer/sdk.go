@@ -0,0 +1,14 @@
+package signer
+
+import (
+	cliapp "github.com/urfave/cli"
+)
+
+// SDKFunc represents the signer SDK func
+var SDKFunc = struct {
+	Start func() *cliapp.Command
+}{
+	Start: func() *cliapp.Command {
+		return start()
+	},
+}
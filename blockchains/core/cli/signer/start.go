@@ -0,0 +1,111 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/keystore"
+	"github.com/xmnservices/xmnsuite/signer"
+)
+
+func start() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "start",
+		Aliases: []string{"s"},
+		Usage:   "Starts a signer daemon that holds keys of the keystore and signs requests on behalf of the other commands",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "keys",
+				Value: "",
+				Usage: "This is the names of the keys held by the daemon, separated by commas",
+			},
+			cliapp.StringFlag{
+				Name:  "pass",
+				Value: "",
+				Usage: "This is the password used to unlock the keys, the keys unlocked in the agent are used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "address",
+				Value: "unix://./signer.sock",
+				Usage: "This is the address the daemon listens on (example: unix:///path/to/signer.sock or tcp://127.0.0.1:26680)",
+			},
+			cliapp.StringFlag{
+				Name:  "token",
+				Value: "",
+				Usage: "This is the token the clients must send, mandatory on a tcp address",
+			},
+			cliapp.BoolFlag{
+				Name:  "approve",
+				Usage: "If set, the operator must approve every signing request in this terminal",
+			},
+		},
+		Action: func(c *cliapp.Context) error {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+				}
+			}()
+
+			// unlock the keys:
+			keys := map[string]crypto.PrivateKey{}
+			for _, oneName := range strings.Split(c.String("keys"), ",") {
+				name := strings.TrimSpace(oneName)
+				if name == "" {
+					continue
+				}
+
+				conf := keystore.SDKFunc.RetrieveConfigs(keystore.RetrieveConfigsParams{
+					Dir:  c.String("keystore"),
+					Name: name,
+					Pass: c.String("pass"),
+				})
+
+				keys[name] = conf.WalletPK()
+			}
+
+			// create the approver:
+			approver := signer.SDKFunc.CreateAutoApprover()
+			if c.Bool("approve") {
+				approver = signer.SDKFunc.CreatePromptApprover(signer.CreatePromptApproverParams{})
+			}
+
+			// start the daemon:
+			daemon := signer.SDKFunc.CreateDaemon(signer.CreateDaemonParams{
+				Address:  c.String("address"),
+				Token:    c.String("token"),
+				Keys:     keys,
+				Approver: approver,
+			})
+
+			startErr := daemon.Start()
+			if startErr != nil {
+				str := fmt.Sprintf("there was an error while starting the signer daemon: %s", startErr.Error())
+				panic(errors.New(str))
+			}
+			defer daemon.Stop()
+
+			// daemon started, wait until we stop:
+			str := fmt.Sprintf("XMN signer daemon listening on %s, holding %d keys\nPress Ctrl+C to stop...", c.String("address"), len(keys))
+			helpers.Print(str)
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt)
+			<-stop
+
+			// returns:
+			return nil
+		},
+	}
+}
@@ -27,25 +27,37 @@ func spawn() *cliapp.Command {
 				Value: "./blockchain",
 				Usage: "this is the blockchain database path",
 			},
+			cliapp.StringFlag{
+				Name:  "keystore",
+				Value: "",
+				Usage: "this is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "this is the name of your key in the keystore",
+			},
 			cliapp.StringFlag{
 				Name:  "pass",
 				Value: "",
-				Usage: "this is the password used to decrypt your configuration file",
+				Usage: "this is the password used to unlock your key, the key unlocked in the agent is used if empty",
 			},
 			cliapp.StringFlag{
-				Name:  "file",
+				Name:  "metrics",
 				Value: "",
-				Usage: "this is the path of your encrypted configuration file",
+				Usage: "this is the address on which the prometheus metrics are exposed (ex: :26660), disabled if empty",
 			},
 		},
 		Action: func(c *cliapp.Context) error {
 
 			// spawn the node:
 			node := commands.SDKFunc.Spawn(commands.SpawnParams{
+				Keystore: c.String("keystore"),
+				Key:      c.String("key"),
 				Pass:     c.String("pass"),
-				Filename: c.String("file"),
 				Dir:      c.String("dir"),
 				Port:     c.Int("port"),
+				Metrics:  c.String("metrics"),
 			})
 
 			// retrieve the client:
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	cliapp "github.com/urfave/cli"
+	"github.com/xmnservices/xmnsuite/helpers"
+	"github.com/xmnservices/xmnsuite/modules/sandbox"
+	"github.com/xmnservices/xmnsuite/modules/test"
+)
+
+func testScript() *cliapp.Command {
+	return &cliapp.Command{
+		Name:    "test",
+		Aliases: []string{"t"},
+		Usage:   "Runs the lua test files (*_test.lua) against an in-memory blockchain scripted in lua",
+		Flags: []cliapp.Flag{
+			cliapp.StringFlag{
+				Name:  "script",
+				Value: "",
+				Usage: "this is the path of the lua script that describes the blockchain",
+			},
+			cliapp.StringFlag{
+				Name:  "dir",
+				Value: ".",
+				Usage: "this is the directory in which the test files are discovered",
+			},
+			cliapp.StringFlag{
+				Name:  "format",
+				Value: test.TAPFormat,
+				Usage: "this is the format of the report (tap or junit)",
+			},
+			cliapp.StringFlag{
+				Name:  "out",
+				Value: "",
+				Usage: "this is the path of the file the report is written to, the report is printed if empty",
+			},
+			cliapp.IntFlag{
+				Name:  "budget",
+				Value: sandbox.DefaultBudget,
+				Usage: "this is the amount of lua instructions a test case can execute before being aborted",
+			},
+		},
+		Action: func(c *cliapp.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					str := fmt.Sprintf("%s", r)
+					helpers.Print(str)
+					err = cliapp.NewExitError("", 1)
+				}
+			}()
+
+			// discover the test files:
+			testFilePaths := test.SDKFunc.Discover(test.DiscoverParams{
+				Dir: c.String("dir"),
+			})
+
+			if len(testFilePaths) <= 0 {
+				str := fmt.Sprintf("there is no test file (*%s) in the directory (%s)", test.TestFileSuffix, c.String("dir"))
+				panic(errors.New(str))
+			}
+
+			// run the tests:
+			runner := test.SDKFunc.CreateRunner(test.CreateRunnerParams{
+				ScriptPath: c.String("script"),
+				Budget:     c.Int("budget"),
+			})
+
+			results := runner.Run(testFilePaths)
+
+			// report:
+			report := test.SDKFunc.Format(test.FormatParams{
+				Format:  c.String("format"),
+				Results: results,
+			})
+
+			if outPath := c.String("out"); outPath != "" {
+				writeErr := ioutil.WriteFile(outPath, []byte(report), 0644)
+				if writeErr != nil {
+					panic(writeErr)
+				}
+			} else {
+				fmt.Print(report)
+			}
+
+			// the command fails when a test fails:
+			failures := 0
+			for _, oneResult := range results {
+				if !oneResult.IsSuccessful() {
+					failures++
+				}
+			}
+
+			if failures > 0 {
+				str := fmt.Sprintf("%d of %d tests failed", failures, len(results))
+				return cliapp.NewExitError(str, 1)
+			}
+
+			// returns:
+			return nil
+		},
+	}
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/transfer"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/deposit"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/withdrawal"
-	"github.com/xmnservices/xmnsuite/configs"
+	"github.com/xmnservices/xmnsuite/crypto"
 	core_helpers "github.com/xmnservices/xmnsuite/helpers"
 )
 
@@ -29,14 +29,29 @@ func retrievelist() *cliapp.Command {
 				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
 			},
 			cliapp.StringFlag{
-				Name:  "file",
+				Name:  "keystore",
 				Value: "",
-				Usage: "This is the path of your encrypted configuration file",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
 			},
 			cliapp.StringFlag{
 				Name:  "pass",
 				Value: "",
-				Usage: "This is the password used to decrypt the encrypted configuration file",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
 			},
 			cliapp.StringFlag{
 				Name:  "walletid",
@@ -70,14 +85,14 @@ func retrievelist() *cliapp.Command {
 				}
 			}()
 
-			// retrieve conf with client:
-			conf, client := helpers.SDKFunc.RetrieveConfWithClient(helpers.RetrieveConfWithClientParams{
+			// retrieve signer with client:
+			signer, client := helpers.SDKFunc.RetrieveSignerWithClient(helpers.RetrieveSignerWithClientParams{
 				CLIContext: c,
 			})
 
 			// create the repositories:
 			entityRepository := entity.SDKFunc.CreateSDKRepository(entity.CreateSDKRepositoryParams{
-				PK:     conf.WalletPK(),
+				Signer: signer,
 				Client: client,
 			})
 
@@ -109,7 +124,7 @@ func retrievelist() *cliapp.Command {
 			}
 
 			// set the index and amount:
-			trsfPS, trsfs, trsfsErr := retrieveRightTransfers(c, conf, gen, transferRepository, walletRepository, depositRepository, withdrawalRepository)
+			trsfPS, trsfs, trsfsErr := retrieveRightTransfers(c, signer, gen, transferRepository, walletRepository, depositRepository, withdrawalRepository)
 			if trsfsErr != nil {
 				panic(trsfsErr)
 			}
@@ -134,7 +149,7 @@ func retrievelist() *cliapp.Command {
 
 func retrieveRightTransfers(
 	c *cliapp.Context,
-	conf configs.Configs,
+	signer crypto.Signer,
 	gen genesis.Genesis,
 	transferRepository transfer.Repository,
 	walletRepository wallet.Repository,
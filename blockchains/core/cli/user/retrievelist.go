@@ -10,7 +10,7 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/user"
-	"github.com/xmnservices/xmnsuite/configs"
+	"github.com/xmnservices/xmnsuite/crypto"
 	core_helpers "github.com/xmnservices/xmnsuite/helpers"
 )
 
@@ -26,14 +26,29 @@ func retrievelist() *cliapp.Command {
 				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
 			},
 			cliapp.StringFlag{
-				Name:  "file",
+				Name:  "keystore",
 				Value: "",
-				Usage: "This is the path of your encrypted configuration file",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
 			},
 			cliapp.StringFlag{
 				Name:  "pass",
 				Value: "",
-				Usage: "This is the password used to decrypt the encrypted configuration file",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
 			},
 			cliapp.StringFlag{
 				Name:  "walletid",
@@ -63,14 +78,14 @@ func retrievelist() *cliapp.Command {
 				}
 			}()
 
-			// retrieve conf with client:
-			conf, client := helpers.SDKFunc.RetrieveConfWithClient(helpers.RetrieveConfWithClientParams{
+			// retrieve signer with client:
+			signer, client := helpers.SDKFunc.RetrieveSignerWithClient(helpers.RetrieveSignerWithClientParams{
 				CLIContext: c,
 			})
 
 			// create the repositories:
 			entityRepository := entity.SDKFunc.CreateSDKRepository(entity.CreateSDKRepositoryParams{
-				PK:     conf.WalletPK(),
+				Signer: signer,
 				Client: client,
 			})
 
@@ -83,7 +98,7 @@ func retrievelist() *cliapp.Command {
 			})
 
 			// set the index and amount:
-			retUserPS, retUser, retUserPSErr := retrieveRightUsers(c, conf, userRepository, walletRepository)
+			retUserPS, retUser, retUserPSErr := retrieveRightUsers(c, signer, userRepository, walletRepository)
 			if retUserPSErr != nil {
 				panic(retUserPSErr)
 			}
@@ -106,7 +121,7 @@ func retrievelist() *cliapp.Command {
 	}
 }
 
-func retrieveRightUsers(c *cliapp.Context, conf configs.Configs, userRepository user.Repository, walletRepository wallet.Repository) (entity.PartialSet, entity.Entity, error) {
+func retrieveRightUsers(c *cliapp.Context, signer crypto.Signer, userRepository user.Repository, walletRepository wallet.Repository) (entity.PartialSet, entity.Entity, error) {
 	// get the variables:
 	me := c.Bool("me")
 	index := c.Int("index")
@@ -125,9 +140,9 @@ func retrieveRightUsers(c *cliapp.Context, conf configs.Configs, userRepository
 			return retUsersPS, nil, nil
 		}
 
-		retUser, retUserErr := userRepository.RetrieveByPubKey(conf.WalletPK().PublicKey())
+		retUser, retUserErr := userRepository.RetrieveByPubKey(signer.PublicKey())
 		if retUserErr != nil {
-			str := fmt.Sprintf("there was an error while retrieving a user (pubKey: %s): %s", conf.WalletPK().PublicKey().String(), retUserErr.Error())
+			str := fmt.Sprintf("there was an error while retrieving a user (pubKey: %s): %s", signer.PublicKey().String(), retUserErr.Error())
 			panic(errors.New(str))
 		}
 
@@ -158,9 +173,9 @@ func retrieveRightUsers(c *cliapp.Context, conf configs.Configs, userRepository
 		return retUsersPS, nil, nil
 	}
 
-	retUser, retUserErr := userRepository.RetrieveByPubKey(conf.WalletPK().PublicKey())
+	retUser, retUserErr := userRepository.RetrieveByPubKey(signer.PublicKey())
 	if retUserErr != nil {
-		str := fmt.Sprintf("there was an error while retrieving a user (pubKey: %s, walletID: %s): %s", conf.WalletPK().PublicKey().String(), wal.ID().String(), retUserErr.Error())
+		str := fmt.Sprintf("there was an error while retrieving a user (pubKey: %s, walletID: %s): %s", signer.PublicKey().String(), wal.ID().String(), retUserErr.Error())
 		panic(errors.New(str))
 	}
 
@@ -25,14 +25,39 @@ func save() *cliapp.Command {
 				Usage: "This is the blockchain ip and port (example: 127.0.0.1:8080)",
 			},
 			cliapp.StringFlag{
-				Name:  "file",
+				Name:  "keystore",
 				Value: "",
-				Usage: "This is the path of your encrypted configuration file",
+				Usage: "This is the path of your keystore directory (default: ./keystore)",
+			},
+			cliapp.StringFlag{
+				Name:  "key",
+				Value: "",
+				Usage: "This is the name of your key in the keystore",
+			},
+			cliapp.StringFlag{
+				Name:  "signer",
+				Value: "",
+				Usage: "This is the address of the signer daemon holding your key (example: unix:///path/to/signer.sock), the keystore is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "signertoken",
+				Value: "",
+				Usage: "This is the token used to authenticate to the signer daemon",
 			},
 			cliapp.StringFlag{
 				Name:  "pass",
 				Value: "",
-				Usage: "This is the password used to decrypt the encrypted configuration file",
+				Usage: "This is the password used to unlock your key, the key unlocked in the agent is used if empty",
+			},
+			cliapp.StringFlag{
+				Name:  "unsigned",
+				Value: "",
+				Usage: "This is the path of the unsigned transaction file to write, in order to sign it offline, instead of saving the request",
+			},
+			cliapp.StringFlag{
+				Name:  "from",
+				Value: "",
+				Usage: "This is the PublicKey that will sign the unsigned transaction offline",
 			},
 			cliapp.IntFlag{
 				Name:  "shares",
@@ -68,13 +93,13 @@ func save() *cliapp.Command {
 				panic(errors.New("the pubKey cannot be empty"))
 			}
 
-			// retrieve conf with client:
-			conf, _ := helpers.SDKFunc.RetrieveConfWithClient(helpers.RetrieveConfWithClientParams{
+			// retrieve signer with client:
+			signer, _ := helpers.SDKFunc.RetrieveSignerWithClient(helpers.RetrieveSignerWithClientParams{
 				CLIContext: c,
 			})
 
 			// create the pubKey:
-			pubKey := crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+			pubKey := crypto.SDKFunc.ParsePubKey(crypto.CreatePubKeyParams{
 				PubKeyAsString: pubKeyAsString,
 			})
 
@@ -89,7 +114,7 @@ func save() *cliapp.Command {
 					PubKey: pubKey,
 					Shares: shares,
 					Wallet: wallet.SDKFunc.Create(wallet.CreateParams{
-						Creator:         conf.WalletPK().PublicKey(),
+						Creator:         signer.PublicKey(),
 						ConcensusNeeded: concensusNeeded,
 					}),
 				}),
@@ -2,17 +2,32 @@ package commands
 
 import (
 	"github.com/xmnservices/xmnsuite/configs"
+	"github.com/xmnservices/xmnsuite/keystore"
 )
 
-func generateConfigs(pass string, retypedPass string, filename string) (configs.Configs, error) {
+func generateConfigs(keystoreDir string, name string, label string, pass string, retypedPass string, useMasterPass bool) (configs.Configs, error) {
 	// create the configs:
 	conf := configs.SDKFunc.Generate()
 
-	// create the service:
-	service := configs.SDKFunc.CreateService()
+	// save the configs:
+	saveErr := saveConfigs(conf, keystoreDir, name, label, pass, retypedPass, useMasterPass)
+	if saveErr != nil {
+		return nil, saveErr
+	}
+
+	// return the configs:
+	return conf, nil
+}
+
+func generateConfigsFromMnemonic(mnemonic string, passphrase string, keystoreDir string, name string, label string, pass string, retypedPass string, useMasterPass bool) (configs.Configs, error) {
+	// derive the configs:
+	conf := configs.SDKFunc.GenerateFromMnemonic(configs.GenerateFromMnemonicParams{
+		Mnemonic:   mnemonic,
+		Passphrase: passphrase,
+	})
 
 	// save the configs:
-	saveErr := service.Save(conf, filename, pass, retypedPass)
+	saveErr := saveConfigs(conf, keystoreDir, name, label, pass, retypedPass, useMasterPass)
 	if saveErr != nil {
 		return nil, saveErr
 	}
@@ -20,3 +35,19 @@ func generateConfigs(pass string, retypedPass string, filename string) (configs.
 	// return the configs:
 	return conf, nil
 }
+
+func saveConfigs(conf configs.Configs, keystoreDir string, name string, label string, pass string, retypedPass string, useMasterPass bool) error {
+	// create the key:
+	key := keystore.SDKFunc.Create(keystore.CreateParams{
+		Name:  name,
+		Label: label,
+		Conf:  conf,
+	})
+
+	// save the key in the keystore:
+	ks := keystore.SDKFunc.CreateKeystore(keystore.CreateKeystoreParams{
+		Dir: keystoreDir,
+	})
+
+	return ks.Save(key, pass, retypedPass, useMasterPass)
+}
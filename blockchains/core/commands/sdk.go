@@ -31,26 +31,52 @@ var peers = []string{}
 
 // GenerateConfigsParams represents the generate configs params
 type GenerateConfigsParams struct {
-	Pass        string
-	RetypedPass string
-	Filename    string
+	Keystore      string
+	Name          string
+	Label         string
+	Pass          string
+	RetypedPass   string
+	UseMasterPass bool
+}
+
+// GenerateConfigsFromMnemonicParams represents the generate configs from mnemonic params
+type GenerateConfigsFromMnemonicParams struct {
+	Mnemonic      string
+	Passphrase    string
+	Keystore      string
+	Name          string
+	Label         string
+	Pass          string
+	RetypedPass   string
+	UseMasterPass bool
 }
 
 // SpawnParams represents the spawn params
 type SpawnParams struct {
+	Keystore string
+	Key      string
 	Pass     string
-	Filename string
 	Dir      string
 	Port     int
+	Metrics  string
 }
 
 // SDKFunc represents the commands SDK func
 var SDKFunc = struct {
-	GenerateConfigs func(params GenerateConfigsParams) configs.Configs
-	Spawn           func(params SpawnParams) applications.Node
+	GenerateConfigs             func(params GenerateConfigsParams) configs.Configs
+	GenerateConfigsFromMnemonic func(params GenerateConfigsFromMnemonicParams) configs.Configs
+	Spawn                       func(params SpawnParams) applications.Node
 }{
 	GenerateConfigs: func(params GenerateConfigsParams) configs.Configs {
-		out, outErr := generateConfigs(params.Pass, params.RetypedPass, params.Filename)
+		out, outErr := generateConfigs(params.Keystore, params.Name, params.Label, params.Pass, params.RetypedPass, params.UseMasterPass)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	GenerateConfigsFromMnemonic: func(params GenerateConfigsFromMnemonicParams) configs.Configs {
+		out, outErr := generateConfigsFromMnemonic(params.Mnemonic, params.Passphrase, params.Keystore, params.Name, params.Label, params.Pass, params.RetypedPass, params.UseMasterPass)
 		if outErr != nil {
 			panic(outErr)
 		}
@@ -58,7 +84,7 @@ var SDKFunc = struct {
 		return out
 	},
 	Spawn: func(params SpawnParams) applications.Node {
-		out, outErr := spawn(params.Pass, params.Filename, params.Dir, params.Port)
+		out, outErr := spawn(params.Keystore, params.Key, params.Pass, params.Dir, params.Port, params.Metrics)
 		if outErr != nil {
 			panic(outErr)
 		}
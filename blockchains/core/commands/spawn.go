@@ -12,18 +12,16 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/deposit"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token/entities/information"
-	"github.com/xmnservices/xmnsuite/configs"
+	"github.com/xmnservices/xmnsuite/keystore"
 )
 
-func spawn(pass string, filename string, rootDir string, port int) (applications.Node, error) {
-	// create the repository:
-	repository := configs.SDKFunc.CreateRepository()
-
+func spawn(keystoreDir string, keyName string, pass string, rootDir string, port int, metricsAddress string) (applications.Node, error) {
 	// retrieve the configs:
-	retConf, retConfErr := repository.Retrieve(filename, pass)
-	if retConfErr != nil {
-		return nil, retConfErr
-	}
+	retConf := keystore.SDKFunc.RetrieveConfigs(keystore.RetrieveConfigsParams{
+		Dir:  keystoreDir,
+		Name: keyName,
+		Pass: pass,
+	})
 
 	wal := wallet.SDKFunc.Create(wallet.CreateParams{
 		Creator:         retConf.WalletPK().PublicKey(),
@@ -40,6 +38,7 @@ func spawn(pass string, filename string, rootDir string, port int) (applications
 		DatabaseFilePath:        filepath.Join(rootDir, databaseFilePath),
 		Peers:                   peers,
 		Meta:                    meta.SDKFunc.Create(meta.CreateParams{}),
+		MetricsAddress:          metricsAddress,
 		GenesisTransaction: genesis.SDKFunc.Create(genesis.CreateParams{
 			Info: information.SDKFunc.Create(information.CreateParams{
 				GazPricePerKb:         initialGazPricePerKB,
@@ -121,12 +121,14 @@ type CreateRepresentationParams struct {
 // CreateSDKRepositoryParams represents the CreateSDKRepository params
 type CreateSDKRepositoryParams struct {
 	PK     crypto.PrivateKey
+	Signer crypto.Signer
 	Client applications.Client
 }
 
 // CreateSDKServiceParams represents the CreateSDKService params
 type CreateSDKServiceParams struct {
 	PK     crypto.PrivateKey
+	Signer crypto.Signer
 	Client applications.Client
 }
 
@@ -187,11 +189,25 @@ var SDKFunc = struct {
 		return out
 	},
 	CreateSDKRepository: func(params CreateSDKRepositoryParams) Repository {
-		out := createSDKRepository(params.PK, params.Client)
+		signer := params.Signer
+		if signer == nil {
+			signer = crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+				PK: params.PK,
+			})
+		}
+
+		out := createSDKRepository(signer, params.Client)
 		return out
 	},
 	CreateSDKService: func(params CreateSDKServiceParams) Service {
-		out := createSDKService(params.PK, params.Client)
+		signer := params.Signer
+		if signer == nil {
+			signer = crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+				PK: params.PK,
+			})
+		}
+
+		out := createSDKService(signer, params.Client)
 		return out
 	},
 	NormalizePartialSet: func(params NormalizePartialSetParams) NormalizedPartialSet {
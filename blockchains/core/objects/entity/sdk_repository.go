@@ -13,13 +13,13 @@ import (
 )
 
 type sdkRepository struct {
-	pk     crypto.PrivateKey
+	signer crypto.Signer
 	client applications.Client
 }
 
-func createSDKRepository(pk crypto.PrivateKey, client applications.Client) Repository {
+func createSDKRepository(signer crypto.Signer, client applications.Client) Repository {
 	out := sdkRepository{
-		pk:     pk,
+		signer: signer,
 		client: client,
 	}
 	return &out
@@ -105,12 +105,15 @@ func (app *sdkRepository) RetrieveSetByIntersectKeynames(met MetaData, keynames
 
 func (app *sdkRepository) execute(path string) (routers.QueryResponse, error) {
 	queryResPtr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
-		From: app.pk.PublicKey(),
+		From: app.signer.PublicKey(),
 		Path: path,
 	})
 
 	// create the signature:
-	querySig := app.pk.Sign(queryResPtr.Hash())
+	querySig, querySigErr := app.signer.Sign(routers.QueryDomain, []byte(queryResPtr.Hash()))
+	if querySigErr != nil {
+		return nil, querySigErr
+	}
 
 	// execute a query:
 	queryResp, queryRespErr := app.client.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
@@ -10,13 +10,13 @@ import (
 )
 
 type sdkService struct {
-	pk     crypto.PrivateKey
+	signer crypto.Signer
 	client applications.Client
 }
 
-func createSDKService(pk crypto.PrivateKey, client applications.Client) Service {
+func createSDKService(signer crypto.Signer, client applications.Client) Service {
 	out := sdkService{
-		pk:     pk,
+		signer: signer,
 		client: client,
 	}
 	return &out
@@ -38,14 +38,17 @@ func (app *sdkService) Save(ins Entity, rep Representation) error {
 	route := fmt.Sprintf("/%s", rep.MetaData().Keyname())
 	firstRes := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
 		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
-			From: app.pk.PublicKey(),
+			From: app.signer.PublicKey(),
 			Path: route,
 		}),
 		Data: js,
 	})
 
 	// sign the resource:
-	firstSig := app.pk.Sign(firstRes.Hash())
+	firstSig, firstSigErr := app.signer.Sign(routers.SaveTransactionDomain, []byte(firstRes.Hash()))
+	if firstSigErr != nil {
+		return firstSigErr
+	}
 
 	// save the instance:
 	trxResp, trxRespErr := app.client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
@@ -72,12 +75,15 @@ func (app *sdkService) Save(ins Entity, rep Representation) error {
 func (app *sdkService) Delete(ins Entity, rep Representation) error {
 	// create the resource:
 	respPtr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
-		From: app.pk.PublicKey(),
+		From: app.signer.PublicKey(),
 		Path: fmt.Sprintf("/%s/%s", rep.MetaData().Keyname(), ins.ID().String()),
 	})
 
 	// sign the resource:
-	firstSig := app.pk.Sign(respPtr.Hash())
+	firstSig, firstSigErr := app.signer.Sign(routers.DeleteTransactionDomain, []byte(respPtr.Hash()))
+	if firstSigErr != nil {
+		return firstSigErr
+	}
 
 	// delete the instance:
 	trxResp, trxRespErr := app.client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
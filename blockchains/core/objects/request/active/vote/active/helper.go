@@ -29,6 +29,11 @@ func retrieveVotesByVoterIDKeyname(voterID *uuid.UUID) string {
 	return fmt.Sprintf("%s:by_voter_id:%s", base, voterID.String())
 }
 
+func retrieveVotesByKeyImageKeyname(keyImage string) string {
+	base := retrieveAllVotesKeyname()
+	return fmt.Sprintf("%s:by_key_image:%s", base, keyImage)
+}
+
 func retrieveVotesIsApprovedKeyname(isApproved bool) string {
 	base := retrieveAllVotesKeyname()
 	return fmt.Sprintf("%s:is_approved:%t", base, isApproved)
@@ -90,14 +95,23 @@ func createRepresentation() entity.Representation {
 		},
 		Keynames: func(ins entity.Entity) ([]string, error) {
 			if vot, ok := ins.(Vote); ok {
-				return []string{
+				keynames := []string{
 					retrieveAllVotesKeyname(),
 					retrieveVotesByVoteIDKeyname(vot.Vote().ID()),
 					retrieveVotesByRequestIDKeyname(vot.Vote().Request().ID()),
-					retrieveVotesByVoterIDKeyname(vot.Vote().Voter().ID()),
 					retrieveVotesIsApprovedKeyname(vot.Vote().IsApproved()),
 					retrieveVotesIsNeutralKeyname(vot.Vote().IsNeutral()),
-				}, nil
+				}
+
+				if vot.Vote().IsAnonymous() {
+					keynames = append(keynames, retrieveVotesByKeyImageKeyname(vot.Vote().RingSignature().KeyImage()))
+				}
+
+				if !vot.Vote().IsAnonymous() {
+					keynames = append(keynames, retrieveVotesByVoterIDKeyname(vot.Vote().Voter().ID()))
+				}
+
+				return keynames, nil
 			}
 
 			return nil, errors.New("the given entity is not a valid active Vote instance")
@@ -120,11 +134,23 @@ func createRepresentation() entity.Representation {
 					return errors.New(str)
 				}
 
+				// make sure the anonymous voter did not already vote, using the key image of its ring signature:
+				if vot.Vote().IsAnonymous() {
+					keyImage := vot.Vote().RingSignature().KeyImage()
+					_, retVoteByKeyImageErr := repository.RetrieveByRequestKeyImage(keyImage, vot.Vote().Request())
+					if retVoteByKeyImageErr == nil {
+						str := fmt.Sprintf("the Request (ID: %s) has already been voted on by the given ring signature key image (%s)", vot.Vote().Request().ID().String(), keyImage)
+						return errors.New(str)
+					}
+				}
+
 				// make sure the voter did not already vote:
-				_, retVoteByVoterErr := repository.RetrieveByRequestVoter(vot.Vote().Voter(), vot.Vote().Request())
-				if retVoteByVoterErr == nil {
-					str := fmt.Sprintf("the Request (ID: %s) has already been voted on by the given Voter (ID: %s)", vot.Vote().Request().ID().String(), vot.Vote().Voter().ID().String())
-					return errors.New(str)
+				if !vot.Vote().IsAnonymous() {
+					_, retVoteByVoterErr := repository.RetrieveByRequestVoter(vot.Vote().Voter(), vot.Vote().Request())
+					if retVoteByVoterErr == nil {
+						str := fmt.Sprintf("the Request (ID: %s) has already been voted on by the given Voter (ID: %s)", vot.Vote().Request().ID().String(), vot.Vote().Voter().ID().String())
+						return errors.New(str)
+					}
 				}
 
 				// make sure the core vote does not exits, then save it:
@@ -81,6 +81,27 @@ func (app *repository) RetrieveByRequestVoter(voter user.User, req active_reques
 	return nil, errors.New(str)
 }
 
+// RetrieveByRequestKeyImage retrieves an anonymous active vote by the key image of its ring signature and request
+func (app *repository) RetrieveByRequestKeyImage(keyImage string, req active_request.Request) (Vote, error) {
+	keynames := []string{
+		retrieveAllVotesKeyname(),
+		retrieveVotesByRequestIDKeyname(req.ID()),
+		retrieveVotesByKeyImageKeyname(keyImage),
+	}
+
+	ins, insErr := app.entityRepository.RetrieveByIntersectKeynames(app.metaData, keynames)
+	if insErr != nil {
+		return nil, insErr
+	}
+
+	if vot, ok := ins.(Vote); ok {
+		return vot, nil
+	}
+
+	str := fmt.Sprintf("the entity (ID: %s) is not a valid active Vote instance", ins.ID().String())
+	return nil, errors.New(str)
+}
+
 // RetrieveSetByRequest retrieves a vote set by request
 func (app *repository) RetrieveSetByRequest(req active_request.Request, index int, amount int) (entity.PartialSet, error) {
 	keynames := []string{
@@ -26,6 +26,7 @@ type Repository interface {
 	RetrieveByID(id *uuid.UUID) (Vote, error)
 	RetrieveByVote(vot core_vote.Vote) (Vote, error)
 	RetrieveByRequestVoter(voter user.User, req active_request.Request) (Vote, error)
+	RetrieveByRequestKeyImage(keyImage string, req active_request.Request) (Vote, error)
 	RetrieveSetByRequest(req active_request.Request, index int, amount int) (entity.PartialSet, error)
 	RetrieveSetByRequestWithDirection(req active_request.Request, index int, amount int, isApproved bool, isNeutral bool) (entity.PartialSet, error)
 }
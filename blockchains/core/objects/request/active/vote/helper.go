@@ -1,6 +1,8 @@
 package vote
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/user"
 	request "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active"
+	"github.com/xmnservices/xmnsuite/crypto"
 	"github.com/xmnservices/xmnsuite/datastore"
 )
 
@@ -25,6 +28,12 @@ func retrieveVotesByVoterIDKeyname(voterID *uuid.UUID) string {
 	return fmt.Sprintf("%s:by_voter_id:%s", base, voterID.String())
 }
 
+func createAnonymousVoteMessage(reqID *uuid.UUID, reason string, isNeutral bool, isApproved bool) string {
+	msg := fmt.Sprintf("/requests/%s/votes/anonymous|%t|%t|%s", reqID.String(), isNeutral, isApproved, reason)
+	hashed := sha256.Sum256([]byte(msg))
+	return hex.EncodeToString(hashed[:])
+}
+
 func createMetaData() entity.MetaData {
 	return entity.SDKFunc.CreateMetaData(entity.CreateMetaDataParams{
 		Name: "Vote",
@@ -40,11 +49,6 @@ func createMetaData() entity.MetaData {
 					return nil, reqIDErr
 				}
 
-				voterID, voterIDErr := uuid.FromString(storable.VoterID)
-				if voterIDErr != nil {
-					return nil, voterIDErr
-				}
-
 				// retrieve the request:
 				reqMet := request.SDKFunc.CreateMetaData()
 				reqIns, reqInsErr := rep.RetrieveByID(reqMet, &reqID)
@@ -52,14 +56,28 @@ func createMetaData() entity.MetaData {
 					return nil, reqInsErr
 				}
 
-				// retrieve the user:
-				usrMet := user.SDKFunc.CreateMetaData()
-				usrIns, usrInsErr := rep.RetrieveByID(usrMet, &voterID)
-				if usrInsErr != nil {
-					return nil, usrInsErr
-				}
-
 				if req, ok := reqIns.(request.Request); ok {
+					// if the vote is anonymous:
+					if storable.RingSig != "" {
+						ringSig := crypto.SDKFunc.CreateLinkableRingSig(crypto.CreateLinkableRingSigParams{
+							LinkableRingSigAsString: storable.RingSig,
+						})
+
+						return createAnonymousVote(&id, req, ringSig, storable.Reason, storable.IsNeutral, storable.IsAppr)
+					}
+
+					voterID, voterIDErr := uuid.FromString(storable.VoterID)
+					if voterIDErr != nil {
+						return nil, voterIDErr
+					}
+
+					// retrieve the user:
+					usrMet := user.SDKFunc.CreateMetaData()
+					usrIns, usrInsErr := rep.RetrieveByID(usrMet, &voterID)
+					if usrInsErr != nil {
+						return nil, usrInsErr
+					}
+
 					if usr, ok := usrIns.(user.User); ok {
 						out, outErr := createVote(&id, req, usr, storable.Reason, storable.IsNeutral, storable.IsAppr)
 						if outErr != nil {
@@ -125,11 +143,16 @@ func createRepresentation() entity.Representation {
 		},
 		Keynames: func(ins entity.Entity) ([]string, error) {
 			if vote, ok := ins.(Vote); ok {
-				return []string{
+				keynames := []string{
 					retrieveAllVotesKeyname(),
 					retrieveVotesByRequestIDKeyname(vote.Request().ID()),
-					retrieveVotesByVoterIDKeyname(vote.Voter().ID()),
-				}, nil
+				}
+
+				if !vote.IsAnonymous() {
+					keynames = append(keynames, retrieveVotesByVoterIDKeyname(vote.Voter().ID()))
+				}
+
+				return keynames, nil
 			}
 
 			return nil, errors.New("the given entity is not a valid Vote instance")
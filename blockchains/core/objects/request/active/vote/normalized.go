@@ -6,12 +6,13 @@ import (
 )
 
 type normalizedVote struct {
-	ID         string             `json:"id"`
-	Request    request.Normalized `json:"request"`
-	Voter      user.Normalized    `json:"voter"`
-	Reason     string             `json:"reason"`
-	IsNeutral  bool               `json:"is_neutral"`
-	IsApproved bool               `json:"is_approved"`
+	ID            string             `json:"id"`
+	Request       request.Normalized `json:"request"`
+	Voter         user.Normalized    `json:"voter"`
+	RingSignature string             `json:"ring_signature"`
+	Reason        string             `json:"reason"`
+	IsNeutral     bool               `json:"is_neutral"`
+	IsApproved    bool               `json:"is_approved"`
 }
 
 func createNormalizedVote(ins Vote) (*normalizedVote, error) {
@@ -20,18 +21,29 @@ func createNormalizedVote(ins Vote) (*normalizedVote, error) {
 		return nil, reqErr
 	}
 
-	voter, voterErr := user.SDKFunc.CreateMetaData().Normalize()(ins.Voter())
-	if voterErr != nil {
-		return nil, voterErr
+	var voter user.Normalized
+	if !ins.IsAnonymous() {
+		normalizedVoter, normalizedVoterErr := user.SDKFunc.CreateMetaData().Normalize()(ins.Voter())
+		if normalizedVoterErr != nil {
+			return nil, normalizedVoterErr
+		}
+
+		voter = normalizedVoter
+	}
+
+	ringSig := ""
+	if ins.IsAnonymous() {
+		ringSig = ins.RingSignature().String()
 	}
 
 	out := normalizedVote{
-		ID:         ins.ID().String(),
-		Request:    req,
-		Voter:      voter,
-		Reason:     ins.Reason(),
-		IsNeutral:  ins.IsNeutral(),
-		IsApproved: ins.IsApproved(),
+		ID:            ins.ID().String(),
+		Request:       req,
+		Voter:         voter,
+		RingSignature: ringSig,
+		Reason:        ins.Reason(),
+		IsNeutral:     ins.IsNeutral(),
+		IsApproved:    ins.IsApproved(),
 	}
 
 	return &out, nil
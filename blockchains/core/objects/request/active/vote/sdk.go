@@ -10,6 +10,9 @@ import (
 	"github.com/xmnservices/xmnsuite/crypto"
 )
 
+// AnonymousDomain represents the domain in which the messages of the anonymous votes are signed
+const AnonymousDomain = "xmnsuite/core/request/vote/anonymous"
+
 // CalculateFn represents the vote calculation func.
 // First bool = concensus is reached
 // Second bool = the vote passed
@@ -23,6 +26,8 @@ type Vote interface {
 	ID() *uuid.UUID
 	Request() request.Request
 	Voter() user.User
+	IsAnonymous() bool
+	RingSignature() crypto.LinkableRingSignature
 	Reason() string
 	IsNeutral() bool
 	IsApproved() bool
@@ -37,11 +42,20 @@ type Service interface {
 	Save(ins Vote, rep entity.Representation) error
 }
 
-// CreateParams represents the Create params
+// CreateParams represents the Create params.  The vote is anonymous when a RingSignature is provided instead of a Voter
 type CreateParams struct {
-	ID         *uuid.UUID
+	ID            *uuid.UUID
+	Request       request.Request
+	Voter         user.User
+	RingSignature crypto.LinkableRingSignature
+	Reason        string
+	IsApproved    bool
+	IsNeutral     bool
+}
+
+// CreateAnonymousMessageParams represents the CreateAnonymousMessage params.  The message is ring signed in the AnonymousDomain
+type CreateAnonymousMessageParams struct {
 	Request    request.Request
-	Voter      user.User
 	Reason     string
 	IsApproved bool
 	IsNeutral  bool
@@ -50,15 +64,17 @@ type CreateParams struct {
 // CreateSDKServiceParams represents the CreateSDKService params
 type CreateSDKServiceParams struct {
 	PK     crypto.PrivateKey
+	Signer crypto.Signer
 	Client applications.Client
 }
 
 // SDKFunc represents the vote SDK func
 var SDKFunc = struct {
-	Create               func(params CreateParams) Vote
-	CreateMetaData       func() entity.MetaData
-	CreateRepresentation func() entity.Representation
-	CreateSDKService     func(params CreateSDKServiceParams) Service
+	Create                 func(params CreateParams) Vote
+	CreateAnonymousMessage func(params CreateAnonymousMessageParams) string
+	CreateMetaData         func() entity.MetaData
+	CreateRepresentation   func() entity.Representation
+	CreateSDKService       func(params CreateSDKServiceParams) Service
 }{
 	Create: func(params CreateParams) Vote {
 		if params.ID == nil {
@@ -66,6 +82,15 @@ var SDKFunc = struct {
 			params.ID = &id
 		}
 
+		if params.RingSignature != nil {
+			out, outErr := createAnonymousVote(params.ID, params.Request, params.RingSignature, params.Reason, params.IsNeutral, params.IsApproved)
+			if outErr != nil {
+				panic(outErr)
+			}
+
+			return out
+		}
+
 		out, outErr := createVote(params.ID, params.Request, params.Voter, params.Reason, params.IsNeutral, params.IsApproved)
 		if outErr != nil {
 			panic(outErr)
@@ -73,6 +98,9 @@ var SDKFunc = struct {
 
 		return out
 	},
+	CreateAnonymousMessage: func(params CreateAnonymousMessageParams) string {
+		return createAnonymousVoteMessage(params.Request.ID(), params.Reason, params.IsNeutral, params.IsApproved)
+	},
 	CreateMetaData: func() entity.MetaData {
 		return createMetaData()
 	},
@@ -80,7 +108,14 @@ var SDKFunc = struct {
 		return createRepresentation()
 	},
 	CreateSDKService: func(params CreateSDKServiceParams) Service {
-		out := createSDKService(params.PK, params.Client)
+		signer := params.Signer
+		if signer == nil {
+			signer = crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+				PK: params.PK,
+			})
+		}
+
+		out := createSDKService(signer, params.Client)
 		return out
 	},
 }
@@ -11,21 +11,22 @@ import (
 )
 
 type outgoingVote struct {
-	ID         string `json:"id"`
-	UserID     string `json:"user_id"`
-	Reason     string `json:"reason"`
-	IsNeutral  bool   `json:"is_neutral"`
-	IsApproved bool   `json:"is_approved"`
+	ID            string `json:"id"`
+	UserID        string `json:"user_id"`
+	RingSignature string `json:"ring_signature"`
+	Reason        string `json:"reason"`
+	IsNeutral     bool   `json:"is_neutral"`
+	IsApproved    bool   `json:"is_approved"`
 }
 
 type sdkService struct {
-	pk     crypto.PrivateKey
+	signer crypto.Signer
 	client applications.Client
 }
 
-func createSDKService(pk crypto.PrivateKey, client applications.Client) Service {
+func createSDKService(signer crypto.Signer, client applications.Client) Service {
 	out := sdkService{
-		pk:     pk,
+		signer: signer,
 		client: client,
 	}
 	return &out
@@ -33,21 +34,29 @@ func createSDKService(pk crypto.PrivateKey, client applications.Client) Service
 
 // Save saves a request instance to the service
 func (app *sdkService) Save(ins Vote, rep entity.Representation) error {
-	// make sure the voter matches the pk:
-	if !ins.Voter().PubKey().Equals(app.pk.PublicKey()) {
-		str := fmt.Sprintf("the Voter PubKey was not created by the service's PK")
-		return errors.New(str)
-	}
-
 	// create the vote:
 	outVote := outgoingVote{
 		ID:         ins.ID().String(),
-		UserID:     ins.Voter().ID().String(),
 		Reason:     ins.Reason(),
 		IsNeutral:  ins.IsNeutral(),
 		IsApproved: ins.IsApproved(),
 	}
 
+	// an anonymous vote can be relayed by any PK, since it is signed by its ring signature:
+	if ins.IsAnonymous() {
+		outVote.RingSignature = ins.RingSignature().String()
+	}
+
+	if !ins.IsAnonymous() {
+		// make sure the voter matches the signer:
+		if !ins.Voter().PubKey().Equals(app.signer.PublicKey()) {
+			str := fmt.Sprintf("the Voter PubKey was not created by the service's signer")
+			return errors.New(str)
+		}
+
+		outVote.UserID = ins.Voter().ID().String()
+	}
+
 	// marshals to JSON:
 	js, jsErr := cdc.MarshalJSON(&outVote)
 	if jsErr != nil {
@@ -58,14 +67,17 @@ func (app *sdkService) Save(ins Vote, rep entity.Representation) error {
 	route := fmt.Sprintf("/%s/requests/%s", rep.MetaData().Keyname(), ins.Request().ID().String())
 	firstRes := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
 		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
-			From: app.pk.PublicKey(),
+			From: app.signer.PublicKey(),
 			Path: route,
 		}),
 		Data: js,
 	})
 
 	// sign the resource:
-	firstSig := app.pk.Sign(firstRes.Hash())
+	firstSig, firstSigErr := app.signer.Sign(routers.SaveTransactionDomain, []byte(firstRes.Hash()))
+	if firstSigErr != nil {
+		return firstSigErr
+	}
 
 	// save the instance:
 	trxResp, trxRespErr := app.client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
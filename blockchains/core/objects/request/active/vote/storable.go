@@ -4,16 +4,28 @@ type storableVote struct {
 	ID        string `json:"id"`
 	ReqID     string `json:"request_id"`
 	VoterID   string `json:"voter_id"`
+	RingSig   string `json:"ring_signature"`
 	Reason    string `json:"reason"`
 	IsNeutral bool   `json:"is_neutral"`
 	IsAppr    bool   `json:"is_approved"`
 }
 
 func createStorableVote(vote Vote) *storableVote {
+	voterID := ""
+	if !vote.IsAnonymous() {
+		voterID = vote.Voter().ID().String()
+	}
+
+	ringSig := ""
+	if vote.IsAnonymous() {
+		ringSig = vote.RingSignature().String()
+	}
+
 	out := storableVote{
 		ID:        vote.ID().String(),
 		ReqID:     vote.Request().ID().String(),
-		VoterID:   vote.Voter().ID().String(),
+		VoterID:   voterID,
+		RingSig:   ringSig,
 		Reason:    vote.Reason(),
 		IsNeutral: vote.IsNeutral(),
 		IsAppr:    vote.IsApproved(),
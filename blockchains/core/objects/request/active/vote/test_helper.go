@@ -18,8 +18,19 @@ func CompareVoteForTests(t *testing.T, first Vote, second Vote) {
 		return
 	}
 
+	if first.IsAnonymous() != second.IsAnonymous() {
+		t.Errorf("the isAnonymous is invalid, expected: %t, returned: %t", first.IsAnonymous(), second.IsAnonymous())
+		return
+	}
+
+	// compare the key images of anonymous votes:
+	if first.IsAnonymous() && !first.RingSignature().IsLinked(second.RingSignature()) {
+		t.Errorf("the ring signature key image is invalid, expected: %s, returned: %s", first.RingSignature().KeyImage(), second.RingSignature().KeyImage())
+		return
+	}
+
 	// compare the voter IDs:
-	if !reflect.DeepEqual(first.Voter().ID(), second.Voter().ID()) {
+	if !first.IsAnonymous() && !reflect.DeepEqual(first.Voter().ID(), second.Voter().ID()) {
 		t.Errorf("the voter ID is invalid, expected: %s, returned: %s", first.Voter().ID().String(), second.Voter().ID().String())
 		return
 	}
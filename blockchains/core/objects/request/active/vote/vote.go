@@ -7,18 +7,43 @@ import (
 	uuid "github.com/satori/go.uuid"
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/user"
 	request "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active"
+	"github.com/xmnservices/xmnsuite/crypto"
 )
 
 type vote struct {
-	UUID     *uuid.UUID      `json:"id"`
-	Req      request.Request `json:"request"`
-	Votr     user.User       `json:"voter"`
-	Rson     string          `json:"reason"`
-	IsNeutrl bool            `json:"is_neutral"`
-	IsAppr   bool            `json:"is_approved"`
+	UUID     *uuid.UUID                   `json:"id"`
+	Req      request.Request              `json:"request"`
+	Votr     user.User                    `json:"voter"`
+	RingSig  crypto.LinkableRingSignature `json:"ring_signature"`
+	Rson     string                       `json:"reason"`
+	IsNeutrl bool                         `json:"is_neutral"`
+	IsAppr   bool                         `json:"is_approved"`
 }
 
 func createVote(id *uuid.UUID, req request.Request, votr user.User, reason string, isNeutrl bool, isApproved bool) (Vote, error) {
+	if votr == nil {
+		return nil, errors.New("the voter is mandatory in order to create a Vote")
+	}
+
+	return createVoteWithVoterOrRingSignature(id, req, votr, nil, reason, isNeutrl, isApproved)
+}
+
+func createAnonymousVote(id *uuid.UUID, req request.Request, ringSig crypto.LinkableRingSignature, reason string, isNeutrl bool, isApproved bool) (Vote, error) {
+	if ringSig == nil {
+		return nil, errors.New("the ring signature is mandatory in order to create an anonymous Vote")
+	}
+
+	// make sure the ring signature signed the vote:
+	msg := createAnonymousVoteMessage(req.ID(), reason, isNeutrl, isApproved)
+	if !ringSig.VerifyBytes(AnonymousDomain, []byte(msg)) {
+		str := fmt.Sprintf("the ring signature of the anonymous Vote (ID: %s) cannot be verified", id.String())
+		return nil, errors.New(str)
+	}
+
+	return createVoteWithVoterOrRingSignature(id, req, nil, ringSig, reason, isNeutrl, isApproved)
+}
+
+func createVoteWithVoterOrRingSignature(id *uuid.UUID, req request.Request, votr user.User, ringSig crypto.LinkableRingSignature, reason string, isNeutrl bool, isApproved bool) (Vote, error) {
 	if isNeutrl == isApproved {
 		str := fmt.Sprintf("the vote cannot have the same value for neutral and approved")
 		return nil, errors.New(str)
@@ -28,6 +53,7 @@ func createVote(id *uuid.UUID, req request.Request, votr user.User, reason strin
 		UUID:     id,
 		Req:      req,
 		Votr:     votr,
+		RingSig:  ringSig,
 		Rson:     reason,
 		IsNeutrl: isNeutrl,
 		IsAppr:   isApproved,
@@ -47,12 +73,20 @@ func createVoteFromNormalized(normalized *normalizedVote) (Vote, error) {
 		return nil, reqInsErr
 	}
 
-	voterIns, voterInsErr := user.SDKFunc.CreateMetaData().Denormalize()(normalized.Voter)
-	if voterInsErr != nil {
-		return nil, voterInsErr
-	}
-
 	if req, ok := reqIns.(request.Request); ok {
+		if normalized.RingSignature != "" {
+			ringSig := crypto.SDKFunc.CreateLinkableRingSig(crypto.CreateLinkableRingSigParams{
+				LinkableRingSigAsString: normalized.RingSignature,
+			})
+
+			return createAnonymousVote(&id, req, ringSig, normalized.Reason, normalized.IsNeutral, normalized.IsApproved)
+		}
+
+		voterIns, voterInsErr := user.SDKFunc.CreateMetaData().Denormalize()(normalized.Voter)
+		if voterInsErr != nil {
+			return nil, voterInsErr
+		}
+
 		if voter, ok := voterIns.(user.User); ok {
 			return createVote(&id, req, voter, normalized.Reason, normalized.IsNeutral, normalized.IsApproved)
 		}
@@ -75,11 +109,21 @@ func (obj *vote) Request() request.Request {
 	return obj.Req
 }
 
-// Voter returns the voter
+// Voter returns the voter, if any
 func (obj *vote) Voter() user.User {
 	return obj.Votr
 }
 
+// IsAnonymous returns true if the vote is signed by a ring signature instead of a voter, false otherwise
+func (obj *vote) IsAnonymous() bool {
+	return obj.RingSig != nil
+}
+
+// RingSignature returns the ring signature, if any
+func (obj *vote) RingSignature() crypto.LinkableRingSignature {
+	return obj.RingSig
+}
+
 // IsApproved returns true if the vote is approved, false otherwise
 func (obj *vote) Reason() string {
 	return obj.Rson
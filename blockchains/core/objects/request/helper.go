@@ -14,6 +14,11 @@ func retrieveAllRequestsKeyname() string {
 	return "requests"
 }
 
+func createMultiSigMessage(keyname string, requestJS []byte) []byte {
+	out := []byte(fmt.Sprintf("/%s/requests/multisig", keyname))
+	return append(out, requestJS...)
+}
+
 func createMetaData(reg *registry) entity.MetaData {
 	return entity.SDKFunc.CreateMetaData(entity.CreateMetaDataParams{
 		Name: "Request",
@@ -11,8 +11,12 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/keyname"
 	"github.com/xmnservices/xmnsuite/crypto"
 	"github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/routers"
 )
 
+// MultiSigDomain represents the domain in which the co-signers of a request sign its multi-signature message
+const MultiSigDomain = "xmnsuite/core/request/multisig"
+
 // Request represents an entity request
 type Request interface {
 	ID() *uuid.UUID
@@ -28,6 +32,8 @@ type Request interface {
 // Service represents an entity service
 type Service interface {
 	Save(req Request, entityRep entity.Representation) error
+	MultiSigMessage(req Request, entityRep entity.Representation) ([]byte, error)
+	SaveWithMultiSig(req Request, entityRep entity.Representation, multiSig crypto.MultiSignature) error
 }
 
 // Normalized represents a normalized request
@@ -52,18 +58,34 @@ type RegisterParams struct {
 // CreateSDKServiceParams represents the CreateSDKService params
 type CreateSDKServiceParams struct {
 	PK     crypto.PrivateKey
+	Signer crypto.Signer
 	Client applications.Client
 }
 
+// CreateResourceParams represents the CreateResource params
+type CreateResourceParams struct {
+	From                 crypto.PublicKey
+	Request              Request
+	EntityRepresentation entity.Representation
+}
+
+// CreateMultiSigMessageParams represents the CreateMultiSigMessage params.  The message is signed in the MultiSigDomain
+type CreateMultiSigMessageParams struct {
+	Keyname     string
+	RequestJSON []byte
+}
+
 var reg = createRegistry()
 
 // SDKFunc represents the request SDK func
 var SDKFunc = struct {
-	Create               func(params CreateParams) Request
-	Register             func(params RegisterParams)
-	CreateMetaData       func() entity.MetaData
-	CreateRepresentation func() entity.Representation
-	CreateSDKService     func(params CreateSDKServiceParams) Service
+	Create                func(params CreateParams) Request
+	Register              func(params RegisterParams)
+	CreateMetaData        func() entity.MetaData
+	CreateRepresentation  func() entity.Representation
+	CreateSDKService      func(params CreateSDKServiceParams) Service
+	CreateMultiSigMessage func(params CreateMultiSigMessageParams) []byte
+	CreateResource        func(params CreateResourceParams) routers.Resource
 }{
 	Create: func(params CreateParams) Request {
 		if params.ID == nil {
@@ -151,7 +173,25 @@ var SDKFunc = struct {
 		})
 	},
 	CreateSDKService: func(params CreateSDKServiceParams) Service {
-		out := createSDKService(params.PK, params.Client)
+		signer := params.Signer
+		if signer == nil {
+			signer = crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+				PK: params.PK,
+			})
+		}
+
+		out := createSDKService(signer, params.Client)
+		return out
+	},
+	CreateMultiSigMessage: func(params CreateMultiSigMessageParams) []byte {
+		return createMultiSigMessage(params.Keyname, params.RequestJSON)
+	},
+	CreateResource: func(params CreateResourceParams) routers.Resource {
+		out, outErr := createResource(params.From, params.Request, params.EntityRepresentation)
+		if outErr != nil {
+			panic(outErr)
+		}
+
 		return out
 	},
 }
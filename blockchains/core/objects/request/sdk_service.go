@@ -18,14 +18,19 @@ type outgoingRequest struct {
 	DeleteEntityJSON []byte `json:"delete_entity_json"`
 }
 
+type outgoingMultiSigRequest struct {
+	RequestJSON []byte `json:"request_json"`
+	MultiSig    string `json:"multisig"`
+}
+
 type sdkService struct {
-	pk     crypto.PrivateKey
+	signer crypto.Signer
 	client applications.Client
 }
 
-func createSDKService(pk crypto.PrivateKey, client applications.Client) Service {
+func createSDKService(signer crypto.Signer, client applications.Client) Service {
 	out := sdkService{
-		pk:     pk,
+		signer: signer,
 		client: client,
 	}
 	return &out
@@ -33,19 +38,74 @@ func createSDKService(pk crypto.PrivateKey, client applications.Client) Service
 
 // Save saves a request instance to the service
 func (app *sdkService) Save(req Request, rep entity.Representation) error {
+	res, resErr := createResource(app.signer.PublicKey(), req, rep)
+	if resErr != nil {
+		return resErr
+	}
+
+	return app.transactResource(res)
+}
+
+// MultiSigMessage returns the message the co-signers of a request must sign in the MultiSigDomain
+func (app *sdkService) MultiSigMessage(req Request, rep entity.Representation) ([]byte, error) {
+	js, jsErr := toJSON(req, rep)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	return createMultiSigMessage(req.Keyname().Name(), js), nil
+}
 
+// SaveWithMultiSig saves a request instance, approved by the co-signers of the multi-signature, to the service
+func (app *sdkService) SaveWithMultiSig(req Request, rep entity.Representation, multiSig crypto.MultiSignature) error {
+	js, jsErr := toJSON(req, rep)
+	if jsErr != nil {
+		return jsErr
+	}
+
+	multiSigJS, multiSigJSErr := cdc.MarshalJSON(&outgoingMultiSigRequest{
+		RequestJSON: js,
+		MultiSig:    multiSig.String(),
+	})
+
+	if multiSigJSErr != nil {
+		return multiSigJSErr
+	}
+
+	route := fmt.Sprintf("/%s/requests/multisig", req.Keyname().Name())
+	return app.transact(route, multiSigJS)
+}
+
+func createResource(from crypto.PublicKey, req Request, rep entity.Representation) (routers.Resource, error) {
+	js, jsErr := toJSON(req, rep)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	out := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: from,
+			Path: fmt.Sprintf("/%s/requests", req.Keyname().Name()),
+		}),
+		Data: js,
+	})
+
+	return out, nil
+}
+
+func toJSON(req Request, rep entity.Representation) ([]byte, error) {
 	var toSaveEntity []byte
 	var toDeleteEntity []byte
 
 	if req.HasSave() {
 		normalized, normalizedErr := rep.MetaData().Normalize()(req.Save())
 		if normalizedErr != nil {
-			return normalizedErr
+			return nil, normalizedErr
 		}
 
 		insJS, insJSErr := cdc.MarshalJSON(normalized)
 		if insJSErr != nil {
-			return insJSErr
+			return nil, insJSErr
 		}
 
 		toSaveEntity = insJS
@@ -54,12 +114,12 @@ func (app *sdkService) Save(req Request, rep entity.Representation) error {
 	if req.HasDelete() {
 		normalized, normalizedErr := rep.MetaData().Normalize()(req.Delete())
 		if normalizedErr != nil {
-			return normalizedErr
+			return nil, normalizedErr
 		}
 
 		insJS, insJSErr := cdc.MarshalJSON(normalized)
 		if insJSErr != nil {
-			return insJSErr
+			return nil, insJSErr
 		}
 
 		toDeleteEntity = insJS
@@ -73,23 +133,28 @@ func (app *sdkService) Save(req Request, rep entity.Representation) error {
 		DeleteEntityJSON: toDeleteEntity,
 	}
 
-	js, jsErr := cdc.MarshalJSON(&outReq)
-	if jsErr != nil {
-		return jsErr
-	}
+	return cdc.MarshalJSON(&outReq)
+}
 
+func (app *sdkService) transact(route string, js []byte) error {
 	// create the resource:
-	route := fmt.Sprintf("/%s/requests", req.Keyname().Name())
 	firstRes := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
 		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
-			From: app.pk.PublicKey(),
+			From: app.signer.PublicKey(),
 			Path: route,
 		}),
 		Data: js,
 	})
 
+	return app.transactResource(firstRes)
+}
+
+func (app *sdkService) transactResource(firstRes routers.Resource) error {
 	// sign the resource:
-	firstSig := app.pk.Sign(firstRes.Hash())
+	firstSig, firstSigErr := app.signer.Sign(routers.SaveTransactionDomain, []byte(firstRes.Hash()))
+	if firstSigErr != nil {
+		return firstSigErr
+	}
 
 	// save the instance:
 	trxResp, trxRespErr := app.client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
@@ -105,7 +170,7 @@ func (app *sdkService) Save(req Request, rep entity.Representation) error {
 	chkCode := chk.Code()
 	if chkCode != routers.IsSuccessful {
 		trx := trxResp.Transaction()
-		str := fmt.Sprintf("there was an error (Check Code: %d, Trx Code: %d) while executing the transaction: (Check Log: %s, Trx Log: %s, Route: %s)", chkCode, trx.Code(), chk.Log(), trx.Log(), route)
+		str := fmt.Sprintf("there was an error (Check Code: %d, Trx Code: %d) while executing the transaction: (Check Log: %s, Trx Log: %s, Route: %s)", chkCode, trx.Code(), chk.Log(), trx.Log(), firstRes.Pointer().Path())
 		return errors.New(str)
 	}
 
@@ -0,0 +1,25 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token/entities/link"
+)
+
+func createDiscover(repository Repository, lnk link.Link) func() ([]string, error) {
+	return func() ([]string, error) {
+		nodes, nodesErr := repository.RetrieveByLink(lnk)
+		if nodesErr != nil {
+			str := fmt.Sprintf("there was an error while retrieving the Node instances of the Link (ID: %s): %s", lnk.ID().String(), nodesErr.Error())
+			return nil, errors.New(str)
+		}
+
+		out := []string{}
+		for _, oneNode := range nodes {
+			out = append(out, fmt.Sprintf("tcp://%s:%d", oneNode.IP().String(), oneNode.Port()))
+		}
+
+		return out, nil
+	}
+}
@@ -37,11 +37,18 @@ type CreateParams struct {
 	Port  int
 }
 
+// CreateDiscoverParams represents the CreateDiscover params
+type CreateDiscoverParams struct {
+	Repository Repository
+	Link       link.Link
+}
+
 // SDKFunc represents the Link SDK func
 var SDKFunc = struct {
 	Create               func(params CreateParams) Node
 	CreateMetaData       func() entity.MetaData
 	CreateRepresentation func() entity.Representation
+	CreateDiscover       func(params CreateDiscoverParams) func() ([]string, error)
 }{
 	Create: func(params CreateParams) Node {
 		if params.ID == nil {
@@ -74,4 +81,11 @@ var SDKFunc = struct {
 			},
 		})
 	},
+	CreateDiscover: func(params CreateDiscoverParams) func() ([]string, error) {
+		if params.Repository == nil || params.Link == nil {
+			panic(errors.New("the Repository and Link are mandatory in order to create a discover func"))
+		}
+
+		return createDiscover(params.Repository, params.Link)
+	},
 }
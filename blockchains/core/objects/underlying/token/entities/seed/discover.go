@@ -0,0 +1,25 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token/entities/link"
+)
+
+func createDiscover(repository Repository, lnk link.Link) func() ([]string, error) {
+	return func() ([]string, error) {
+		seeds, seedsErr := repository.RetrieveByLink(lnk)
+		if seedsErr != nil {
+			str := fmt.Sprintf("there was an error while retrieving the Seed instances of the Link (ID: %s): %s", lnk.ID().String(), seedsErr.Error())
+			return nil, errors.New(str)
+		}
+
+		out := []string{}
+		for _, oneSeed := range seeds {
+			out = append(out, fmt.Sprintf("tcp://%s:%d", oneSeed.IP().String(), oneSeed.Port()))
+		}
+
+		return out, nil
+	}
+}
@@ -21,6 +21,7 @@ type CreateParams struct {
 	Store         datastore.StoredDataStore
 	Meta          meta.Meta
 	RootPubKey    crypto.PublicKey
+	OnRoute       applications.OnRoute
 }
 
 // SDKFunc represents the core SDK func
@@ -38,6 +39,7 @@ var SDKFunc = struct {
 				params.RouterRoleKey,
 				params.Store,
 				params.Meta,
+				params.OnRoute,
 			)
 		}
 
@@ -50,6 +52,7 @@ var SDKFunc = struct {
 			params.Store,
 			params.Meta,
 			params.RootPubKey,
+			params.OnRoute,
 		)
 	},
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token/entities/node"
 	community_project "github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token/entities/project"
 	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/datastore"
 )
 
 type simpleRequestVote struct {
@@ -82,6 +83,39 @@ func spawnBlockchainForTests(t *testing.T, pk crypto.PrivateKey, rootPath string
 	return node, client, entityService, entityRepository
 }
 
+func createMemoryBlockchainForTests(pk crypto.PrivateKey) (applications.Client, entity.Service, entity.Repository) {
+	// variables:
+	namespace := "xmn"
+	name := "core"
+	id := uuid.NewV4()
+	routerRoleKey := "router-role"
+
+	// create the applications on an in-memory datastore:
+	met := meta.SDKFunc.Create(meta.CreateParams{})
+	store := datastore.SDKFunc.CreateMemoryStore()
+	apps := createApplicationsWithRootPubKey(namespace, name, &id, "", routerRoleKey, store, met, pk.PublicKey(), nil)
+
+	// create the in-memory client:
+	client := applications.SDKFunc.CreateMemoryClient(applications.CreateMemoryClientParams{
+		Apps: apps,
+	})
+
+	// create the entity service:
+	entityService := entity.SDKFunc.CreateSDKService(entity.CreateSDKServiceParams{
+		PK:     pk,
+		Client: client,
+	})
+
+	// create the entity repository:
+	entityRepository := entity.SDKFunc.CreateSDKRepository(entity.CreateSDKRepositoryParams{
+		PK:     pk,
+		Client: client,
+	})
+
+	// returns:
+	return client, entityService, entityRepository
+}
+
 func spawnBlockchainWithGenesisForTests(t *testing.T, pk crypto.PrivateKey, rootPath string, genIns genesis.Genesis) (applications.Node, applications.Client, entity.Service, entity.Repository) {
 	// sopawn the blockchain:
 	node, client, service, repository := spawnBlockchainForTests(t, pk, rootPath)
@@ -0,0 +1,46 @@
+package history
+
+import (
+	amino "github.com/tendermint/go-amino"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+const (
+	// XMNSuiteHistoryTransaction represents the xmnsuite history Transaction resource
+	XMNSuiteHistoryTransaction = "xmnsuite/history/Transaction"
+
+	// XMNSuiteHistoryPartialSet represents the xmnsuite history PartialSet resource
+	XMNSuiteHistoryPartialSet = "xmnsuite/history/PartialSet"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	Register(cdc)
+}
+
+// Register registers all the interface -> struct to amino
+func Register(codec *amino.Codec) {
+	// Dependencies
+	crypto.Register(codec)
+	routers.Register(codec)
+
+	// Transaction
+	func() {
+		defer func() {
+			recover()
+		}()
+		codec.RegisterInterface((*Transaction)(nil), nil)
+		codec.RegisterConcrete(&transaction{}, XMNSuiteHistoryTransaction, nil)
+	}()
+
+	// PartialSet
+	func() {
+		defer func() {
+			recover()
+		}()
+		codec.RegisterInterface((*PartialSet)(nil), nil)
+		codec.RegisterConcrete(&partialSet{}, XMNSuiteHistoryPartialSet, nil)
+	}()
+}
@@ -0,0 +1,132 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+// the controllers query the datastore of the history, instead of the datastore of the application:
+type controllers struct {
+	ds datastore.DataStore
+}
+
+func createControllers(ds datastore.DataStore) *controllers {
+	out := controllers{
+		ds: ds,
+	}
+
+	return &out
+}
+
+/*
+ * RetrieveByHash
+ * Expected params:
+ *      <hash|[0-9a-f]+>
+ */
+func (app *controllers) retrieveByHash() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: "/history/transactions/<hash|[0-9a-f]+>",
+		QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+			// retrieve the transaction:
+			repository := createRepository(app.ds)
+			trx, trxErr := repository.RetrieveByHash(params["hash"])
+			if trxErr != nil {
+				return nil, trxErr
+			}
+
+			// convert the transaction to json:
+			js, jsErr := cdc.MarshalJSON(trx)
+			if jsErr != nil {
+				return nil, jsErr
+			}
+
+			// return the response:
+			resp := routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+				Code:  routers.IsSuccessful,
+				Log:   "success",
+				Key:   path,
+				Value: js,
+			})
+
+			return resp, nil
+		},
+	}
+}
+
+/*
+ * RetrieveSetBy
+ * Expected params:
+ *      <value|[0-9a-f-]+>
+ *      <index|[0-9]+>
+ *      <amount|[0-9]+>
+ */
+func (app *controllers) retrieveSetBy(by string) routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: fmt.Sprintf("/history/%s/<value|[0-9a-f-]+>/<index|[0-9]+>/<amount|[0-9]+>", by),
+		QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+			index, indexErr := strconv.Atoi(params["index"])
+			if indexErr != nil {
+				str := fmt.Sprintf("the given index (%s) is invalid: %s", params["index"], indexErr.Error())
+				return nil, errors.New(str)
+			}
+
+			amount, amountErr := strconv.Atoi(params["amount"])
+			if amountErr != nil {
+				str := fmt.Sprintf("the given amount (%s) is invalid: %s", params["amount"], amountErr.Error())
+				return nil, errors.New(str)
+			}
+
+			if amount > maxAmountOfTransactionsToRetrieve {
+				amount = maxAmountOfTransactionsToRetrieve
+			}
+
+			// decode the value:
+			value, valueErr := decodeIndexValue(by, params["value"])
+			if valueErr != nil {
+				str := fmt.Sprintf("the given value (%s) is invalid: %s", params["value"], valueErr.Error())
+				return nil, errors.New(str)
+			}
+
+			// the resources must be valid IDs:
+			if by == byResource {
+				_, idErr := uuid.FromString(value)
+				if idErr != nil {
+					str := fmt.Sprintf("the given ID (%s) is invalid: %s", value, idErr.Error())
+					return nil, errors.New(str)
+				}
+			}
+
+			// retrieve the partial set:
+			rep := repository{
+				ds: app.ds,
+			}
+
+			ps, psErr := rep.retrieveSet(createIndexKey(by, value), index, amount)
+			if psErr != nil {
+				return nil, psErr
+			}
+
+			// convert the partial set to json:
+			js, jsErr := cdc.MarshalJSON(ps)
+			if jsErr != nil {
+				return nil, jsErr
+			}
+
+			// return the response:
+			resp := routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+				Code:  routers.IsSuccessful,
+				Log:   "success",
+				Key:   path,
+				Value: js,
+			})
+
+			return resp, nil
+		},
+	}
+}
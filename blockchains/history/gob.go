@@ -0,0 +1,9 @@
+package history
+
+import (
+	"encoding/gob"
+)
+
+func init() {
+	gob.Register(&storableTransaction{})
+}
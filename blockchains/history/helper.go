@@ -0,0 +1,91 @@
+package history
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	byHash     = "hashes"
+	bySigner   = "signers"
+	byPath     = "paths"
+	byTag      = "tags"
+	byResource = "resources"
+)
+
+const maxAmountOfTransactionsToRetrieve = 500
+
+var resourceIDPattern = regexp.MustCompile("[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}")
+
+// the same transaction can be delivered more than once, therefore every delivery is saved under its own reference:
+func createTransactionReference(hash string, height int64, occurrence int) string {
+	if occurrence <= 0 {
+		return fmt.Sprintf("%s:%d", hash, height)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", hash, height, occurrence)
+}
+
+func createTransactionKey(reference string) string {
+	return fmt.Sprintf("history:transactions:%s", reference)
+}
+
+func createIndexKey(by string, value string) string {
+	return fmt.Sprintf("history:%s:%s", by, value)
+}
+
+func createTransactionQueryPath(hash string) string {
+	return fmt.Sprintf("/history/transactions/%s", hash)
+}
+
+func createIndexQueryPath(by string, value string, index int, amount int) string {
+	return fmt.Sprintf("/history/%s/%s/%d/%d", by, encodeIndexValue(by, value), index, amount)
+}
+
+// paths and tags can contain slashes, therefore they are hex-encoded in the query paths:
+func encodeIndexValue(by string, value string) string {
+	if by == byPath || by == byTag {
+		return hex.EncodeToString([]byte(value))
+	}
+
+	return value
+}
+
+func decodeIndexValue(by string, value string) (string, error) {
+	if by == byPath || by == byTag {
+		decoded, decodedErr := hex.DecodeString(value)
+		if decodedErr != nil {
+			return "", decodedErr
+		}
+
+		return string(decoded), nil
+	}
+
+	return value, nil
+}
+
+func findResources(elements ...string) []*uuid.UUID {
+	out := []*uuid.UUID{}
+	found := map[string]bool{}
+	for _, oneElement := range elements {
+		ids := resourceIDPattern.FindAllString(oneElement, -1)
+		for _, oneIDAsString := range ids {
+			if _, ok := found[oneIDAsString]; ok {
+				continue
+			}
+
+			id, idErr := uuid.FromString(oneIDAsString)
+			if idErr != nil {
+				continue
+			}
+
+			found[oneIDAsString] = true
+			out = append(out, &id)
+		}
+	}
+
+	return out
+}
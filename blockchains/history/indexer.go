@@ -0,0 +1,67 @@
+package history
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+// createIndexer creates an indexer that saves the transactions in the given datastore, outside of the state of the application
+func createIndexer(store datastore.DataStore) applications.OnTransaction {
+	return func(height int64, hash []byte, req routers.TransactionRequest, resp routers.TransactionResponse) error {
+		return index(store, height, hash, req, resp)
+	}
+}
+
+func index(store datastore.DataStore, height int64, txHash []byte, req routers.TransactionRequest, resp routers.TransactionResponse) error {
+	// the hash is the same as the one the node returns to the client:
+	hash := hex.EncodeToString(txHash)
+
+	// fetch the method, pointer and data:
+	method := routers.Delete
+	ptr := req.Pointer()
+	elements := []string{}
+	if res := req.Resource(); res != nil {
+		method = routers.Save
+		ptr = res.Pointer()
+		elements = append(elements, string(res.Data()))
+	}
+
+	// sort the tags, so that the indexes are deterministic:
+	respTags := resp.Tags()
+	tags := []string{}
+	for oneTag := range respTags {
+		tags = append(tags, oneTag)
+	}
+
+	sort.Strings(tags)
+
+	// the resources are the IDs found in the path, the data and the tags:
+	elements = append(elements, ptr.Path())
+	for _, oneTag := range tags {
+		elements = append(elements, oneTag, string(respTags[oneTag]))
+	}
+
+	trx, trxErr := createTransaction(
+		hash,
+		height,
+		ptr.From(),
+		ptr.Path(),
+		method,
+		resp.Code(),
+		resp.Log(),
+		resp.GazUsed(),
+		tags,
+		findResources(elements...),
+	)
+
+	if trxErr != nil {
+		return trxErr
+	}
+
+	// save the transaction:
+	return createService(store).Save(trx)
+}
@@ -0,0 +1,58 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+)
+
+type partialSet struct {
+	Trx   []Transaction `json:"transactions"`
+	Idx   int           `json:"index"`
+	TotAm int           `json:"total_amount"`
+}
+
+func createPartialSet(trx []Transaction, index int, totalAmount int) (PartialSet, error) {
+	if index < 0 {
+		str := fmt.Sprintf("the index (%d) cannot be smaller than 0", index)
+		return nil, errors.New(str)
+	}
+
+	minAmount := (index + len(trx))
+	if totalAmount < minAmount {
+		str := fmt.Sprintf("the totalAmount (%d) cannot be smaller than the index + the length of the transactions (%d)", totalAmount, minAmount)
+		return nil, errors.New(str)
+	}
+
+	out := partialSet{
+		Trx:   trx,
+		Idx:   index,
+		TotAm: totalAmount,
+	}
+
+	return &out, nil
+}
+
+// Transactions returns the transactions
+func (obj *partialSet) Transactions() []Transaction {
+	return obj.Trx
+}
+
+// Index returns the index
+func (obj *partialSet) Index() int {
+	return obj.Idx
+}
+
+// Amount returns the amount
+func (obj *partialSet) Amount() int {
+	return len(obj.Trx)
+}
+
+// TotalAmount returns the totalAmount
+func (obj *partialSet) TotalAmount() int {
+	return obj.TotAm
+}
+
+// IsLast returns true if this is the last element of the partial set, false otherwise
+func (obj *partialSet) IsLast() bool {
+	return (obj.Index() + obj.Amount()) >= obj.TotalAmount()
+}
@@ -0,0 +1,92 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/datastore/objects"
+)
+
+type repository struct {
+	ds datastore.DataStore
+}
+
+func createRepository(ds datastore.DataStore) Repository {
+	out := repository{
+		ds: ds,
+	}
+
+	return &out
+}
+
+// RetrieveByHash retrieves a transaction by hash.  When the transaction has been delivered more than once, its latest delivery is returned
+func (app *repository) RetrieveByHash(hash string) (Transaction, error) {
+	key := createIndexKey(byHash, hash)
+	amount := app.ds.Lists().Len(key)
+	if amount <= 0 {
+		str := fmt.Sprintf("there is no transaction indexed with hash: %s", hash)
+		return nil, errors.New(str)
+	}
+
+	references := app.ds.Lists().Retrieve(key, amount-1, 1)
+	if len(references) != 1 {
+		str := fmt.Sprintf("the latest transaction indexed with hash: %s could not be retrieved", hash)
+		return nil, errors.New(str)
+	}
+
+	return app.retrieveByReference(references[0].(string))
+}
+
+func (app *repository) retrieveByReference(reference string) (Transaction, error) {
+	obj := objects.ObjInKey{
+		Key: createTransactionKey(reference),
+		Obj: new(storableTransaction),
+	}
+
+	amount := app.ds.Objects().Retrieve(&obj)
+	if amount != 1 {
+		str := fmt.Sprintf("there is no transaction indexed with reference: %s", reference)
+		return nil, errors.New(str)
+	}
+
+	return createTransactionFromStorable(obj.Obj.(*storableTransaction))
+}
+
+// RetrieveSetBySigner retrieves a transaction partial set by signer
+func (app *repository) RetrieveSetBySigner(signer crypto.PublicKey, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexKey(bySigner, signer.String()), index, amount)
+}
+
+// RetrieveSetByPath retrieves a transaction partial set by path
+func (app *repository) RetrieveSetByPath(path string, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexKey(byPath, path), index, amount)
+}
+
+// RetrieveSetByTag retrieves a transaction partial set by tag
+func (app *repository) RetrieveSetByTag(tag string, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexKey(byTag, tag), index, amount)
+}
+
+// RetrieveSetByResource retrieves a transaction partial set by the ID of a resource it touched
+func (app *repository) RetrieveSetByResource(id *uuid.UUID, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexKey(byResource, id.String()), index, amount)
+}
+
+func (app *repository) retrieveSet(key string, index int, amount int) (PartialSet, error) {
+	references := app.ds.Lists().Retrieve(key, index, amount)
+	trx := []Transaction{}
+	for _, oneReference := range references {
+		oneTrx, oneTrxErr := app.retrieveByReference(oneReference.(string))
+		if oneTrxErr != nil {
+			return nil, oneTrxErr
+		}
+
+		trx = append(trx, oneTrx)
+	}
+
+	totalAmount := app.ds.Lists().Len(key)
+	return createPartialSet(trx, index, totalAmount)
+}
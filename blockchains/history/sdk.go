@@ -0,0 +1,162 @@
+package history
+
+import (
+	"errors"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+// Transaction represents an indexed transaction
+type Transaction interface {
+	Hash() string
+	Height() int64
+	Signer() crypto.PublicKey
+	Path() string
+	Method() int
+	Code() int
+	Log() string
+	GazUsed() int64
+	Tags() []string
+	Resources() []*uuid.UUID
+}
+
+// PartialSet represents a transaction partial set
+type PartialSet interface {
+	Transactions() []Transaction
+	Index() int
+	Amount() int
+	TotalAmount() int
+	IsLast() bool
+}
+
+// Repository represents a transaction history repository
+type Repository interface {
+	RetrieveByHash(hash string) (Transaction, error)
+	RetrieveSetBySigner(signer crypto.PublicKey, index int, amount int) (PartialSet, error)
+	RetrieveSetByPath(path string, index int, amount int) (PartialSet, error)
+	RetrieveSetByTag(tag string, index int, amount int) (PartialSet, error)
+	RetrieveSetByResource(id *uuid.UUID, index int, amount int) (PartialSet, error)
+}
+
+// Service represents a transaction history service
+type Service interface {
+	Save(trx Transaction) error
+}
+
+// CreateTransactionParams represents the CreateTransaction params
+type CreateTransactionParams struct {
+	Hash      string
+	Height    int64
+	Signer    crypto.PublicKey
+	Path      string
+	Method    int
+	Code      int
+	Log       string
+	GazUsed   int64
+	Tags      []string
+	Resources []*uuid.UUID
+}
+
+// CreateRepositoryParams represents the CreateRepository params
+type CreateRepositoryParams struct {
+	Datastore datastore.DataStore
+	PK        crypto.PrivateKey
+	Signer    crypto.Signer
+	Client    applications.Client
+}
+
+// CreateServiceParams represents the CreateService params
+type CreateServiceParams struct {
+	Datastore datastore.DataStore
+}
+
+// CreateIndexerParams represents the CreateIndexer params.  The Datastore must not be the datastore of the application,
+// so that the history stays outside of the consensus state
+type CreateIndexerParams struct {
+	Datastore datastore.DataStore
+}
+
+// CreateRoutesParams represents the CreateRoutes params.  The Datastore is the one the transactions are indexed in
+type CreateRoutesParams struct {
+	Datastore datastore.DataStore
+}
+
+// SDKFunc represents the history SDK func
+var SDKFunc = struct {
+	CreateTransaction func(params CreateTransactionParams) Transaction
+	CreateRepository  func(params CreateRepositoryParams) Repository
+	CreateService     func(params CreateServiceParams) Service
+	CreateIndexer     func(params CreateIndexerParams) applications.OnTransaction
+	CreateRoutes      func(params CreateRoutesParams) []routers.CreateRouteParams
+}{
+	CreateTransaction: func(params CreateTransactionParams) Transaction {
+		out, outErr := createTransaction(
+			params.Hash,
+			params.Height,
+			params.Signer,
+			params.Path,
+			params.Method,
+			params.Code,
+			params.Log,
+			params.GazUsed,
+			params.Tags,
+			params.Resources,
+		)
+
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateRepository: func(params CreateRepositoryParams) Repository {
+		if params.Datastore != nil {
+			out := createRepository(params.Datastore)
+			return out
+		}
+
+		if params.Signer != nil && params.Client != nil {
+			out := createSDKRepository(params.Signer, params.Client)
+			return out
+		}
+
+		if params.PK != nil && params.Client != nil {
+			out := createSDKRepository(crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+				PK: params.PK,
+			}), params.Client)
+
+			return out
+		}
+
+		panic(errors.New("the Datastore or the PK (or Signer) and Client are mandatory in order to create a Repository instance"))
+	},
+	CreateService: func(params CreateServiceParams) Service {
+		out := createService(params.Datastore)
+		return out
+	},
+	CreateIndexer: func(params CreateIndexerParams) applications.OnTransaction {
+		if params.Datastore == nil {
+			panic(errors.New("the Datastore is mandatory in order to create an indexer"))
+		}
+
+		return createIndexer(params.Datastore)
+	},
+	CreateRoutes: func(params CreateRoutesParams) []routers.CreateRouteParams {
+		if params.Datastore == nil {
+			panic(errors.New("the Datastore is mandatory in order to create the history routes"))
+		}
+
+		ctrl := createControllers(params.Datastore)
+		return []routers.CreateRouteParams{
+			ctrl.retrieveByHash(),
+			ctrl.retrieveSetBy(bySigner),
+			ctrl.retrieveSetBy(byPath),
+			ctrl.retrieveSetBy(byTag),
+			ctrl.retrieveSetBy(byResource),
+		}
+	},
+}
@@ -0,0 +1,108 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+type sdkRepository struct {
+	signer crypto.Signer
+	client applications.Client
+}
+
+func createSDKRepository(signer crypto.Signer, client applications.Client) Repository {
+	out := sdkRepository{
+		signer: signer,
+		client: client,
+	}
+
+	return &out
+}
+
+// RetrieveByHash retrieves a transaction by hash
+func (app *sdkRepository) RetrieveByHash(hash string) (Transaction, error) {
+	queryPath := createTransactionQueryPath(strings.ToLower(hash))
+	queryResp, queryRespErr := app.execute(queryPath)
+	if queryRespErr != nil {
+		return nil, queryRespErr
+	}
+
+	ptr := new(transaction)
+	jsErr := cdc.UnmarshalJSON(queryResp.Value(), ptr)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	return ptr, nil
+}
+
+// RetrieveSetBySigner retrieves a transaction partial set by signer
+func (app *sdkRepository) RetrieveSetBySigner(signer crypto.PublicKey, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexQueryPath(bySigner, signer.String(), index, amount))
+}
+
+// RetrieveSetByPath retrieves a transaction partial set by path
+func (app *sdkRepository) RetrieveSetByPath(path string, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexQueryPath(byPath, path, index, amount))
+}
+
+// RetrieveSetByTag retrieves a transaction partial set by tag
+func (app *sdkRepository) RetrieveSetByTag(tag string, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexQueryPath(byTag, tag, index, amount))
+}
+
+// RetrieveSetByResource retrieves a transaction partial set by the ID of a resource it touched
+func (app *sdkRepository) RetrieveSetByResource(id *uuid.UUID, index int, amount int) (PartialSet, error) {
+	return app.retrieveSet(createIndexQueryPath(byResource, id.String(), index, amount))
+}
+
+func (app *sdkRepository) retrieveSet(queryPath string) (PartialSet, error) {
+	queryResp, queryRespErr := app.execute(queryPath)
+	if queryRespErr != nil {
+		return nil, queryRespErr
+	}
+
+	ptr := new(partialSet)
+	jsErr := cdc.UnmarshalJSON(queryResp.Value(), ptr)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	return ptr, nil
+}
+
+func (app *sdkRepository) execute(path string) (routers.QueryResponse, error) {
+	queryResPtr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+		From: app.signer.PublicKey(),
+		Path: path,
+	})
+
+	// create the signature:
+	querySig, querySigErr := app.signer.Sign(routers.QueryDomain, []byte(queryResPtr.Hash()))
+	if querySigErr != nil {
+		return nil, querySigErr
+	}
+
+	// execute a query:
+	queryResp, queryRespErr := app.client.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+		Ptr: queryResPtr,
+		Sig: querySig,
+	}))
+
+	if queryRespErr != nil {
+		return nil, queryRespErr
+	}
+
+	if queryResp.Code() != routers.IsSuccessful {
+		str := fmt.Sprintf("there was an error (Code: %d) while executing the query: %s", queryResp.Code(), queryResp.Log())
+		return nil, errors.New(str)
+	}
+
+	return queryResp, nil
+}
@@ -0,0 +1,147 @@
+package history
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+func TestIndex_thenRetrieve_Success(t *testing.T) {
+	// variables:
+	store := datastore.SDKFunc.Create()
+	pk := crypto.SDKFunc.GenPK()
+	walletID := uuid.NewV4()
+	entityID := uuid.NewV4()
+	data := []byte(fmt.Sprintf("{\"id\":\"%s\",\"wallet_id\":\"%s\"}", entityID.String(), walletID.String()))
+	tag := fmt.Sprintf("/wallet/%s", walletID.String())
+
+	// create the transaction request:
+	res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: pk.PublicKey(),
+			Path: "/transfer",
+		}),
+		Data: data,
+	})
+
+	req := routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+		Res: res,
+		Sig: pk.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+	})
+
+	resp := routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+		Code:    routers.IsSuccessful,
+		Log:     "success",
+		GazUsed: 25,
+		Tags: map[string][]byte{
+			tag: data,
+		},
+	})
+
+	// index the transaction:
+	hash := []byte("this is the hash of the raw transaction")
+	indexer := SDKFunc.CreateIndexer(CreateIndexerParams{
+		Datastore: store,
+	})
+
+	indexErr := indexer(3, hash, req, resp)
+	if indexErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", indexErr.Error())
+		return
+	}
+
+	// the same transaction delivered again is indexed again:
+	secondIndexErr := indexer(4, hash, req, resp)
+	if secondIndexErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", secondIndexErr.Error())
+		return
+	}
+
+	// create the repository:
+	repository := SDKFunc.CreateRepository(CreateRepositoryParams{
+		Datastore: store,
+	})
+
+	// retrieve by signer:
+	bySignerPS, bySignerPSErr := repository.RetrieveSetBySigner(pk.PublicKey(), 0, 20)
+	if bySignerPSErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", bySignerPSErr.Error())
+		return
+	}
+
+	if bySignerPS.TotalAmount() != 2 || bySignerPS.Amount() != 2 {
+		t.Errorf("the partial set was expected to contain 2 transactions, total amount: %d, amount: %d", bySignerPS.TotalAmount(), bySignerPS.Amount())
+		return
+	}
+
+	trx := bySignerPS.Transactions()[0]
+	if trx.Height() != 3 {
+		t.Errorf("the height was expected to be %d, returned: %d", 3, trx.Height())
+		return
+	}
+
+	if trx.Method() != routers.Save {
+		t.Errorf("the method was expected to be %d, returned: %d", routers.Save, trx.Method())
+		return
+	}
+
+	if !trx.Signer().Equals(pk.PublicKey()) {
+		t.Errorf("the returned signer is invalid")
+		return
+	}
+
+	// retrieve by hash:
+	retTrx, retTrxErr := repository.RetrieveByHash(trx.Hash())
+	if retTrxErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retTrxErr.Error())
+		return
+	}
+
+	if retTrx.Path() != "/transfer" {
+		t.Errorf("the path was expected to be %s, returned: %s", "/transfer", retTrx.Path())
+		return
+	}
+
+	if retTrx.Height() != 4 {
+		t.Errorf("the latest delivery was expected to be returned, height: %d, returned: %d", 4, retTrx.Height())
+		return
+	}
+
+	// retrieve by path, tag and resources:
+	sets := map[string]func() (PartialSet, error){
+		"path": func() (PartialSet, error) {
+			return repository.RetrieveSetByPath("/transfer", 0, 20)
+		},
+		"tag": func() (PartialSet, error) {
+			return repository.RetrieveSetByTag(tag, 0, 20)
+		},
+		"wallet": func() (PartialSet, error) {
+			return repository.RetrieveSetByResource(&walletID, 0, 20)
+		},
+		"entity": func() (PartialSet, error) {
+			return repository.RetrieveSetByResource(&entityID, 0, 20)
+		},
+	}
+
+	for name, oneFn := range sets {
+		ps, psErr := oneFn()
+		if psErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned (%s): %s", name, psErr.Error())
+			return
+		}
+
+		if ps.TotalAmount() != 2 {
+			t.Errorf("the %s partial set was expected to contain 2 transactions, returned: %d", name, ps.TotalAmount())
+			return
+		}
+
+		if ps.Transactions()[0].Hash() != trx.Hash() {
+			t.Errorf("the %s partial set contains an invalid transaction", name)
+			return
+		}
+	}
+}
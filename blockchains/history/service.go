@@ -0,0 +1,67 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/datastore/objects"
+)
+
+type service struct {
+	ds datastore.DataStore
+}
+
+func createService(ds datastore.DataStore) Service {
+	out := service{
+		ds: ds,
+	}
+
+	return &out
+}
+
+// Save saves a transaction and adds it to the hash, signer, path, tags and resources indexes
+func (app *service) Save(trx Transaction) error {
+	// a transaction delivered again is saved under the next reference:
+	occurrence := 0
+	reference := createTransactionReference(trx.Hash(), trx.Height(), occurrence)
+	for app.ds.Objects().Keys().Exists(createTransactionKey(reference)) > 0 {
+		occurrence++
+		reference = createTransactionReference(trx.Hash(), trx.Height(), occurrence)
+	}
+
+	key := createTransactionKey(reference)
+
+	// save the transaction:
+	amountSaved := app.ds.Objects().Save(&objects.ObjInKey{
+		Key: key,
+		Obj: createStorableTransaction(trx),
+	})
+
+	if amountSaved != 1 {
+		str := fmt.Sprintf("there was an error while saving the transaction (hash: %s)", trx.Hash())
+		return errors.New(str)
+	}
+
+	// create the index keys:
+	indexKeys := []string{
+		createIndexKey(byHash, trx.Hash()),
+		createIndexKey(bySigner, trx.Signer().String()),
+		createIndexKey(byPath, trx.Path()),
+	}
+
+	for _, oneTag := range trx.Tags() {
+		indexKeys = append(indexKeys, createIndexKey(byTag, oneTag))
+	}
+
+	for _, oneResource := range trx.Resources() {
+		indexKeys = append(indexKeys, createIndexKey(byResource, oneResource.String()))
+	}
+
+	// add the reference in the indexes:
+	for _, oneIndexKey := range indexKeys {
+		app.ds.Lists().Add(oneIndexKey, reference)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package history
+
+type storableTransaction struct {
+	Hash      string   `json:"hash"`
+	Height    int64    `json:"height"`
+	Signer    string   `json:"signer"`
+	Path      string   `json:"path"`
+	Method    int      `json:"method"`
+	Code      int      `json:"code"`
+	Log       string   `json:"log"`
+	GazUsed   int64    `json:"gaz_used"`
+	Tags      []string `json:"tags"`
+	Resources []string `json:"resources"`
+}
+
+func createStorableTransaction(trx Transaction) *storableTransaction {
+	resources := []string{}
+	for _, oneResource := range trx.Resources() {
+		resources = append(resources, oneResource.String())
+	}
+
+	out := storableTransaction{
+		Hash:      trx.Hash(),
+		Height:    trx.Height(),
+		Signer:    trx.Signer().String(),
+		Path:      trx.Path(),
+		Method:    trx.Method(),
+		Code:      trx.Code(),
+		Log:       trx.Log(),
+		GazUsed:   trx.GazUsed(),
+		Tags:      trx.Tags(),
+		Resources: resources,
+	}
+
+	return &out
+}
@@ -0,0 +1,152 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+type transaction struct {
+	Hsh  string           `json:"hash"`
+	Hght int64            `json:"height"`
+	Sig  crypto.PublicKey `json:"signer"`
+	Pth  string           `json:"path"`
+	Mthd int              `json:"method"`
+	Cde  int              `json:"code"`
+	Lg   string           `json:"log"`
+	Gaz  int64            `json:"gaz_used"`
+	Tgs  []string         `json:"tags"`
+	Res  []*uuid.UUID     `json:"resources"`
+}
+
+func createTransaction(
+	hash string,
+	height int64,
+	signer crypto.PublicKey,
+	path string,
+	method int,
+	code int,
+	log string,
+	gazUsed int64,
+	tags []string,
+	resources []*uuid.UUID,
+) (Transaction, error) {
+	if hash == "" {
+		return nil, errors.New("the hash is mandatory in order to create a Transaction instance")
+	}
+
+	if signer == nil {
+		return nil, errors.New("the signer is mandatory in order to create a Transaction instance")
+	}
+
+	if method != routers.Save && method != routers.Delete {
+		str := fmt.Sprintf("the method (%d) must either be a save (%d) or a delete (%d) method", method, routers.Save, routers.Delete)
+		return nil, errors.New(str)
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	if resources == nil {
+		resources = []*uuid.UUID{}
+	}
+
+	out := transaction{
+		Hsh:  hash,
+		Hght: height,
+		Sig:  signer,
+		Pth:  path,
+		Mthd: method,
+		Cde:  code,
+		Lg:   log,
+		Gaz:  gazUsed,
+		Tgs:  tags,
+		Res:  resources,
+	}
+
+	return &out, nil
+}
+
+func createTransactionFromStorable(storable *storableTransaction) (Transaction, error) {
+	signer := crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+		PubKeyAsString: storable.Signer,
+	})
+
+	resources := []*uuid.UUID{}
+	for _, oneResourceAsString := range storable.Resources {
+		id, idErr := uuid.FromString(oneResourceAsString)
+		if idErr != nil {
+			str := fmt.Sprintf("the stored resource (%s) is not a valid ID: %s", oneResourceAsString, idErr.Error())
+			return nil, errors.New(str)
+		}
+
+		resources = append(resources, &id)
+	}
+
+	return createTransaction(
+		storable.Hash,
+		storable.Height,
+		signer,
+		storable.Path,
+		storable.Method,
+		storable.Code,
+		storable.Log,
+		storable.GazUsed,
+		storable.Tags,
+		resources,
+	)
+}
+
+// Hash returns the hash
+func (obj *transaction) Hash() string {
+	return obj.Hsh
+}
+
+// Height returns the height of the block that contains the transaction
+func (obj *transaction) Height() int64 {
+	return obj.Hght
+}
+
+// Signer returns the public key of the signer
+func (obj *transaction) Signer() crypto.PublicKey {
+	return obj.Sig
+}
+
+// Path returns the path
+func (obj *transaction) Path() string {
+	return obj.Pth
+}
+
+// Method returns the router method
+func (obj *transaction) Method() int {
+	return obj.Mthd
+}
+
+// Code returns the response code
+func (obj *transaction) Code() int {
+	return obj.Cde
+}
+
+// Log returns the response log
+func (obj *transaction) Log() string {
+	return obj.Lg
+}
+
+// GazUsed returns the gaz used
+func (obj *transaction) GazUsed() int64 {
+	return obj.Gaz
+}
+
+// Tags returns the tag keys
+func (obj *transaction) Tags() []string {
+	return obj.Tgs
+}
+
+// Resources returns the IDs of the resources touched by the transaction
+func (obj *transaction) Resources() []*uuid.UUID {
+	return obj.Res
+}
@@ -0,0 +1,31 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+func broadcast(trx Transaction, client applications.Client) (applications.ClientTransactionResponse, error) {
+	req, reqErr := trx.Request()
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	trxResp, trxRespErr := client.Transact(req)
+	if trxRespErr != nil {
+		return nil, trxRespErr
+	}
+
+	chk := trxResp.Check()
+	chkCode := chk.Code()
+	if chkCode != routers.IsSuccessful {
+		trxRes := trxResp.Transaction()
+		str := fmt.Sprintf("there was an error (Check Code: %d, Trx Code: %d) while executing the transaction: (Check Log: %s, Trx Log: %s, Route: %s)", chkCode, trxRes.Code(), chk.Log(), trxRes.Log(), trx.Pointer().Path())
+		return nil, errors.New(str)
+	}
+
+	return trxResp, nil
+}
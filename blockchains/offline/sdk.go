@@ -0,0 +1,80 @@
+package offline
+
+import (
+	"github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+const (
+	// Version represents the version of the offline transaction file format
+	Version = 1
+)
+
+// Transaction represents a transaction that is built, signed and broadcasted in separate steps
+type Transaction interface {
+	Pointer() routers.ResourcePointer
+	HasResource() bool
+	Resource() routers.Resource
+	Domain() string
+	Hash() string
+	IsSigned() bool
+	Signature() crypto.Signature
+	Sign(signer crypto.Signer) (Transaction, error)
+	Request() (routers.TransactionRequest, error)
+}
+
+// CreateSaveParams represents the CreateSave params
+type CreateSaveParams struct {
+	Resource routers.Resource
+}
+
+// CreateDeleteParams represents the CreateDelete params
+type CreateDeleteParams struct {
+	Pointer routers.ResourcePointer
+}
+
+// CreateParams represents the Create params
+type CreateParams struct {
+	JSON []byte
+}
+
+// BroadcastParams represents the Broadcast params
+type BroadcastParams struct {
+	Transaction Transaction
+	Client      applications.Client
+}
+
+// SDKFunc represents the offline SDK func
+var SDKFunc = struct {
+	CreateSave   func(params CreateSaveParams) Transaction
+	CreateDelete func(params CreateDeleteParams) Transaction
+	Create       func(params CreateParams) Transaction
+	Broadcast    func(params BroadcastParams) applications.ClientTransactionResponse
+}{
+	CreateSave: func(params CreateSaveParams) Transaction {
+		out := createTransactionWithResource(params.Resource, nil)
+		return out
+	},
+	CreateDelete: func(params CreateDeleteParams) Transaction {
+		out := createTransactionWithPointer(params.Pointer, nil)
+		return out
+	},
+	Create: func(params CreateParams) Transaction {
+		ptr := new(transaction)
+		jsErr := ptr.UnmarshalJSON(params.JSON)
+		if jsErr != nil {
+			panic(jsErr)
+		}
+
+		return ptr
+	},
+	Broadcast: func(params BroadcastParams) applications.ClientTransactionResponse {
+		out, outErr := broadcast(params.Transaction, params.Client)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+}
@@ -0,0 +1,122 @@
+package offline
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+func TestSave_sign_thenConvertToJSON_thenBackAgain_Success(t *testing.T) {
+	// variables:
+	pk := crypto.SDKFunc.GenPK()
+	res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: pk.PublicKey(),
+			Path: "/some/path",
+		}),
+		Data: []byte(`{"name":"some name"}`),
+	})
+
+	trx := SDKFunc.CreateSave(CreateSaveParams{
+		Resource: res,
+	})
+
+	// an unsigned transaction cannot be converted to a request:
+	_, unsignedErr := trx.Request()
+	if unsignedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// another key cannot sign the transaction:
+	_, otherErr := trx.Sign(crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+		PK: crypto.SDKFunc.GenPK(),
+	}))
+
+	if otherErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// sign:
+	signed, signedErr := trx.Sign(crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+		PK: pk,
+	}))
+
+	if signedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", signedErr.Error())
+		return
+	}
+
+	// convert to an indented JSON file, then back again:
+	js, jsErr := json.MarshalIndent(signed, "", "    ")
+	if jsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", jsErr.Error())
+		return
+	}
+
+	if !strings.Contains(string(js), `"name": "some name"`) {
+		t.Errorf("the data was expected to be readable in the transaction file: %s", js)
+		return
+	}
+
+	loaded := SDKFunc.Create(CreateParams{
+		JSON: js,
+	})
+
+	if loaded.Hash() != res.Hash() || !loaded.IsSigned() {
+		t.Errorf("the loaded transaction did not match the signed transaction")
+		return
+	}
+
+	_, reqErr := loaded.Request()
+	if reqErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", reqErr.Error())
+		return
+	}
+}
+
+func TestDelete_tamperedData_returnsError(t *testing.T) {
+	// variables:
+	pk := crypto.SDKFunc.GenPK()
+	ptr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+		From: pk.PublicKey(),
+		Path: "/some/path",
+	})
+
+	signed, _ := SDKFunc.CreateDelete(CreateDeleteParams{
+		Pointer: ptr,
+	}).Sign(crypto.SDKFunc.CreateLocalSigner(crypto.CreateLocalSignerParams{
+		PK: pk,
+	}))
+
+	js, _ := json.Marshal(signed)
+
+	// the path is modified after signing:
+	tampered := strings.Replace(string(js), "/some/path", "/another/path", 1)
+	_, tamperedErr := fromJSONToTransaction([]byte(tampered))
+	if tamperedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// the untouched file is valid:
+	_, validErr := fromJSONToTransaction(js)
+	if validErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", validErr.Error())
+		return
+	}
+}
+
+func fromJSONToTransaction(js []byte) (Transaction, error) {
+	ptr := new(transaction)
+	jsErr := json.Unmarshal(js, ptr)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	return ptr, nil
+}
@@ -0,0 +1,301 @@
+package offline
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/xmnservices/xmnsuite/crypto"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+const (
+	methodSave   = "save"
+	methodDelete = "delete"
+)
+
+type transaction struct {
+	ptr routers.ResourcePointer
+	res routers.Resource
+	sig crypto.Signature
+}
+
+type jsonTransaction struct {
+	Version    int             `json:"version"`
+	Method     string          `json:"method"`
+	Domain     string          `json:"domain"`
+	From       string          `json:"from"`
+	Path       string          `json:"path"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	DataBase64 string          `json:"data_base64,omitempty"`
+	Hash       string          `json:"hash"`
+	Signature  string          `json:"signature,omitempty"`
+}
+
+func createTransactionWithResource(res routers.Resource, sig crypto.Signature) Transaction {
+	out := transaction{
+		ptr: res.Pointer(),
+		res: res,
+		sig: sig,
+	}
+
+	return &out
+}
+
+func createTransactionWithPointer(ptr routers.ResourcePointer, sig crypto.Signature) Transaction {
+	out := transaction{
+		ptr: ptr,
+		res: nil,
+		sig: sig,
+	}
+
+	return &out
+}
+
+// Pointer returns the resource pointer
+func (obj *transaction) Pointer() routers.ResourcePointer {
+	return obj.ptr
+}
+
+// HasResource returns true if the transaction saves a resource, false if it deletes one
+func (obj *transaction) HasResource() bool {
+	return obj.res != nil
+}
+
+// Resource returns the resource, if any
+func (obj *transaction) Resource() routers.Resource {
+	return obj.res
+}
+
+// Domain returns the domain the hash is signed with
+func (obj *transaction) Domain() string {
+	if obj.HasResource() {
+		return routers.SaveTransactionDomain
+	}
+
+	return routers.DeleteTransactionDomain
+}
+
+// Hash returns the hash to sign
+func (obj *transaction) Hash() string {
+	if obj.HasResource() {
+		return obj.res.Hash()
+	}
+
+	return obj.ptr.Hash()
+}
+
+// IsSigned returns true if the transaction is signed, false otherwise
+func (obj *transaction) IsSigned() bool {
+	return obj.sig != nil
+}
+
+// Signature returns the signature, if any
+func (obj *transaction) Signature() crypto.Signature {
+	return obj.sig
+}
+
+// Sign signs the transaction, the signer must hold the PrivateKey of the resource pointer's PublicKey
+func (obj *transaction) Sign(signer crypto.Signer) (Transaction, error) {
+	if !signer.PublicKey().Equals(obj.ptr.From()) {
+		str := fmt.Sprintf("the transaction must be signed by its PublicKey (%s), not by the given PublicKey (%s)", obj.ptr.From().String(), signer.PublicKey().String())
+		return nil, errors.New(str)
+	}
+
+	sig, sigErr := signer.Sign(obj.Domain(), []byte(obj.Hash()))
+	if sigErr != nil {
+		return nil, sigErr
+	}
+
+	if obj.HasResource() {
+		return createTransactionWithResource(obj.res, sig), nil
+	}
+
+	return createTransactionWithPointer(obj.ptr, sig), nil
+}
+
+// Request returns the transaction request of a signed transaction
+func (obj *transaction) Request() (out routers.TransactionRequest, err error) {
+	if !obj.IsSigned() {
+		return nil, errors.New("the transaction must be signed before creating its transaction request")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	if obj.HasResource() {
+		out = routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+			Res: obj.res,
+			Sig: obj.sig,
+		})
+
+		return out, nil
+	}
+
+	out = routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+		Ptr: obj.ptr,
+		Sig: obj.sig,
+	})
+
+	return out, nil
+}
+
+// MarshalJSON returns a human-readable JSON representation of the transaction
+func (obj *transaction) MarshalJSON() ([]byte, error) {
+	out := jsonTransaction{
+		Version: Version,
+		Method:  methodDelete,
+		Domain:  obj.Domain(),
+		From:    obj.ptr.From().String(),
+		Path:    obj.ptr.Path(),
+		Hash:    obj.Hash(),
+	}
+
+	if obj.HasResource() {
+		out.Method = methodSave
+
+		// the data is shown as is when it is compact JSON, so that it can be read and hashed back identically:
+		data := obj.res.Data()
+		compacted := new(bytes.Buffer)
+		if json.Compact(compacted, data) == nil && bytes.Equal(compacted.Bytes(), data) {
+			out.Data = data
+		}
+
+		if out.Data == nil {
+			out.DataBase64 = base64.StdEncoding.EncodeToString(data)
+		}
+	}
+
+	if obj.IsSigned() {
+		out.Signature = obj.sig.String()
+	}
+
+	return json.Marshal(&out)
+}
+
+// UnmarshalJSON validates and converts a JSON representation to a transaction
+func (obj *transaction) UnmarshalJSON(data []byte) error {
+	ptr := new(jsonTransaction)
+	jsErr := json.Unmarshal(data, ptr)
+	if jsErr != nil {
+		return jsErr
+	}
+
+	if ptr.Version != Version {
+		str := fmt.Sprintf("the transaction file version (%d) is not supported, expected: %d", ptr.Version, Version)
+		return errors.New(str)
+	}
+
+	from, fromErr := fromStringToPubKey(ptr.From)
+	if fromErr != nil {
+		return fromErr
+	}
+
+	resPtr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+		From: from,
+		Path: ptr.Path,
+	})
+
+	var out *transaction
+	switch ptr.Method {
+	case methodSave:
+		resData, resDataErr := fromJSONToData(ptr)
+		if resDataErr != nil {
+			return resDataErr
+		}
+
+		out = createTransactionWithResource(routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+			ResPtr: resPtr,
+			Data:   resData,
+		}), nil).(*transaction)
+	case methodDelete:
+		out = createTransactionWithPointer(resPtr, nil).(*transaction)
+	default:
+		str := fmt.Sprintf("the transaction method (%s) must either be %s or %s", ptr.Method, methodSave, methodDelete)
+		return errors.New(str)
+	}
+
+	// the domain and hash are only shown for inspection, they must match the content of the transaction:
+	if ptr.Domain != out.Domain() {
+		str := fmt.Sprintf("the domain (%s) does not match the method of the transaction, expected: %s", ptr.Domain, out.Domain())
+		return errors.New(str)
+	}
+
+	if ptr.Hash != out.Hash() {
+		str := fmt.Sprintf("the hash (%s) does not match the content of the transaction, which has been modified (expected hash: %s)", ptr.Hash, out.Hash())
+		return errors.New(str)
+	}
+
+	if ptr.Signature != "" {
+		sig, sigErr := fromStringToSig(ptr.Signature)
+		if sigErr != nil {
+			return sigErr
+		}
+
+		if !sig.VerifyBytes(out.Domain(), []byte(out.Hash()), from) {
+			return errors.New("the signature of the transaction is invalid")
+		}
+
+		out.sig = sig
+	}
+
+	obj.ptr = out.ptr
+	obj.res = out.res
+	obj.sig = out.sig
+	return nil
+}
+
+func fromJSONToData(ptr *jsonTransaction) ([]byte, error) {
+	if ptr.DataBase64 != "" {
+		return base64.StdEncoding.DecodeString(ptr.DataBase64)
+	}
+
+	if len(ptr.Data) <= 0 {
+		return []byte{}, nil
+	}
+
+	// the file may have been indented, the data is hashed in its compact form:
+	compacted := new(bytes.Buffer)
+	compactErr := json.Compact(compacted, ptr.Data)
+	if compactErr != nil {
+		return nil, compactErr
+	}
+
+	return compacted.Bytes(), nil
+}
+
+func fromStringToPubKey(str string) (out crypto.PublicKey, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the PublicKey (%s) of the transaction is invalid: %v", str, r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+		PubKeyAsString: str,
+	})
+
+	return out, nil
+}
+
+func fromStringToSig(str string) (out crypto.Signature, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the signature of the transaction is invalid: %v", r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreateSig(crypto.CreateSigParams{
+		SigAsString: str,
+	})
+
+	return out, nil
+}
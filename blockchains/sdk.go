@@ -25,6 +25,7 @@ type CreateParams struct {
 	Peers                   []string
 	GenesisTransaction      genesis.Genesis
 	Meta                    meta.Meta
+	MetricsAddress          string
 }
 
 // SDKFunc represents the blockchains SDK func
@@ -48,6 +49,7 @@ var SDKFunc = struct {
 			params.Peers,
 			params.GenesisTransaction,
 			params.Meta,
+			params.MetricsAddress,
 		)
 
 		if outErr != nil {
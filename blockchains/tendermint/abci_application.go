@@ -2,14 +2,21 @@ package tendermint
 
 import (
 	"log"
+	"sort"
+	"time"
 
 	types "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
 	routers "github.com/xmnservices/xmnsuite/routers"
 )
 
+// the type of the proof operation that proves a query response with the datastore:
+const datastoreProofOpType = "xmnsuite/datastore"
+
 /*
  * ABCI Application
  */
@@ -18,17 +25,37 @@ type abciApplication struct {
 	types.BaseApplication
 	apps      applications.Applications
 	blkHeight int64
+	metrics   Metrics
 }
 
-func createABCIApplication(apps applications.Applications) (*abciApplication, error) {
+func createABCIApplication(apps applications.Applications, metrics Metrics) (*abciApplication, error) {
 	out := abciApplication{
 		apps:      apps,
 		blkHeight: apps.RetrieveBlockIndex(),
+		metrics:   metrics,
 	}
 
 	return &out, nil
 }
 
+// BeginBlock signals the beginning of a block, before its transactions are delivered
+func (app *abciApplication) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	// retrieve the app:
+	curApp, curAppErr := app.apps.RetrieveByBlockIndex(app.blkHeight)
+	if curAppErr != nil {
+		panic(curAppErr)
+	}
+
+	// execute the begin block func of the application.  A failing func does not halt the chain, its writes are discarded on every node:
+	beginBlockErr := curApp.BeginBlock(req.Header.Height)
+	if beginBlockErr != nil {
+		log.Printf("there was an error while beginning the block (height: %d): %s", req.Header.Height, beginBlockErr.Error())
+	}
+
+	// returns:
+	return types.ResponseBeginBlock{}
+}
+
 // EndBlock signals the end of a block, returns changes to the validator set
 func (app *abciApplication) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
 	// retrieve the app:
@@ -37,8 +64,8 @@ func (app *abciApplication) EndBlock(req types.RequestEndBlock) types.ResponseEn
 		panic(curAppErr)
 	}
 
-	// retrieve the validators:
-	vals, valsErr := curApp.Validators()
+	// retrieve the validators.  A failing end block func does not halt the chain, its writes are discarded on every node:
+	vals, valsErr := curApp.EndBlock(req.Height)
 	if valsErr != nil {
 		log.Printf("there was an error while updating the blockchain validators: %s", valsErr.Error())
 
@@ -126,10 +153,10 @@ func (app *abciApplication) DeliverTx(tx []byte) types.ResponseDeliverTx {
 		panic(curAppErr)
 	}
 
-	// execute the transaction on the application:
+	// execute the transaction on the application, with the hash of the raw transaction:
 	resp := curApp.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
 		JSData: tx,
-	}))
+	}), tmhash.Sum(tx))
 
 	// fetch the data from response:
 	code := resp.Code()
@@ -137,14 +164,11 @@ func (app *abciApplication) DeliverTx(tx []byte) types.ResponseDeliverTx {
 	log := resp.Log()
 	inputTags := resp.Tags()
 
-	//create the tags:
-	tagPairs := []cmn.KVPair{}
-	for key, value := range inputTags {
-		tagPairs = append(tagPairs, cmn.KVPair{
-			Key:   []byte(key),
-			Value: value,
-		})
-	}
+	//create the tags, sorted by key so that every node returns them in the same order:
+	tagPairs := createTagPairs(inputTags)
+
+	// measure the transaction:
+	app.metrics.DeliverTransaction(code, gazUsed)
 
 	//return the value:
 	return types.ResponseDeliverTx{Code: uint32(code), Log: log, GasUsed: gazUsed, Tags: tagPairs}
@@ -169,14 +193,11 @@ func (app *abciApplication) CheckTx(tx []byte) types.ResponseCheckTx {
 	log := resp.Log()
 	inputTags := resp.Tags()
 
-	//create the tags:
-	tagPairs := []cmn.KVPair{}
-	for key, value := range inputTags {
-		tagPairs = append(tagPairs, cmn.KVPair{
-			Key:   []byte(key),
-			Value: value,
-		})
-	}
+	//create the tags, sorted by key so that every node returns them in the same order:
+	tagPairs := createTagPairs(inputTags)
+
+	// measure the transaction:
+	app.metrics.CheckTransaction(code)
 
 	//return the value:
 	return types.ResponseCheckTx{Code: uint32(code), Log: log, GasWanted: gazWanted, Tags: tagPairs}
@@ -191,7 +212,9 @@ func (app *abciApplication) Commit() types.ResponseCommit {
 	}
 
 	//execute the commit on the application:
+	beginsOn := time.Now()
 	resp := curApp.Commit()
+	duration := time.Since(beginsOn)
 
 	// fetch the data from the response:
 	appHash := resp.AppHash()
@@ -201,6 +224,10 @@ func (app *abciApplication) Commit() types.ResponseCommit {
 
 	log.Printf("Commit height: %d, AppHash: %X\n", app.blkHeight, appHash)
 
+	// measure the commit:
+	info := curApp.Info(applications.SDKFunc.CreateInfoRequest(applications.CreateInfoRequestParams{}))
+	app.metrics.Commit(duration, info.State().Size())
+
 	// return the value:
 	return types.ResponseCommit{Data: appHash}
 }
@@ -229,12 +256,15 @@ func (app *abciApplication) Query(reqQuery types.RequestQuery) types.ResponseQue
 	code := resp.Code()
 	key := resp.Key()
 	value := resp.Value()
-	log := resp.Log()
+	logMsg := resp.Log()
+
+	// measure the query:
+	app.metrics.Query(code)
 
 	// return the value:
 	out := types.ResponseQuery{
 		Code: uint32(code),
-		Log:  log,
+		Log:  logMsg,
 	}
 
 	if key != "" {
@@ -245,5 +275,49 @@ func (app *abciApplication) Query(reqQuery types.RequestQuery) types.ResponseQue
 		out.Value = value
 	}
 
+	// prove the object stored at key, if any:
+	if code == routers.IsSuccessful && key != "" {
+		prf, prfErr := curApp.Prove(key)
+		if prfErr != nil {
+			log.Printf("the query (key: %s) cannot be proven: %s", key, prfErr.Error())
+			return out
+		}
+
+		prfJS, prfJSErr := cdc.MarshalJSON(prf)
+		if prfJSErr != nil {
+			log.Printf("the proof of the query (key: %s) could not be encoded: %s", key, prfJSErr.Error())
+			return out
+		}
+
+		out.Height = app.blkHeight
+		out.Proof = &merkle.Proof{
+			Ops: []merkle.ProofOp{
+				merkle.ProofOp{
+					Type: datastoreProofOpType,
+					Key:  []byte(key),
+					Data: prfJS,
+				},
+			},
+		}
+	}
+
+	return out
+}
+
+func createTagPairs(tags map[string][]byte) []cmn.KVPair {
+	keys := []string{}
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	out := []cmn.KVPair{}
+	for _, oneKey := range keys {
+		out = append(out, cmn.KVPair{
+			Key:   []byte(oneKey),
+			Value: tags[oneKey],
+		})
+	}
+
 	return out
 }
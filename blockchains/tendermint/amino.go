@@ -6,6 +6,7 @@ import (
 	ed25519 "github.com/tendermint/tendermint/crypto/ed25519"
 	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
 	crypto "github.com/xmnservices/xmnsuite/crypto"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
 )
 
 var cdc = amino.NewCodec()
@@ -20,6 +21,7 @@ func Register(codec *amino.Codec) {
 	func() {
 		applications.Register(codec)
 		crypto.Register(codec)
+		datastore.Register(codec)
 	}()
 
 	// PublicKey
@@ -16,10 +16,25 @@ import (
 )
 
 type applicationService struct {
+	metrics       Metrics
+	listenAddress string
 }
 
 func createApplicationService() ApplicationService {
-	out := applicationService{}
+	out := applicationService{
+		metrics:       createNopMetrics(),
+		listenAddress: "",
+	}
+
+	return &out
+}
+
+func createInstrumentedApplicationService(metrics Metrics, listenAddress string) ApplicationService {
+	out := applicationService{
+		metrics:       metrics,
+		listenAddress: listenAddress,
+	}
+
 	return &out
 }
 
@@ -36,7 +51,7 @@ func (obj *applicationService) Spawn(
 	gen := blkChain.GetGenesis()
 
 	//create the abci application:
-	abciApp, abciAppErr := createABCIApplication(apps)
+	abciApp, abciAppErr := createABCIApplication(apps, obj.metrics)
 	if abciAppErr != nil {
 		return nil, abciAppErr
 	}
@@ -48,6 +63,12 @@ func (obj *applicationService) Spawn(
 	//set the custom port in the RPC ListenAddress:
 	conf.RPC.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", port)
 
+	// expose the metrics, along with the tendermint metrics, if instrumented:
+	if obj.listenAddress != "" {
+		conf.Instrumentation.Prometheus = true
+		conf.Instrumentation.PrometheusListenAddr = obj.listenAddress
+	}
+
 	// set the seeds, if any:
 	if seeds != nil && len(seeds) > 0 {
 		conf.P2P.Seeds = strings.Join(seeds, ",")
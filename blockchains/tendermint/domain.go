@@ -208,13 +208,9 @@ func createJSONGenesis(gen Genesis) (*jsonGenesis, error) {
 		jsValidators = append(jsValidators, oneJSValidator)
 	}
 
-	sh := sha256.New()
-	sh.Write([]byte(strings.Replace(gen.GetPath().String(), string(filepath.Separator), "-", 2)))
-	chainID := hex.EncodeToString(sh.Sum(nil))
-
 	out := jsonGenesis{
 		Head:            fmt.Sprintf("%X", gen.GetHead()),
-		ChainIdentifier: chainID[0:49],
+		ChainIdentifier: createChainID(gen.GetPath()),
 		Validators:      jsValidators,
 		CreatedOn:       gen.CreatedOn(),
 		Path:            gen.GetPath().String(),
@@ -223,6 +219,13 @@ func createJSONGenesis(gen Genesis) (*jsonGenesis, error) {
 	return &out, nil
 }
 
+func createChainID(path Path) string {
+	sh := sha256.New()
+	sh.Write([]byte(strings.Replace(path.String(), string(filepath.Separator), "-", 2)))
+	chainID := hex.EncodeToString(sh.Sum(nil))
+	return chainID[0:49]
+}
+
 type genesis struct {
 	head       []byte
 	validators []Validator
@@ -0,0 +1,135 @@
+package tendermint
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	routers "github.com/xmnservices/xmnsuite/routers"
+)
+
+// the subsystem of the abci application metrics:
+const metricsSubsystem = "abci"
+
+/*
+ * Metrics
+ */
+
+type abciMetrics struct {
+	checkTrxs      metrics.Counter
+	deliverTrxs    metrics.Counter
+	gazUsed        metrics.Counter
+	queries        metrics.Counter
+	commitDuration metrics.Histogram
+	datastoreSize  metrics.Gauge
+	routeDuration  metrics.Histogram
+}
+
+func createNopMetrics() Metrics {
+	out := abciMetrics{
+		checkTrxs:      discard.NewCounter(),
+		deliverTrxs:    discard.NewCounter(),
+		gazUsed:        discard.NewCounter(),
+		queries:        discard.NewCounter(),
+		commitDuration: discard.NewHistogram(),
+		datastoreSize:  discard.NewGauge(),
+		routeDuration:  discard.NewHistogram(),
+	}
+
+	return &out
+}
+
+func createPrometheusMetrics(namespace string) Metrics {
+	out := abciMetrics{
+		checkTrxs: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "check_transactions",
+			Help:      "Amount of checked transactions, by response code.",
+		}, []string{"code"}),
+		deliverTrxs: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "deliver_transactions",
+			Help:      "Amount of delivered transactions, by response code.",
+		}, []string{"code"}),
+		gazUsed: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "gaz_used",
+			Help:      "Total amount of gaz used by the delivered transactions.",
+		}, []string{}),
+		queries: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "queries",
+			Help:      "Amount of executed queries, by response code.",
+		}, []string{"code"}),
+		commitDuration: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "commit_duration_seconds",
+			Help:      "Time it took to commit a block, in seconds.",
+		}, []string{}),
+		datastoreSize: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "datastore_size",
+			Help:      "Amount of transactions stored in the datastore.",
+		}, []string{}),
+		routeDuration: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "route_duration_seconds",
+			Help:      "Time it took to execute the handler of a route, in seconds, by route pattern and method.",
+		}, []string{"pattern", "method"}),
+	}
+
+	return &out
+}
+
+// OnRoute returns the func that measures the latency of the routes
+func (obj *abciMetrics) OnRoute() applications.OnRoute {
+	return func(pattern string, method int, duration time.Duration) {
+		obj.routeDuration.With("pattern", pattern, "method", methodName(method)).Observe(duration.Seconds())
+	}
+}
+
+// CheckTransaction measures a checked transaction
+func (obj *abciMetrics) CheckTransaction(code int) {
+	obj.checkTrxs.With("code", strconv.Itoa(code)).Add(1)
+}
+
+// DeliverTransaction measures a delivered transaction
+func (obj *abciMetrics) DeliverTransaction(code int, gazUsed int64) {
+	obj.deliverTrxs.With("code", strconv.Itoa(code)).Add(1)
+	obj.gazUsed.Add(float64(gazUsed))
+}
+
+// Query measures an executed query
+func (obj *abciMetrics) Query(code int) {
+	obj.queries.With("code", strconv.Itoa(code)).Add(1)
+}
+
+// Commit measures a committed block
+func (obj *abciMetrics) Commit(duration time.Duration, size int64) {
+	obj.commitDuration.Observe(duration.Seconds())
+	obj.datastoreSize.Set(float64(size))
+}
+
+func methodName(method int) string {
+	switch method {
+	case routers.Save:
+		return "save"
+	case routers.Delete:
+		return "delete"
+	case routers.Retrieve:
+		return "retrieve"
+	}
+
+	return strconv.Itoa(method)
+}
@@ -0,0 +1,343 @@
+package tendermint
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/lib/client"
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	routers "github.com/xmnservices/xmnsuite/routers"
+)
+
+/*
+ * Endpoint
+ */
+
+type endpoint struct {
+	address   string
+	client    applications.Client
+	status    func() error
+	isHealthy bool
+	latency   time.Duration
+}
+
+func createRPCEndpoint(address string) (*endpoint, error) {
+	client, clientErr := createRPCClient(address)
+	if clientErr != nil {
+		return nil, clientErr
+	}
+
+	statusClient := rpcclient.NewJSONRPCClient(address)
+	ctypes.RegisterAmino(statusClient.Codec())
+	status := func() error {
+		result := new(ctypes.ResultStatus)
+		_, err := statusClient.Call("status", map[string]interface{}{}, result)
+		return err
+	}
+
+	return createEndpoint(address, client, status), nil
+}
+
+func createEndpoint(address string, client applications.Client, status func() error) *endpoint {
+	out := endpoint{
+		address:   address,
+		client:    client,
+		status:    status,
+		isHealthy: true,
+		latency:   0,
+	}
+
+	return &out
+}
+
+/*
+ * Multi Client
+ */
+
+type multiClient struct {
+	lock           *sync.Mutex
+	strategy       int
+	interval       time.Duration
+	addresses      []string
+	discover       DiscoverFn
+	createEndpoint func(address string) (*endpoint, error)
+	endpoints      []*endpoint
+	next           int
+	lastCheck      time.Time
+}
+
+func createMultiClient(
+	strategy int,
+	interval time.Duration,
+	addresses []string,
+	discover DiscoverFn,
+	createEndpoint func(address string) (*endpoint, error),
+) (applications.Client, error) {
+	if strategy != RoundRobin && strategy != LowestLatency {
+		str := fmt.Sprintf("the strategy (%d) must either be round robin (%d) or lowest latency (%d)", strategy, RoundRobin, LowestLatency)
+		return nil, errors.New(str)
+	}
+
+	if len(addresses) <= 0 && discover == nil {
+		return nil, errors.New("the addresses or the discover func are mandatory in order to create a multi client")
+	}
+
+	out := multiClient{
+		lock:           new(sync.Mutex),
+		strategy:       strategy,
+		interval:       interval,
+		addresses:      addresses,
+		discover:       discover,
+		createEndpoint: createEndpoint,
+		endpoints:      []*endpoint{},
+		next:           0,
+	}
+
+	// check the endpoints a first time:
+	refreshErr := out.refresh()
+	if refreshErr != nil {
+		return nil, refreshErr
+	}
+
+	return &out, nil
+}
+
+// IP returns the address of the preferred node
+func (app *multiClient) IP() string {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+
+	candidates := app.candidates(false)
+	if len(candidates) <= 0 {
+		return ""
+	}
+
+	return candidates[0].address
+}
+
+// Query executes a query on the preferred healthy node, then falls back on the other nodes
+func (app *multiClient) Query(req routers.QueryRequest) (routers.QueryResponse, error) {
+	var out routers.QueryResponse
+	err := app.execute(true, func(cl applications.Client) error {
+		resp, respErr := cl.Query(req)
+		if respErr != nil {
+			return respErr
+		}
+
+		out = resp
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Transact executes a transaction on the preferred healthy node, then retries it on the other nodes only if a node could not
+// be connected to.  A node that fails after the transaction has been sent, for example on a commit timeout, may still
+// have added it to its mempool, and a retry on another node could submit it twice, so its error is returned instead
+func (app *multiClient) Transact(req routers.TransactionRequest) (applications.ClientTransactionResponse, error) {
+	var out applications.ClientTransactionResponse
+	err := app.execute(false, func(cl applications.Client) error {
+		resp, respErr := cl.Transact(req)
+		if respErr != nil {
+			return respErr
+		}
+
+		out = resp
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// execute tries the request on the candidates, one after the other.  The requests that are not idempotent are only
+// retried when the request could not be sent:
+func (app *multiClient) execute(isIdempotent bool, fn func(cl applications.Client) error) error {
+	// retrieve the candidates:
+	app.lock.Lock()
+	if time.Since(app.lastCheck) >= app.interval {
+		refreshErr := app.refresh()
+		if refreshErr != nil {
+			app.lock.Unlock()
+			return refreshErr
+		}
+	}
+
+	candidates := app.candidates(true)
+	app.lock.Unlock()
+
+	if len(candidates) <= 0 {
+		return errors.New("the multi client has no node to connect to")
+	}
+
+	// try the candidates, one after the other:
+	errs := []string{}
+	for _, oneEndpoint := range candidates {
+		beginsOn := time.Now()
+		err := fn(oneEndpoint.client)
+
+		app.lock.Lock()
+		oneEndpoint.isHealthy = err == nil
+		if err == nil {
+			oneEndpoint.latency = time.Since(beginsOn)
+		}
+		app.lock.Unlock()
+
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Sprintf("%s: %s", oneEndpoint.address, err.Error()))
+		if !isIdempotent && !isDialError(err) {
+			str := fmt.Sprintf("the request failed after being sent, and therefore cannot be retried on another node: %s", strings.Join(errs, ", "))
+			return errors.New(str)
+		}
+	}
+
+	str := fmt.Sprintf("the request failed on every node: %s", strings.Join(errs, ", "))
+	return errors.New(str)
+}
+
+// refresh skips the nodes that cannot be discovered or connected to, and only fails when no node is healthy:
+func (app *multiClient) refresh() error {
+	// retrieve the addresses, the previously discovered nodes are kept when the discovery fails:
+	addresses := []string{}
+	addresses = append(addresses, app.addresses...)
+	if app.discover != nil {
+		discovered, discoveredErr := app.discover()
+		if discoveredErr != nil {
+			log.Printf("there was an error while discovering the nodes, the known nodes are kept: %s", discoveredErr.Error())
+			for _, oneEndpoint := range app.endpoints {
+				discovered = append(discovered, oneEndpoint.address)
+			}
+		}
+
+		addresses = append(addresses, discovered...)
+	}
+
+	// keep the known endpoints, create the new ones:
+	known := map[string]*endpoint{}
+	for _, oneEndpoint := range app.endpoints {
+		known[oneEndpoint.address] = oneEndpoint
+	}
+
+	errs := []string{}
+	added := map[string]bool{}
+	endpoints := []*endpoint{}
+	for _, oneAddress := range addresses {
+		if added[oneAddress] {
+			continue
+		}
+
+		added[oneAddress] = true
+		if oneEndpoint, ok := known[oneAddress]; ok {
+			endpoints = append(endpoints, oneEndpoint)
+			continue
+		}
+
+		oneEndpoint, oneEndpointErr := app.createEndpoint(oneAddress)
+		if oneEndpointErr != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", oneAddress, oneEndpointErr.Error()))
+			continue
+		}
+
+		endpoints = append(endpoints, oneEndpoint)
+	}
+
+	// health-check the endpoints:
+	amountHealthy := 0
+	for _, oneEndpoint := range endpoints {
+		beginsOn := time.Now()
+		statusErr := oneEndpoint.status()
+		oneEndpoint.isHealthy = statusErr == nil
+		if statusErr != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", oneEndpoint.address, statusErr.Error()))
+			continue
+		}
+
+		oneEndpoint.latency = time.Since(beginsOn)
+		amountHealthy++
+	}
+
+	app.endpoints = endpoints
+	app.lastCheck = time.Now()
+	if amountHealthy <= 0 {
+		str := fmt.Sprintf("none of the nodes are healthy: %s", strings.Join(errs, ", "))
+		return errors.New(str)
+	}
+
+	return nil
+}
+
+// isDialError returns true when the error happened while connecting to the node, before the request was sent:
+func isDialError(err error) bool {
+	for err != nil {
+		switch casted := err.(type) {
+		case *url.Error:
+			err = casted.Err
+		case *net.OpError:
+			return casted.Op == "dial"
+		case interface{ Cause() error }:
+			cause := casted.Cause()
+			if cause == err {
+				return false
+			}
+
+			err = cause
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+func (app *multiClient) candidates(moveNext bool) []*endpoint {
+	// the healthy endpoints go first, the unhealthy ones are still tried last:
+	healthy := []*endpoint{}
+	unhealthy := []*endpoint{}
+	for _, oneEndpoint := range app.endpoints {
+		if oneEndpoint.isHealthy {
+			healthy = append(healthy, oneEndpoint)
+			continue
+		}
+
+		unhealthy = append(unhealthy, oneEndpoint)
+	}
+
+	if app.strategy == LowestLatency {
+		sort.SliceStable(healthy, func(i int, j int) bool {
+			return healthy[i].latency < healthy[j].latency
+		})
+
+		return append(healthy, unhealthy...)
+	}
+
+	// round robin:
+	out := []*endpoint{}
+	if len(healthy) > 0 {
+		start := app.next % len(healthy)
+		out = append(out, healthy[start:]...)
+		out = append(out, healthy[:start]...)
+		if moveNext {
+			app.next++
+		}
+	}
+
+	return append(out, unhealthy...)
+}
@@ -0,0 +1,288 @@
+package tendermint
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	routers "github.com/xmnservices/xmnsuite/routers"
+)
+
+type clientForTests struct {
+	address     string
+	isDown      bool
+	isTimingOut bool
+	amount      int
+}
+
+func (obj *clientForTests) IP() string {
+	return obj.address
+}
+
+func (obj *clientForTests) Query(req routers.QueryRequest) (routers.QueryResponse, error) {
+	if obj.isDown {
+		return nil, errors.New("the node is down")
+	}
+
+	obj.amount++
+	return routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+		Code: routers.IsSuccessful,
+		Log:  obj.address,
+		Key:  req.Pointer().Path(),
+	}), nil
+}
+
+func (obj *clientForTests) Transact(req routers.TransactionRequest) (applications.ClientTransactionResponse, error) {
+	// a node that is down cannot be connected to:
+	if obj.isDown {
+		return nil, &url.Error{
+			Op:  "Post",
+			URL: obj.address,
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+		}
+	}
+
+	// a node that times out received the transaction:
+	obj.amount++
+	if obj.isTimingOut {
+		return nil, errors.New("timed out waiting for the transaction to be included in a block")
+	}
+
+	resp := routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+		Code: routers.IsSuccessful,
+		Log:  obj.address,
+	})
+
+	return applications.SDKFunc.CreateClientTransactionResponse(applications.CreateClientTransactionResponseParams{
+		Chk:    resp,
+		Trx:    resp,
+		Height: 1,
+		Hash:   []byte(obj.address),
+	}), nil
+}
+
+func createMultiClientForTests(t *testing.T, strategy int, clients []*clientForTests) applications.Client {
+	addresses := []string{}
+	byAddress := map[string]*clientForTests{}
+	for _, oneClient := range clients {
+		addresses = append(addresses, oneClient.address)
+		byAddress[oneClient.address] = oneClient
+	}
+
+	out, outErr := createMultiClient(strategy, time.Hour, addresses, nil, func(address string) (*endpoint, error) {
+		cl := byAddress[address]
+		return createEndpoint(address, cl, func() error {
+			if cl.isDown {
+				return errors.New("the node is down")
+			}
+
+			return nil
+		}), nil
+	})
+
+	if outErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", outErr.Error())
+		return nil
+	}
+
+	return out
+}
+
+func createQueryRequestForTests() routers.QueryRequest {
+	pk := crypto.SDKFunc.GenPK()
+	ptr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+		From: pk.PublicKey(),
+		Path: "/messages",
+	})
+
+	return routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+		Ptr: ptr,
+		Sig: pk.SignBytes(routers.QueryDomain, []byte(ptr.Hash())),
+	})
+}
+
+func TestMultiClient_roundRobin_skipsDownNodes_Success(t *testing.T) {
+	// variables:
+	clients := []*clientForTests{
+		&clientForTests{address: "tcp://127.0.0.1:1000"},
+		&clientForTests{address: "tcp://127.0.0.1:1001", isDown: true},
+		&clientForTests{address: "tcp://127.0.0.1:1002"},
+	}
+
+	client := createMultiClientForTests(t, RoundRobin, clients)
+	if client == nil {
+		return
+	}
+
+	// execute some queries:
+	req := createQueryRequestForTests()
+	for i := 0; i < 4; i++ {
+		resp, respErr := client.Query(req)
+		if respErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", respErr.Error())
+			return
+		}
+
+		if resp.Log() == clients[1].address {
+			t.Errorf("the query was not expected to be routed to the down node")
+			return
+		}
+	}
+
+	// the queries must be spread on the healthy nodes:
+	if clients[0].amount != 2 || clients[2].amount != 2 {
+		t.Errorf("the queries were expected to be spread evenly, amounts: %d, %d", clients[0].amount, clients[2].amount)
+		return
+	}
+}
+
+func TestMultiClient_nodeGoesDown_retriesTransactionOnAnotherNode_Success(t *testing.T) {
+	// variables:
+	clients := []*clientForTests{
+		&clientForTests{address: "tcp://127.0.0.1:1000"},
+		&clientForTests{address: "tcp://127.0.0.1:1001"},
+	}
+
+	client := createMultiClientForTests(t, LowestLatency, clients)
+	if client == nil {
+		return
+	}
+
+	// the preferred node goes down after the health check:
+	preferred := client.IP()
+	for _, oneClient := range clients {
+		if oneClient.address == preferred {
+			oneClient.isDown = true
+		}
+	}
+
+	// execute a transaction:
+	pk := crypto.SDKFunc.GenPK()
+	res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: pk.PublicKey(),
+			Path: "/messages",
+		}),
+		Data: []byte("{}"),
+	})
+
+	resp, respErr := client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+		Res: res,
+		Sig: pk.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+	}))
+
+	if respErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", respErr.Error())
+		return
+	}
+
+	if resp.Transaction().Log() == preferred {
+		t.Errorf("the transaction was not expected to be executed on the down node")
+		return
+	}
+
+	// the down node must no longer be preferred:
+	if client.IP() == preferred {
+		t.Errorf("the down node (%s) was not expected to be preferred anymore", preferred)
+		return
+	}
+
+	// when every node is down, the request must fail:
+	for _, oneClient := range clients {
+		oneClient.isDown = true
+	}
+
+	_, allDownErr := client.Query(createQueryRequestForTests())
+	if allDownErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestMultiClient_transactionTimesOut_isNotRetriedOnAnotherNode_returnsError(t *testing.T) {
+	// variables:
+	clients := []*clientForTests{
+		&clientForTests{address: "tcp://127.0.0.1:1000", isTimingOut: true},
+		&clientForTests{address: "tcp://127.0.0.1:1001", isTimingOut: true},
+	}
+
+	client := createMultiClientForTests(t, RoundRobin, clients)
+	if client == nil {
+		return
+	}
+
+	// execute a transaction:
+	pk := crypto.SDKFunc.GenPK()
+	res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+		ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: pk.PublicKey(),
+			Path: "/messages",
+		}),
+		Data: []byte("{}"),
+	})
+
+	_, respErr := client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+		Res: res,
+		Sig: pk.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+	}))
+
+	if respErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// the transaction must only be submitted once:
+	if clients[0].amount+clients[1].amount != 1 {
+		t.Errorf("the transaction was expected to be submitted to a single node, submitted: %d", clients[0].amount+clients[1].amount)
+		return
+	}
+}
+
+func TestMultiClient_withUnreachableNodes_skipsThem_Success(t *testing.T) {
+	// variables:
+	healthy := &clientForTests{address: "tcp://127.0.0.1:1000"}
+	addresses := []string{
+		"tcp://127.0.0.1:1001",
+		healthy.address,
+	}
+
+	createEndpointFn := func(address string) (*endpoint, error) {
+		if address != healthy.address {
+			return nil, errors.New("the node cannot be connected to")
+		}
+
+		return createEndpoint(address, healthy, func() error {
+			if healthy.isDown {
+				return errors.New("the node is down")
+			}
+
+			return nil
+		}), nil
+	}
+
+	client, clientErr := createMultiClient(RoundRobin, time.Hour, addresses, func() ([]string, error) {
+		return nil, errors.New("the discovery failed")
+	}, createEndpointFn)
+
+	if clientErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", clientErr.Error())
+		return
+	}
+
+	if client.IP() != healthy.address {
+		t.Errorf("the preferred node was expected to be the healthy node (%s), returned: %s", healthy.address, client.IP())
+		return
+	}
+
+	// when no node is healthy, the multi client cannot be created:
+	healthy.isDown = true
+	_, downErr := createMultiClient(RoundRobin, time.Hour, addresses, nil, createEndpointFn)
+	if downErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -1,6 +1,7 @@
 package tendermint
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"time"
@@ -8,8 +9,24 @@ import (
 	uuid "github.com/satori/go.uuid"
 	crypto "github.com/tendermint/tendermint/crypto"
 	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
 )
 
+const (
+	// RoundRobin routes the requests of a multi client to its healthy nodes, one after the other
+	RoundRobin = iota
+
+	// LowestLatency routes the requests of a multi client to its healthy node with the lowest latency
+	LowestLatency
+)
+
+// MatchFn represents a func that makes sure the value of a verified query response matches the proven object.  The routes
+// usually return a normalized representation of the stored object, so only the caller knows how to compare them
+type MatchFn func(prf datastore.Proof, value []byte) error
+
+// DiscoverFn represents a func that returns the addresses of the nodes a multi client can connect to
+type DiscoverFn func() ([]string, error)
+
 /*
  * Blockchain
  */
@@ -65,6 +82,15 @@ type BlockchainService interface {
  * Application
  */
 
+// Metrics represents the metrics of an abci application
+type Metrics interface {
+	OnRoute() applications.OnRoute
+	CheckTransaction(code int)
+	DeliverTransaction(code int, gazUsed int64)
+	Query(code int)
+	Commit(duration time.Duration, size int64)
+}
+
 // ApplicationService represents an application service
 type ApplicationService interface {
 	Spawn(port int, seeds []string, rootDir string, blkChain Blockchain, apps applications.Applications) (applications.Node, error)
@@ -82,6 +108,33 @@ type CreateClientParams struct {
 	Port       int
 }
 
+// CreateMultiClientParams represents the params of the CreateMultiClient SDK func
+type CreateMultiClientParams struct {
+	Addresses           []string
+	Discover            DiscoverFn
+	Strategy            int
+	HealthCheckInterval time.Duration
+}
+
+// CreateVerifyingClientParams represents the params of the CreateVerifyingClient SDK func
+type CreateVerifyingClientParams struct {
+	IPAsString string
+	Genesis    Genesis
+	Match      MatchFn
+	Timeout    time.Duration
+}
+
+// CreateMetricsParams represents the params of the CreateMetrics SDK func
+type CreateMetricsParams struct {
+	Namespace string
+}
+
+// CreateInstrumentedApplicationServiceParams represents the params of the CreateInstrumentedApplicationService SDK func
+type CreateInstrumentedApplicationServiceParams struct {
+	Metrics       Metrics
+	ListenAddress string
+}
+
 // CreatePathParams represents the params of the CreatePath SDK func
 type CreatePathParams struct {
 	Namespace string
@@ -104,11 +157,15 @@ type CreateBlockchainServiceParams struct {
 
 // SDKFunc represents the tendermint interval blockchains SDK functions
 var SDKFunc = struct {
-	CreatePath               func(params CreatePathParams) Path
-	CreateClient             func(params CreateClientParams) applications.Client
-	CreateBlockchain         func(params CreateBlockchainParams) Blockchain
-	CreateBlockchainService  func(params CreateBlockchainServiceParams) BlockchainService
-	CreateApplicationService func() ApplicationService
+	CreatePath                           func(params CreatePathParams) Path
+	CreateClient                         func(params CreateClientParams) applications.Client
+	CreateMultiClient                    func(params CreateMultiClientParams) applications.Client
+	CreateVerifyingClient                func(params CreateVerifyingClientParams) applications.Client
+	CreateBlockchain                     func(params CreateBlockchainParams) Blockchain
+	CreateBlockchainService              func(params CreateBlockchainServiceParams) BlockchainService
+	CreateApplicationService             func() ApplicationService
+	CreateMetrics                        func(params CreateMetricsParams) Metrics
+	CreateInstrumentedApplicationService func(params CreateInstrumentedApplicationServiceParams) ApplicationService
 }{
 	CreatePath: func(params CreatePathParams) Path {
 		return createPath(params.Namespace, params.Name, params.ID)
@@ -125,6 +182,38 @@ var SDKFunc = struct {
 
 		return out
 	},
+	CreateMultiClient: func(params CreateMultiClientParams) applications.Client {
+		if params.HealthCheckInterval == 0 {
+			params.HealthCheckInterval = time.Second * 30
+		}
+
+		out, outErr := createMultiClient(params.Strategy, params.HealthCheckInterval, params.Addresses, params.Discover, createRPCEndpoint)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateVerifyingClient: func(params CreateVerifyingClientParams) applications.Client {
+		if params.Genesis == nil {
+			panic(errors.New("the genesis is mandatory in order to create a verifying client"))
+		}
+
+		if params.Match == nil {
+			panic(errors.New("the match func is mandatory in order to create a verifying client"))
+		}
+
+		if params.Timeout == 0 {
+			params.Timeout = time.Second * 10
+		}
+
+		out, outErr := createVerifyingClient(params.IPAsString, params.Genesis, params.Timeout, params.Match)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
 	CreateBlockchain: func(params CreateBlockchainParams) Blockchain {
 		if params.PrivKey != nil {
 			out, outErr := generateBlockchainWithPrivateKey(params.Namespace, params.Name, params.ID, params.PrivKey)
@@ -149,4 +238,23 @@ var SDKFunc = struct {
 		serv := createApplicationService()
 		return serv
 	},
+	CreateMetrics: func(params CreateMetricsParams) Metrics {
+		if params.Namespace == "" {
+			params.Namespace = "xmnsuite"
+		}
+
+		return createPrometheusMetrics(params.Namespace)
+	},
+	CreateInstrumentedApplicationService: func(params CreateInstrumentedApplicationServiceParams) ApplicationService {
+		if params.Metrics == nil {
+			panic(errors.New("the metrics are mandatory in order to create an instrumented application service"))
+		}
+
+		if params.ListenAddress == "" {
+			panic(errors.New("the listen address is mandatory in order to create an instrumented application service"))
+		}
+
+		serv := createInstrumentedApplicationService(params.Metrics, params.ListenAddress)
+		return serv
+	},
 }
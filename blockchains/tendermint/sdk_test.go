@@ -1,6 +1,7 @@
 package tendermint
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -215,7 +216,7 @@ func TestCreateBlockchainWithApplication_thenSpawn_Success(t *testing.T) {
 	})
 
 	// sign the resource:
-	firstSig := fromPrivKey.Sign(firstRes.Hash())
+	firstSig := fromPrivKey.SignBytes(routers.SaveTransactionDomain, []byte(firstRes.Hash()))
 
 	// save the message:
 	trxResp, trxRespErr := client.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
@@ -261,7 +262,7 @@ func TestCreateBlockchainWithApplication_thenSpawn_Success(t *testing.T) {
 	})
 
 	// create the signature:
-	querySig := fromPrivKey.Sign(queryResPtr.Hash())
+	querySig := fromPrivKey.SignBytes(routers.QueryDomain, []byte(queryResPtr.Hash()))
 
 	// execute a query:
 	queryResp, queryRespErr := client.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
@@ -304,4 +305,70 @@ func TestCreateBlockchainWithApplication_thenSpawn_Success(t *testing.T) {
 		t.Errorf("the returned message is invalid")
 		return
 	}
+
+	// execute the query again, with a verifying client:
+	matchMessage := func(prf datastore.Proof, value []byte) error {
+		storedMsg := new(messageForTest)
+		objErr := prf.Object(storedMsg)
+		if objErr != nil {
+			return objErr
+		}
+
+		valueMsg := new(messageForTest)
+		jsErr := cdc.UnmarshalJSON(value, valueMsg)
+		if jsErr != nil {
+			return jsErr
+		}
+
+		if !reflect.DeepEqual(storedMsg, valueMsg) {
+			return errors.New("the message is not the stored message")
+		}
+
+		return nil
+	}
+
+	verifyingClient := SDKFunc.CreateVerifyingClient(CreateVerifyingClientParams{
+		IPAsString: address,
+		Genesis:    blkChain.GetGenesis(),
+		Match:      matchMessage,
+	})
+
+	verifiedQueryResp, verifiedQueryRespErr := verifyingClient.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+		Ptr: queryResPtr,
+		Sig: querySig,
+	}))
+
+	if verifiedQueryRespErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", verifiedQueryRespErr.Error())
+		return
+	}
+
+	if !bytes.Equal(verifiedQueryResp.Value(), retQueryValue) {
+		t.Errorf("the verified query was expected to return the same value as the query")
+		return
+	}
+
+	// a client that trusts another blockchain must refuse the answer:
+	otherID := uuid.NewV4()
+	otherBlkChain := SDKFunc.CreateBlockchain(CreateBlockchainParams{
+		Namespace: namespace,
+		Name:      name,
+		ID:        &otherID,
+	})
+
+	untrustedClient := SDKFunc.CreateVerifyingClient(CreateVerifyingClientParams{
+		IPAsString: address,
+		Genesis:    otherBlkChain.GetGenesis(),
+		Match:      matchMessage,
+	})
+
+	_, untrustedErr := untrustedClient.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+		Ptr: queryResPtr,
+		Sig: querySig,
+	}))
+
+	if untrustedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
 }
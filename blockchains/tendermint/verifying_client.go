@@ -0,0 +1,270 @@
+package tendermint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/lib/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	routers "github.com/xmnservices/xmnsuite/routers"
+)
+
+// the interval between two attempts to retrieve a signed header that is not created yet:
+const verifyingClientPollInterval = time.Millisecond * 500
+
+/*
+ * Verifying Client
+ */
+
+type verifyingClient struct {
+	lock    *sync.Mutex
+	client  applications.Client
+	cl      *rpcclient.JSONRPCClient
+	chainID string
+	trusted *tmtypes.ValidatorSet
+	timeout time.Duration
+	match   MatchFn
+}
+
+func createVerifyingClient(ipAddress string, gen Genesis, timeout time.Duration, match MatchFn) (applications.Client, error) {
+	client, clientErr := createRPCClient(ipAddress)
+	if clientErr != nil {
+		return nil, clientErr
+	}
+
+	// the validators of the genesis are trusted:
+	vals := []*tmtypes.Validator{}
+	for _, oneValidator := range gen.GetValidators() {
+		vals = append(vals, tmtypes.NewValidator(oneValidator.GetPubKey(), int64(oneValidator.GetPower())))
+	}
+
+	if len(vals) <= 0 {
+		return nil, errors.New("the genesis must contain at least one validator in order to create a verifying client")
+	}
+
+	cl := rpcclient.NewJSONRPCClient(ipAddress)
+	ctypes.RegisterAmino(cl.Codec())
+	datastore.Register(cl.Codec())
+
+	out := verifyingClient{
+		lock:    new(sync.Mutex),
+		client:  client,
+		cl:      cl,
+		chainID: createChainID(gen.GetPath()),
+		trusted: tmtypes.NewValidatorSet(vals),
+		timeout: timeout,
+		match:   match,
+	}
+
+	return &out, nil
+}
+
+// IP returns the client IP
+func (app *verifyingClient) IP() string {
+	return app.client.IP()
+}
+
+// Query executes a query, verifies its response against a header signed by the trusted validators, then returns it.
+// A response that cannot be verified is refused
+func (app *verifyingClient) Query(req routers.QueryRequest) (routers.QueryResponse, error) {
+	js, jsErr := cdc.MarshalJSON(req)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	path := req.Pointer().Path()
+	params := map[string]interface{}{
+		"path":    path,
+		"data":    fmt.Sprintf("%X", js),
+		"height":  0,
+		"trusted": false,
+	}
+
+	result := new(ctypes.ResultABCIQuery)
+	_, outErr := app.cl.Call("abci_query", params, result)
+	if outErr != nil {
+		return nil, outErr
+	}
+
+	// only a proven answer can be verified:
+	resp := result.Response
+	if resp.GetCode() != routers.IsSuccessful {
+		str := fmt.Sprintf("the query (path: %s) was not successful (code: %d, log: %s) and therefore cannot be verified", path, resp.GetCode(), resp.GetLog())
+		return nil, errors.New(str)
+	}
+
+	prf, prfErr := app.proof(resp.GetKey(), resp.GetProof())
+	if prfErr != nil {
+		str := fmt.Sprintf("the query (path: %s) cannot be verified: %s", path, prfErr.Error())
+		return nil, errors.New(str)
+	}
+
+	// the app hash of a block is in the header of the next block:
+	header, headerErr := app.header(resp.GetHeight() + 1)
+	if headerErr != nil {
+		str := fmt.Sprintf("the query (path: %s) cannot be verified: %s", path, headerErr.Error())
+		return nil, errors.New(str)
+	}
+
+	verifyErr := prf.Verify(header.AppHash)
+	if verifyErr != nil {
+		str := fmt.Sprintf("the query (path: %s) cannot be verified: %s", path, verifyErr.Error())
+		return nil, errors.New(str)
+	}
+
+	// the value must match the proven object:
+	matchErr := app.match(prf, resp.GetValue())
+	if matchErr != nil {
+		str := fmt.Sprintf("the value of the query (path: %s) does not match the proven object: %s", path, matchErr.Error())
+		return nil, errors.New(str)
+	}
+
+	return routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+		Code:  int(resp.GetCode()),
+		Log:   resp.GetLog(),
+		Key:   string(resp.GetKey()),
+		Value: resp.GetValue(),
+	}), nil
+}
+
+// Transact executes a transaction and returns its response
+func (app *verifyingClient) Transact(req routers.TransactionRequest) (applications.ClientTransactionResponse, error) {
+	return app.client.Transact(req)
+}
+
+func (app *verifyingClient) proof(key []byte, prf *merkle.Proof) (datastore.Proof, error) {
+	if prf == nil {
+		return nil, errors.New("the response does not contain any proof")
+	}
+
+	for _, oneOp := range prf.Ops {
+		if oneOp.Type != datastoreProofOpType {
+			continue
+		}
+
+		if !bytes.Equal(oneOp.Key, key) {
+			str := fmt.Sprintf("the proof (key: %s) does not prove the returned key (%s)", oneOp.Key, key)
+			return nil, errors.New(str)
+		}
+
+		var out datastore.Proof
+		jsErr := app.cl.Codec().UnmarshalJSON(oneOp.Data, &out)
+		if jsErr != nil {
+			return nil, jsErr
+		}
+
+		if out.Key() != string(key) {
+			str := fmt.Sprintf("the proven object (key: %s) is not the returned key (%s)", out.Key(), key)
+			return nil, errors.New(str)
+		}
+
+		return out, nil
+	}
+
+	str := fmt.Sprintf("the response does not contain any proof of type: %s", datastoreProofOpType)
+	return nil, errors.New(str)
+}
+
+func (app *verifyingClient) header(height int64) (*tmtypes.Header, error) {
+	// the header might not be created yet, so wait for it:
+	result := new(ctypes.ResultCommit)
+	beginsOn := time.Now()
+	for {
+		_, err := app.cl.Call("commit", map[string]interface{}{"height": height}, result)
+		if err == nil {
+			break
+		}
+
+		if time.Since(beginsOn) >= app.timeout {
+			str := fmt.Sprintf("the signed header (height: %d) could not be retrieved: %s", height, err.Error())
+			return nil, errors.New(str)
+		}
+
+		time.Sleep(verifyingClientPollInterval)
+	}
+
+	header := result.SignedHeader.Header
+	commit := result.SignedHeader.Commit
+	if header == nil || commit == nil {
+		str := fmt.Sprintf("the signed header (height: %d) is incomplete", height)
+		return nil, errors.New(str)
+	}
+
+	if header.ChainID != app.chainID || header.Height != height {
+		str := fmt.Sprintf("the signed header was expected to be on chain %s (height: %d), returned: %s (height: %d)", app.chainID, height, header.ChainID, header.Height)
+		return nil, errors.New(str)
+	}
+
+	if !bytes.Equal(commit.BlockID.Hash, header.Hash()) {
+		str := fmt.Sprintf("the commit (height: %d) does not sign the returned header", height)
+		return nil, errors.New(str)
+	}
+
+	// retrieve the validators of the block:
+	vals := new(ctypes.ResultValidators)
+	_, valsErr := app.cl.Call("validators", map[string]interface{}{"height": height}, vals)
+	if valsErr != nil {
+		return nil, valsErr
+	}
+
+	set := tmtypes.NewValidatorSet(vals.Validators)
+	if !bytes.Equal(set.Hash(), header.ValidatorsHash) {
+		str := fmt.Sprintf("the returned validators do not match the validators of the header (height: %d)", height)
+		return nil, errors.New(str)
+	}
+
+	app.lock.Lock()
+	defer app.lock.Unlock()
+
+	// if the validators changed, the trusted validators must have signed the block:
+	if !bytes.Equal(set.Hash(), app.trusted.Hash()) {
+		trustedErr := verifyTrustedPower(app.trusted, app.chainID, commit)
+		if trustedErr != nil {
+			return nil, trustedErr
+		}
+	}
+
+	verifyErr := set.VerifyCommit(app.chainID, commit.BlockID, height, commit)
+	if verifyErr != nil {
+		return nil, verifyErr
+	}
+
+	// the validators are now trusted:
+	app.trusted = set
+	return header, nil
+}
+
+func verifyTrustedPower(trusted *tmtypes.ValidatorSet, chainID string, commit *tmtypes.Commit) error {
+	signed := int64(0)
+	for _, oneVote := range commit.Precommits {
+		if oneVote == nil || !oneVote.BlockID.Equals(commit.BlockID) {
+			continue
+		}
+
+		_, val := trusted.GetByAddress(oneVote.ValidatorAddress)
+		if val == nil {
+			continue
+		}
+
+		if oneVote.Verify(chainID, val.PubKey) != nil {
+			continue
+		}
+
+		signed += val.VotingPower
+	}
+
+	total := trusted.TotalVotingPower()
+	if signed*3 <= total*2 {
+		str := fmt.Sprintf("the validators changed, but the trusted validators only signed %d of their %d voting power", signed, total)
+		return errors.New(str)
+	}
+
+	return nil
+}
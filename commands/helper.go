@@ -50,7 +50,7 @@ func createRootPrivateKey(rootPrivateKeyAsString string) (crypto.PrivateKey, err
 		return rootPrivKey, nil
 	}
 
-	privKey := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{
+	privKey := crypto.SDKFunc.ParsePK(crypto.CreatePKParams{
 		PKAsString: rootPrivateKeyAsString,
 	})
 
@@ -48,6 +48,8 @@ type StartConfigs interface {
 	Configs() Configs
 	HasPeers() bool
 	Peers() []Node
+	HasMetrics() bool
+	MetricsAddress() string
 }
 
 // Node represents a blockchain node
@@ -84,8 +86,9 @@ type CreateGenesisParams struct {
 
 // CreateStartParams represents the create start params
 type CreateStartParams struct {
-	Configs CreateParams
-	Peers   []string
+	Configs        CreateParams
+	Peers          []string
+	MetricsAddress string
 }
 
 // SDKFunc represents the cli SDK func
@@ -146,7 +149,7 @@ var SDKFunc = struct {
 		}
 
 		// create the start configs:
-		startConf, startConfErr := createStartConfigs(conf, nodes)
+		startConf, startConfErr := createStartConfigs(conf, nodes, params.MetricsAddress)
 		if startConfErr != nil {
 			panic(startConfErr)
 		}
@@ -46,6 +46,19 @@ func (app *startCommand) Execute() (applications.Node, error) {
 		FilePath: conf.DatabaseFilePath(),
 	})
 
+	// create the application service, instrumented if the metrics are enabled:
+	appService := tendermint.SDKFunc.CreateApplicationService()
+	var onRoute applications.OnRoute
+	if app.conf.HasMetrics() {
+		metrics := tendermint.SDKFunc.CreateMetrics(tendermint.CreateMetricsParams{})
+
+		onRoute = metrics.OnRoute()
+		appService = tendermint.SDKFunc.CreateInstrumentedApplicationService(tendermint.CreateInstrumentedApplicationServiceParams{
+			Metrics:       metrics,
+			ListenAddress: app.conf.MetricsAddress(),
+		})
+	}
+
 	// create the core applications:
 	apps := core.SDKFunc.Create(core.CreateParams{
 		Namespace:     cons.Namespace(),
@@ -57,11 +70,9 @@ func (app *startCommand) Execute() (applications.Node, error) {
 		RouterRoleKey: cons.RouterRoleKey(),
 		Store:         store,
 		Meta:          conf.Meta(),
+		OnRoute:       onRoute,
 	})
 
-	// create the application service:
-	appService := tendermint.SDKFunc.CreateApplicationService()
-
 	// create the peers if any:
 	seeds := []string{}
 	if app.conf.HasPeers() {
@@ -1,14 +1,16 @@
 package commands
 
 type startConfigs struct {
-	conf Configs
-	prs  []Node
+	conf       Configs
+	prs        []Node
+	metricsAdd string
 }
 
-func createStartConfigs(conf Configs, prs []Node) (StartConfigs, error) {
+func createStartConfigs(conf Configs, prs []Node, metricsAdd string) (StartConfigs, error) {
 	out := startConfigs{
-		conf: conf,
-		prs:  prs,
+		conf:       conf,
+		prs:        prs,
+		metricsAdd: metricsAdd,
 	}
 
 	return &out, nil
@@ -28,3 +30,13 @@ func (obj *startConfigs) HasPeers() bool {
 func (obj *startConfigs) Peers() []Node {
 	return obj.prs
 }
+
+// HasMetrics returns true if the metrics must be exposed, false otherwise
+func (obj *startConfigs) HasMetrics() bool {
+	return obj.metricsAdd != ""
+}
+
+// MetricsAddress returns the address on which the metrics are exposed
+func (obj *startConfigs) MetricsAddress() string {
+	return obj.metricsAdd
+}
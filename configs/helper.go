@@ -35,10 +35,11 @@ func encrypt(conf Configs, pass string, retypedPass string) (string, error) {
 }
 
 func decrypt(encryptedData string, pass string) (Configs, error) {
-	decrypted := crypto.SDKFunc.Decrypt(crypto.DecryptParams{
-		Pass:         []byte(pass),
-		EncryptedMsg: encryptedData,
-	})
+	// the legacy encrypted data can still be decrypted:
+	decrypted, decryptedErr := decryptData(encryptedData, pass)
+	if decryptedErr != nil {
+		return nil, decryptedErr
+	}
 
 	ptr := new(storableConfigs)
 	jsErr := json.Unmarshal(decrypted, ptr)
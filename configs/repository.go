@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
 
 	crypto "github.com/xmnservices/xmnsuite/crypto"
 )
@@ -25,10 +26,11 @@ func (app *repository) Retrieve(filePath string, password string) (Configs, erro
 		return nil, errors.New(str)
 	}
 
-	jsonAsBytes := crypto.SDKFunc.Decrypt(crypto.DecryptParams{
-		Pass:         []byte(password),
-		EncryptedMsg: string(data),
-	})
+	jsonAsBytes, jsonAsBytesErr := decryptData(string(data), password)
+	if jsonAsBytesErr != nil {
+		str := fmt.Sprintf("the Configs file could not be decrypted: %s", jsonAsBytesErr.Error())
+		return nil, errors.New(str)
+	}
 
 	ptr := new(storableConfigs)
 	jsErr := json.Unmarshal(jsonAsBytes, ptr)
@@ -43,5 +45,40 @@ func (app *repository) Retrieve(filePath string, password string) (Configs, erro
 		return nil, errors.New(str)
 	}
 
+	// if the file still uses the legacy encryption format, migrate it:
+	if crypto.SDKFunc.IsLegacyEncryption(crypto.IsLegacyEncryptionParams{
+		EncryptedMsg: string(data),
+	}) {
+		saveErr := createService().Save(conf, filePath, password, password)
+		if saveErr != nil {
+			log.Printf("the Configs file (%s) could not be migrated to the current encryption format: %s", filePath, saveErr.Error())
+		}
+	}
+
 	return conf, nil
 }
+
+func decryptData(encryptedData string, pass string) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	params := crypto.DecryptParams{
+		Pass:         []byte(pass),
+		EncryptedMsg: encryptedData,
+	}
+
+	// the Configs files that still use the legacy encryption format are decrypted in order to be migrated:
+	if crypto.SDKFunc.IsLegacyEncryption(crypto.IsLegacyEncryptionParams{
+		EncryptedMsg: encryptedData,
+	}) {
+		out = crypto.SDKFunc.DecryptLegacy(params)
+		return out, nil
+	}
+
+	out = crypto.SDKFunc.Decrypt(params)
+	return out, nil
+}
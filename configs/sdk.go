@@ -34,6 +34,12 @@ type CreateParams struct {
 	Encoded string
 }
 
+// GenerateFromMnemonicParams represents the GenerateFromMnemonic params
+type GenerateFromMnemonicParams struct {
+	Mnemonic   string
+	Passphrase string
+}
+
 // EncryptParams represents the encrypt params
 type EncryptParams struct {
 	Conf        Configs
@@ -49,13 +55,14 @@ type DecryptParams struct {
 
 // SDKFunc represents the confgis sdk func
 var SDKFunc = struct {
-	Generate         func() Configs
-	Create           func(params CreateParams) Configs
-	Encrypt          func(params EncryptParams) string
-	Decrypt          func(params DecryptParams) Configs
-	Normalize        func(ins Configs) Normalized
-	CreateRepository func() Repository
-	CreateService    func() Service
+	Generate             func() Configs
+	GenerateFromMnemonic func(params GenerateFromMnemonicParams) Configs
+	Create               func(params CreateParams) Configs
+	Encrypt              func(params EncryptParams) string
+	Decrypt              func(params DecryptParams) Configs
+	Normalize            func(ins Configs) Normalized
+	CreateRepository     func() Repository
+	CreateService        func() Service
 }{
 	Generate: func() Configs {
 		nodePK := ed25519.GenPrivKey()
@@ -67,6 +74,29 @@ var SDKFunc = struct {
 
 		return out
 	},
+	GenerateFromMnemonic: func(params GenerateFromMnemonicParams) Configs {
+		// derive the wallet key:
+		walletPK := crypto.SDKFunc.CreatePKFromMnemonic(crypto.CreatePKFromMnemonicParams{
+			Mnemonic:   params.Mnemonic,
+			Passphrase: params.Passphrase,
+			Path:       crypto.WalletKeyPath,
+		})
+
+		// derive the secret of the node key:
+		nodeSecret := crypto.SDKFunc.CreatePKFromMnemonic(crypto.CreatePKFromMnemonicParams{
+			Mnemonic:   params.Mnemonic,
+			Passphrase: params.Passphrase,
+			Path:       crypto.NodeKeyPath,
+		})
+
+		nodePK := ed25519.GenPrivKeyFromSecret([]byte(nodeSecret.String()))
+		out, outErr := createConfigs(nodePK, walletPK)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
 	Create: func(params CreateParams) Configs {
 		decoded, decodedErr := base64.StdEncoding.DecodeString(params.Encoded)
 		if decodedErr != nil {
@@ -1,10 +1,20 @@
 package configs
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+
+	crypto "github.com/xmnservices/xmnsuite/crypto"
 )
 
 func TestCreate_ThenSave_ThenRetrieve_Success(t *testing.T) {
@@ -47,3 +57,118 @@ func TestCreate_ThenSave_ThenRetrieve_Success(t *testing.T) {
 		return
 	}
 }
+
+func TestRetrieve_withLegacyEncryption_migrates_Success(t *testing.T) {
+	// variables:
+	dirPath := "test_files"
+	filePath := filepath.Join(dirPath, "configs.xmn")
+	password := "this-is-a-password"
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	// generate the configs:
+	conf := SDKFunc.Generate()
+
+	// write the configs using the legacy encryption format:
+	js, jsErr := json.Marshal(createStorableConfigs(conf))
+	if jsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", jsErr.Error())
+		return
+	}
+
+	os.MkdirAll(dirPath, os.ModePerm)
+	writeErr := ioutil.WriteFile(filePath, []byte(encryptLegacyForTests([]byte(password), js)), os.ModePerm)
+	if writeErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", writeErr.Error())
+		return
+	}
+
+	// retrieve:
+	repository := createRepository()
+	retConf, retConfErr := repository.Retrieve(filePath, password)
+	if retConfErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retConfErr.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(createStorableConfigs(conf), createStorableConfigs(retConf)) {
+		t.Errorf("the retrieved config file did not matched the original config file")
+		return
+	}
+
+	// the file must have been migrated:
+	data, dataErr := ioutil.ReadFile(filePath)
+	if dataErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", dataErr.Error())
+		return
+	}
+
+	if crypto.SDKFunc.IsLegacyEncryption(crypto.IsLegacyEncryptionParams{
+		EncryptedMsg: string(data),
+	}) {
+		t.Errorf("the config file was expected to be migrated to the current encryption format")
+		return
+	}
+
+	// retrieve the migrated file:
+	migratedConf, migratedConfErr := repository.Retrieve(filePath, password)
+	if migratedConfErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", migratedConfErr.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(createStorableConfigs(conf), createStorableConfigs(migratedConf)) {
+		t.Errorf("the migrated config file did not matched the original config file")
+		return
+	}
+
+	// retrieve with an invalid password:
+	_, invalidConfErr := repository.Retrieve(filePath, "this-is-not-the-password")
+	if invalidConfErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+// encryptLegacyForTests encrypts the message the way configs files were encrypted before the envelope format
+func encryptLegacyForTests(pass []byte, msg []byte) string {
+	key := sha256.Sum256(pass)
+	block, blockErr := aes.NewCipher(key[:])
+	if blockErr != nil {
+		panic(blockErr)
+	}
+
+	ciphertext := make([]byte, aes.BlockSize+len(msg))
+	iv := ciphertext[:aes.BlockSize]
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		panic(err)
+	}
+
+	stream := cipher.NewCFBEncrypter(block, iv)
+	stream.XORKeyStream(ciphertext[aes.BlockSize:], msg)
+	return base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+func TestGenerateFromMnemonic_isDeterministic_Success(t *testing.T) {
+	// variables:
+	passphrase := "this is a passphrase"
+	mnemonic := crypto.SDKFunc.GenerateMnemonic(crypto.GenerateMnemonicParams{})
+
+	// generate the configs twice:
+	conf := SDKFunc.GenerateFromMnemonic(GenerateFromMnemonicParams{
+		Mnemonic:   mnemonic,
+		Passphrase: passphrase,
+	})
+
+	restoredConf := SDKFunc.GenerateFromMnemonic(GenerateFromMnemonicParams{
+		Mnemonic:   mnemonic,
+		Passphrase: passphrase,
+	})
+
+	// compare:
+	if !reflect.DeepEqual(createStorableConfigs(conf), createStorableConfigs(restoredConf)) {
+		t.Errorf("the restored config was expected to be the same as the generated config")
+		return
+	}
+}
@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// the length, in bytes, of the hash of the PublicKey contained in an address:
+const addressHashLength = 20
+
+func createAddress(pubKey PublicKey) (string, error) {
+	data, dataErr := fromPubKeyToBytes(pubKey)
+	if dataErr != nil {
+		return "", dataErr
+	}
+
+	return base58CheckEncode(base58Versions[AddressKind], addressHash(data)), nil
+}
+
+func verifyAddress(address string, pubKey PublicKey) (bool, error) {
+	version, hash, hashErr := base58CheckDecode(address)
+	if hashErr != nil {
+		return false, hashErr
+	}
+
+	if version != base58Versions[AddressKind] || len(hash) != addressHashLength {
+		str := fmt.Sprintf("the address (%s) is not a valid address", address)
+		return false, errors.New(str)
+	}
+
+	data, dataErr := fromPubKeyToBytes(pubKey)
+	if dataErr != nil {
+		return false, dataErr
+	}
+
+	return string(addressHash(data)) == string(hash), nil
+}
+
+func addressHash(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:addressHashLength]
+}
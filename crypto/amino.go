@@ -13,6 +13,9 @@ const (
 
 	// XMNSuiteCryptoSignature represents the xmnsuite crypto Signature
 	XMNSuiteCryptoSignature = "xmnsuite/crypto/Signature"
+
+	// XMNSuiteCryptoLinkableRingSignature represents the xmnsuite crypto LinkableRingSignature
+	XMNSuiteCryptoLinkableRingSignature = "xmnsuite/crypto/LinkableRingSignature"
 )
 
 var cdc = amino.NewCodec()
@@ -49,4 +52,13 @@ func Register(codec *amino.Codec) {
 		codec.RegisterInterface((*Signature)(nil), nil)
 		codec.RegisterConcrete(&signature{}, XMNSuiteCryptoSignature, nil)
 	}()
+
+	// LinkableRingSignature
+	func() {
+		defer func() {
+			recover()
+		}()
+		codec.RegisterInterface((*LinkableRingSignature)(nil), nil)
+		codec.RegisterConcrete(&linkableRingSignature{}, XMNSuiteCryptoLinkableRingSignature, nil)
+	}()
 }
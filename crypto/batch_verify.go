@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"errors"
+)
+
+/*
+ * Batch verification:
+ * s[i] * G = r[i] - e[i] * P[i] holds for every signature, so with random coefficients z[i]:
+ * (sum of z[i] * s[i]) * G = sum of z[i] * (r[i] - e[i] * P[i])
+ * The random coefficients prevent an invalid signature from cancelling another one.
+ */
+
+func batchVerify(domain string, msgs [][]byte, sigs []Signature, pubKeys []PublicKey) (bool, error) {
+	if len(msgs) != len(sigs) || len(sigs) != len(pubKeys) {
+		return false, errors.New("the amount of messages, signatures and PublicKeys must be the same in order to batch verify signatures")
+	}
+
+	g := curve.Point().Base()
+	sumS := curve.Scalar().Zero()
+	sumPoints := curve.Point().Null()
+	for index, oneSig := range sigs {
+		sig, ok := oneSig.(*signature)
+		if !ok {
+			// the signature cannot be aggregated, so verify it alone:
+			if !oneSig.VerifyBytes(domain, msgs[index], pubKeys[index]) {
+				return false, nil
+			}
+
+			continue
+		}
+
+		z := curve.Scalar().Pick(curve.RandomStream())
+		e := hashWithDomain(domain, msgs[index], sig.r.Point())
+		ep := curve.Point().Mul(e, pubKeys[index].Point())
+		sGv := curve.Point().Sub(sig.r.Point(), ep)
+
+		sumS = curve.Scalar().Add(sumS, curve.Scalar().Mul(z, sig.s))
+		sumPoints = curve.Point().Add(sumPoints, curve.Point().Mul(z, sGv))
+	}
+
+	return curve.Point().Mul(sumS, g).Equal(sumPoints), nil
+}
@@ -0,0 +1,219 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type hexEncoding struct {
+}
+
+// the built-in encodings are the only ones used to parse the keys and signatures of the consensus,
+// so that the encodings registered on a node never change how its blocks are parsed:
+var builtInEncodings = []Encoding{
+	createPEMEncoding(),
+	createJSONLDEncoding(),
+	createHexEncoding(),
+	createBase58Encoding(),
+}
+
+var registeredEncodings = struct {
+	mut  *sync.Mutex
+	list []Encoding
+}{
+	mut:  &sync.Mutex{},
+	list: []Encoding{},
+}
+
+func registerEncoding(enc Encoding) error {
+	for _, oneEncoding := range builtInEncodings {
+		if oneEncoding.Name() == enc.Name() {
+			str := fmt.Sprintf("the encoding (%s) is built-in and therefore cannot be replaced", enc.Name())
+			return errors.New(str)
+		}
+	}
+
+	registeredEncodings.mut.Lock()
+	defer registeredEncodings.mut.Unlock()
+
+	for index, oneEncoding := range registeredEncodings.list {
+		if oneEncoding.Name() == enc.Name() {
+			registeredEncodings.list[index] = enc
+			return nil
+		}
+	}
+
+	registeredEncodings.list = append(registeredEncodings.list, enc)
+	return nil
+}
+
+// the registered encodings are detected before the built-in ones:
+func retrieveInputEncodings() []Encoding {
+	registeredEncodings.mut.Lock()
+	defer registeredEncodings.mut.Unlock()
+
+	out := []Encoding{}
+	out = append(out, registeredEncodings.list...)
+	return append(out, builtInEncodings...)
+}
+
+func retrieveEncoding(name string) (Encoding, error) {
+	for _, oneEncoding := range retrieveInputEncodings() {
+		if oneEncoding.Name() == name {
+			return oneEncoding, nil
+		}
+	}
+
+	str := fmt.Sprintf("the encoding (%s) is not registered", name)
+	return nil, errors.New(str)
+}
+
+func encodeToString(encodingName string, kind string, data []byte) (string, error) {
+	enc, encErr := retrieveEncoding(encodingName)
+	if encErr != nil {
+		return "", encErr
+	}
+
+	return enc.Encode(kind, data)
+}
+
+// decodeString only detects the built-in encodings, and is therefore used by the consensus:
+func decodeString(kind string, str string) ([]byte, error) {
+	return decodeStringWithEncodings(builtInEncodings, kind, str)
+}
+
+// decodeInputString also detects the registered encodings, and is therefore only used on the inputs of the users:
+func decodeInputString(kind string, str string) ([]byte, error) {
+	return decodeStringWithEncodings(retrieveInputEncodings(), kind, str)
+}
+
+func decodeStringWithEncodings(list []Encoding, kind string, str string) ([]byte, error) {
+	for _, oneEncoding := range list {
+		if !oneEncoding.Detect(str) {
+			continue
+		}
+
+		return oneEncoding.Decode(kind, str)
+	}
+
+	str = fmt.Sprintf("the format of the given %s could not be detected", kind)
+	return nil, errors.New(str)
+}
+
+func fromPubKeyToBytes(pubKey PublicKey) ([]byte, error) {
+	return pubKey.Point().MarshalBinary()
+}
+
+func fromBytesToPubKey(data []byte) (PublicKey, error) {
+	p := curve.Point()
+	err := p.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return createPublicKey(p), nil
+}
+
+func fromPKToBytes(pk PrivateKey) ([]byte, error) {
+	casted, ok := pk.(*privateKey)
+	if !ok {
+		return nil, errors.New("the PrivateKey cannot be encoded since it was not created by this package")
+	}
+
+	return casted.x.MarshalBinary()
+}
+
+func fromBytesToPK(data []byte) (PrivateKey, error) {
+	x := curve.Scalar()
+	err := x.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+
+	out := privateKey{
+		x: x,
+	}
+
+	return &out, nil
+}
+
+// a signature is encoded as its r point, followed by its s scalar:
+func fromSigToBytes(sig Signature) ([]byte, error) {
+	casted, ok := sig.(*signature)
+	if !ok {
+		return nil, errors.New("the Signature cannot be encoded since it was not created by this package")
+	}
+
+	r, rErr := casted.r.Point().MarshalBinary()
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	s, sErr := casted.s.MarshalBinary()
+	if sErr != nil {
+		return nil, sErr
+	}
+
+	return append(r, s...), nil
+}
+
+func fromBytesToSig(data []byte) (Signature, error) {
+	pointLength := curve.PointLen()
+	if len(data) != pointLength+curve.ScalarLen() {
+		str := fmt.Sprintf("the encoded signature was expected to contain %d bytes, %d provided", pointLength+curve.ScalarLen(), len(data))
+		return nil, errors.New(str)
+	}
+
+	r := curve.Point()
+	rErr := r.UnmarshalBinary(data[:pointLength])
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	s := curve.Scalar()
+	sErr := s.UnmarshalBinary(data[pointLength:])
+	if sErr != nil {
+		return nil, sErr
+	}
+
+	return createSignature(createPublicKey(r), s), nil
+}
+
+func createHexEncoding() Encoding {
+	out := hexEncoding{}
+	return &out
+}
+
+// Name returns the name of the encoding
+func (app *hexEncoding) Name() string {
+	return HexEncoding
+}
+
+// Encode encodes the data of the given kind
+func (app *hexEncoding) Encode(kind string, data []byte) (string, error) {
+	return hex.EncodeToString(data), nil
+}
+
+// Decode decodes the string of the given kind
+func (app *hexEncoding) Decode(kind string, str string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimSpace(str))
+}
+
+// Detect returns true if the string is hex encoded, false otherwise
+func (app *hexEncoding) Detect(str string) bool {
+	trimmed := strings.TrimSpace(str)
+	if len(trimmed) <= 0 || len(trimmed)%2 != 0 {
+		return false
+	}
+
+	for _, oneChar := range strings.ToLower(trimmed) {
+		if !strings.ContainsRune("0123456789abcdef", oneChar) {
+			return false
+		}
+	}
+
+	return true
+}
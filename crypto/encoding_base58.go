@@ -0,0 +1,163 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// the version bytes prefixed to the base58check payloads, so that a key of one kind can never be decoded as another kind:
+var base58Versions = map[string]byte{
+	PubKeyKind:  0x3f,
+	PKKind:      0x80,
+	SigKind:     0x3c,
+	AddressKind: 0x4b,
+}
+
+type base58Encoding struct {
+}
+
+func createBase58Encoding() Encoding {
+	out := base58Encoding{}
+	return &out
+}
+
+// Name returns the name of the encoding
+func (app *base58Encoding) Name() string {
+	return Base58Encoding
+}
+
+// Encode encodes the data of the given kind, with its version byte and checksum
+func (app *base58Encoding) Encode(kind string, data []byte) (string, error) {
+	version, ok := base58Versions[kind]
+	if !ok {
+		str := fmt.Sprintf("the kind (%s) does not have a base58check version byte", kind)
+		return "", errors.New(str)
+	}
+
+	return base58CheckEncode(version, data), nil
+}
+
+// Decode decodes the string of the given kind, after validating its version byte and checksum
+func (app *base58Encoding) Decode(kind string, str string) ([]byte, error) {
+	expectedVersion, ok := base58Versions[kind]
+	if !ok {
+		str := fmt.Sprintf("the kind (%s) does not have a base58check version byte", kind)
+		return nil, errors.New(str)
+	}
+
+	version, data, dataErr := base58CheckDecode(strings.TrimSpace(str))
+	if dataErr != nil {
+		return nil, dataErr
+	}
+
+	if version != expectedVersion {
+		str := fmt.Sprintf("the version byte (%d) does not match the version byte of a %s (%d)", version, kind, expectedVersion)
+		return nil, errors.New(str)
+	}
+
+	return data, nil
+}
+
+// Detect returns true if the string only contains base58 characters, false otherwise
+func (app *base58Encoding) Detect(str string) bool {
+	trimmed := strings.TrimSpace(str)
+	if len(trimmed) <= 0 {
+		return false
+	}
+
+	for _, oneChar := range trimmed {
+		if !strings.ContainsRune(base58Alphabet, oneChar) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func base58CheckEncode(version byte, data []byte) string {
+	payload := append([]byte{version}, data...)
+	return base58Encode(append(payload, base58Checksum(payload)...))
+}
+
+func base58CheckDecode(str string) (byte, []byte, error) {
+	decoded, decodedErr := base58Decode(str)
+	if decodedErr != nil {
+		return 0, nil, decodedErr
+	}
+
+	if len(decoded) < 5 {
+		return 0, nil, errors.New("the base58check string is too short to contain a version byte and a checksum")
+	}
+
+	payload := decoded[:len(decoded)-4]
+	if !bytes.Equal(base58Checksum(payload), decoded[len(decoded)-4:]) {
+		return 0, nil, errors.New("the checksum of the base58check string is invalid")
+	}
+
+	return payload[0], payload[1:], nil
+}
+
+func base58Checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
+
+func base58Encode(data []byte) string {
+	radix := big.NewInt(58)
+	number := new(big.Int).SetBytes(data)
+	mod := new(big.Int)
+
+	out := []byte{}
+	for number.Sign() > 0 {
+		number.DivMod(number, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// each leading zero byte is encoded as the first character of the alphabet:
+	for _, oneByte := range data {
+		if oneByte != 0 {
+			break
+		}
+
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+func base58Decode(str string) ([]byte, error) {
+	radix := big.NewInt(58)
+	number := new(big.Int)
+	for _, oneChar := range str {
+		index := strings.IndexRune(base58Alphabet, oneChar)
+		if index < 0 {
+			str := fmt.Sprintf("the character (%c) is not a valid base58 character", oneChar)
+			return nil, errors.New(str)
+		}
+
+		number.Mul(number, radix)
+		number.Add(number, big.NewInt(int64(index)))
+	}
+
+	leadingZeros := 0
+	for _, oneChar := range str {
+		if oneChar != rune(base58Alphabet[0]) {
+			break
+		}
+
+		leadingZeros++
+	}
+
+	return append(make([]byte, leadingZeros), number.Bytes()...), nil
+}
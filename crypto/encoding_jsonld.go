@@ -0,0 +1,98 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const jsonLDContext = "https://w3id.org/security/v2"
+
+type jsonLDKind struct {
+	typ   string
+	field string
+}
+
+// the curve is ed25519 but the signature scheme is not, so the types do not claim to be Ed25519VerificationKey2018:
+var jsonLDKinds = map[string]jsonLDKind{
+	PubKeyKind: {
+		typ:   "XmnSchnorrPublicKey",
+		field: "publicKeyBase58",
+	},
+	PKKind: {
+		typ:   "XmnSchnorrPrivateKey",
+		field: "privateKeyBase58",
+	},
+	SigKind: {
+		typ:   "XmnSchnorrSignature",
+		field: "signatureBase58",
+	},
+}
+
+type jsonLDEncoding struct {
+}
+
+func createJSONLDEncoding() Encoding {
+	out := jsonLDEncoding{}
+	return &out
+}
+
+// Name returns the name of the encoding
+func (app *jsonLDEncoding) Name() string {
+	return JSONLDEncoding
+}
+
+// Encode encodes the data of the given kind in a JSON-LD document
+func (app *jsonLDEncoding) Encode(kind string, data []byte) (string, error) {
+	ldKind, ok := jsonLDKinds[kind]
+	if !ok {
+		str := fmt.Sprintf("the kind (%s) does not have a JSON-LD type", kind)
+		return "", errors.New(str)
+	}
+
+	js, jsErr := json.Marshal(map[string]string{
+		"@context":   jsonLDContext,
+		"type":       ldKind.typ,
+		ldKind.field: base58Encode(data),
+	})
+
+	if jsErr != nil {
+		return "", jsErr
+	}
+
+	return string(js), nil
+}
+
+// Decode decodes the JSON-LD document of the given kind
+func (app *jsonLDEncoding) Decode(kind string, str string) ([]byte, error) {
+	ldKind, ok := jsonLDKinds[kind]
+	if !ok {
+		str := fmt.Sprintf("the kind (%s) does not have a JSON-LD type", kind)
+		return nil, errors.New(str)
+	}
+
+	doc := map[string]string{}
+	jsErr := json.Unmarshal([]byte(str), &doc)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	if doc["type"] != ldKind.typ {
+		str := fmt.Sprintf("the JSON-LD type (%s) was expected to be: %s", doc["type"], ldKind.typ)
+		return nil, errors.New(str)
+	}
+
+	value, ok := doc[ldKind.field]
+	if !ok {
+		str := fmt.Sprintf("the JSON-LD document does not contain the %s field", ldKind.field)
+		return nil, errors.New(str)
+	}
+
+	return base58Decode(value)
+}
+
+// Detect returns true if the string is a JSON object, false otherwise
+func (app *jsonLDEncoding) Detect(str string) bool {
+	return strings.HasPrefix(strings.TrimSpace(str), "{")
+}
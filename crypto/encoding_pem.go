@@ -0,0 +1,67 @@
+package crypto
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var pemBlockTypes = map[string]string{
+	PubKeyKind: "XMN PUBLIC KEY",
+	PKKind:     "XMN PRIVATE KEY",
+	SigKind:    "XMN SIGNATURE",
+}
+
+type pemEncoding struct {
+}
+
+func createPEMEncoding() Encoding {
+	out := pemEncoding{}
+	return &out
+}
+
+// Name returns the name of the encoding
+func (app *pemEncoding) Name() string {
+	return PEMEncoding
+}
+
+// Encode encodes the data of the given kind in a PEM block
+func (app *pemEncoding) Encode(kind string, data []byte) (string, error) {
+	blockType, ok := pemBlockTypes[kind]
+	if !ok {
+		str := fmt.Sprintf("the kind (%s) does not have a PEM block type", kind)
+		return "", errors.New(str)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  blockType,
+		Bytes: data,
+	})), nil
+}
+
+// Decode decodes the PEM block of the given kind
+func (app *pemEncoding) Decode(kind string, str string) ([]byte, error) {
+	blockType, ok := pemBlockTypes[kind]
+	if !ok {
+		str := fmt.Sprintf("the kind (%s) does not have a PEM block type", kind)
+		return nil, errors.New(str)
+	}
+
+	block, _ := pem.Decode([]byte(strings.TrimSpace(str)))
+	if block == nil {
+		return nil, errors.New("the PEM block could not be decoded")
+	}
+
+	if block.Type != blockType {
+		str := fmt.Sprintf("the PEM block type (%s) was expected to be: %s", block.Type, blockType)
+		return nil, errors.New(str)
+	}
+
+	return block.Bytes, nil
+}
+
+// Detect returns true if the string begins with a PEM block, false otherwise
+func (app *pemEncoding) Detect(str string) bool {
+	return strings.HasPrefix(strings.TrimSpace(str), "-----BEGIN ")
+}
@@ -0,0 +1,179 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+type prefixedEncoding struct {
+}
+
+func (app *prefixedEncoding) Name() string {
+	return "prefixed"
+}
+
+func (app *prefixedEncoding) Encode(kind string, data []byte) (string, error) {
+	return "prefixed:" + hex.EncodeToString(data), nil
+}
+
+func (app *prefixedEncoding) Decode(kind string, str string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(str, "prefixed:"))
+}
+
+func (app *prefixedEncoding) Detect(str string) bool {
+	return strings.HasPrefix(str, "prefixed:")
+}
+
+func TestEncoding_encodeThenCreate_Success(t *testing.T) {
+	// variables:
+	pk := SDKFunc.GenPK()
+	sig := pk.SignBytes("some domain", []byte("this is a message"))
+	encodingNames := []string{
+		HexEncoding,
+		Base58Encoding,
+		PEMEncoding,
+		JSONLDEncoding,
+	}
+
+	for _, oneEncoding := range encodingNames {
+		// encode:
+		pubKeyAsString := SDKFunc.EncodePubKey(EncodePubKeyParams{
+			PubKey:   pk.PublicKey(),
+			Encoding: oneEncoding,
+		})
+
+		pkAsString := SDKFunc.EncodePK(EncodePKParams{
+			PK:       pk,
+			Encoding: oneEncoding,
+		})
+
+		sigAsString := SDKFunc.EncodeSig(EncodeSigParams{
+			Sig:      sig,
+			Encoding: oneEncoding,
+		})
+
+		// the format is detected while parsing:
+		retPubKey := SDKFunc.CreatePubKey(CreatePubKeyParams{
+			PubKeyAsString: pubKeyAsString,
+		})
+
+		if !retPubKey.Equals(pk.PublicKey()) {
+			t.Errorf("the %s encoded PublicKey did not match the original PublicKey", oneEncoding)
+			return
+		}
+
+		retPK := SDKFunc.CreatePK(CreatePKParams{
+			PKAsString: pkAsString,
+		})
+
+		if retPK.String() != pk.String() {
+			t.Errorf("the %s encoded PrivateKey did not match the original PrivateKey", oneEncoding)
+			return
+		}
+
+		retSig := SDKFunc.CreateSig(CreateSigParams{
+			SigAsString: sigAsString,
+		})
+
+		if !retSig.VerifyBytes("some domain", []byte("this is a message"), pk.PublicKey()) {
+			t.Errorf("the %s encoded Signature was expected to be valid", oneEncoding)
+			return
+		}
+	}
+}
+
+func TestEncoding_base58_withAnotherKind_returnsError(t *testing.T) {
+	// variables:
+	pk := SDKFunc.GenPK()
+	pkAsString := SDKFunc.EncodePK(EncodePKParams{
+		PK:       pk,
+		Encoding: Base58Encoding,
+	})
+
+	// a PrivateKey cannot be parsed as a PublicKey, since its version byte is different:
+	_, pubKeyErr := createPublicKeyFromString(pkAsString)
+	if pubKeyErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// a modified character breaks the checksum:
+	modified := []byte(pkAsString)
+	if modified[10] == 'a' {
+		modified[10] = 'b'
+	} else {
+		modified[10] = 'a'
+	}
+
+	_, pkErr := createPrivateKeyFromString(string(modified))
+	if pkErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestEncoding_registered_isOnlyUsedToParseInputs(t *testing.T) {
+	// variables:
+	pk := SDKFunc.GenPK()
+	regErr := registerEncoding(new(prefixedEncoding))
+	if regErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", regErr.Error())
+		return
+	}
+
+	pubKeyAsString := SDKFunc.EncodePubKey(EncodePubKeyParams{
+		PubKey:   pk.PublicKey(),
+		Encoding: "prefixed",
+	})
+
+	// the inputs of the users can use the registered encoding:
+	retPubKey := SDKFunc.ParsePubKey(CreatePubKeyParams{
+		PubKeyAsString: pubKeyAsString,
+	})
+
+	if !retPubKey.Equals(pk.PublicKey()) {
+		t.Errorf("the parsed PublicKey did not match the original PublicKey")
+		return
+	}
+
+	// the consensus only uses the built-in encodings:
+	_, pubKeyErr := createPublicKeyFromString(pubKeyAsString)
+	if pubKeyErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// the built-in encodings cannot be replaced:
+	hexErr := registerEncoding(createHexEncoding())
+	if hexErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestAddress_Success(t *testing.T) {
+	// variables:
+	pubKey := SDKFunc.GenPK().PublicKey()
+	otherPubKey := SDKFunc.GenPK().PublicKey()
+
+	// execute:
+	address := SDKFunc.CreateAddress(CreateAddressParams{
+		PubKey: pubKey,
+	})
+
+	if address != SDKFunc.CreateAddress(CreateAddressParams{PubKey: pubKey}) {
+		t.Errorf("the address of a PublicKey was expected to always be the same")
+		return
+	}
+
+	if !SDKFunc.VerifyAddress(VerifyAddressParams{Address: address, PubKey: pubKey}) {
+		t.Errorf("the address was expected to match its PublicKey")
+		return
+	}
+
+	if SDKFunc.VerifyAddress(VerifyAddressParams{Address: address, PubKey: otherPubKey}) {
+		t.Errorf("the address was not expected to match another PublicKey")
+		return
+	}
+}
@@ -1,21 +1,154 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// the prefix of the encrypted messages using the versioned envelope format:
+const envelopePrefix = "xmn2:"
+
+// the version of the envelope format:
+const envelopeVersion = byte(2)
+
+// the argon2id parameters used to derive the key of new envelopes:
+const (
+	kdfTime    = uint32(3)
+	kdfMemory  = uint32(64 * 1024)
+	kdfThreads = uint8(4)
+	kdfKeyLen  = uint32(32)
+	saltLength = 16
+)
+
+// the maximum parameters that an envelope header can request to derive its key.  The memory is in KiB:
+const (
+	kdfMaxTime    = uint32(16)
+	kdfMaxMemory  = uint32(1024 * 1024)
+	kdfMaxThreads = uint8(16)
 )
 
+// the length of the envelope header: version, time, memory, threads and salt:
+const envelopeHeaderLength = 1 + 4 + 4 + 1 + saltLength
+
 func hashPass(pass []byte) []byte {
 	hasher := curve.Hash()
 	hasher.Write([]byte(pass))
 	return hasher.Sum(nil)
 }
 
+func isLegacyEncryption(encryptedText string) bool {
+	return !strings.HasPrefix(encryptedText, envelopePrefix)
+}
+
 func encrypt(pass []byte, msg []byte) (string, error) {
+	// create the header:
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	header := new(bytes.Buffer)
+	header.WriteByte(envelopeVersion)
+	binary.Write(header, binary.BigEndian, kdfTime)
+	binary.Write(header, binary.BigEndian, kdfMemory)
+	header.WriteByte(kdfThreads)
+	header.Write(salt)
+
+	// derive the key and create the cipher:
+	key := argon2.IDKey(pass, salt, kdfTime, kdfMemory, kdfThreads, kdfKeyLen)
+	aead, aeadErr := createAEAD(key)
+	if aeadErr != nil {
+		return "", aeadErr
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	// seal the message, the header is authenticated along with it:
+	headerAsBytes := header.Bytes()
+	sealed := aead.Seal(nil, nonce, msg, headerAsBytes)
+
+	envelope := []byte{}
+	envelope = append(envelope, headerAsBytes...)
+	envelope = append(envelope, nonce...)
+	envelope = append(envelope, sealed...)
+	return fmt.Sprintf("%s%s", envelopePrefix, base64.StdEncoding.EncodeToString(envelope)), nil
+}
+
+func decrypt(pass []byte, encryptedText string) ([]byte, error) {
+	// the legacy encrypted texts can only be decrypted in order to migrate them:
+	if isLegacyEncryption(encryptedText) {
+		return nil, errors.New("the encrypted text does not use the envelope format")
+	}
+
+	envelope, envelopeErr := base64.StdEncoding.DecodeString(strings.TrimPrefix(encryptedText, envelopePrefix))
+	if envelopeErr != nil {
+		return nil, envelopeErr
+	}
+
+	if len(envelope) < envelopeHeaderLength {
+		return nil, errors.New("the encrypted text cannot be decoded: the envelope header is too short")
+	}
+
+	// read the header:
+	header := envelope[:envelopeHeaderLength]
+	if header[0] != envelopeVersion {
+		str := fmt.Sprintf("the envelope version (%d) is not supported", header[0])
+		return nil, errors.New(str)
+	}
+
+	iterations := binary.BigEndian.Uint32(header[1:5])
+	memory := binary.BigEndian.Uint32(header[5:9])
+	threads := header[9]
+	salt := header[10:]
+	if iterations == 0 || iterations > kdfMaxTime || threads == 0 || threads > kdfMaxThreads || memory > kdfMaxMemory {
+		return nil, errors.New("the envelope header contains invalid key derivation parameters")
+	}
+
+	// derive the key and create the cipher:
+	key := argon2.IDKey(pass, salt, iterations, memory, threads, kdfKeyLen)
+	aead, aeadErr := createAEAD(key)
+	if aeadErr != nil {
+		return nil, aeadErr
+	}
+
+	body := envelope[envelopeHeaderLength:]
+	if len(body) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("the encrypted text cannot be decoded: the envelope body is too short")
+	}
+
+	// open the message:
+	nonce := body[:aead.NonceSize()]
+	out, outErr := aead.Open(nil, nonce, body[aead.NonceSize():], header)
+	if outErr != nil {
+		return nil, errors.New("the password is invalid or the encrypted text has been tampered with")
+	}
+
+	return out, nil
+}
+
+func createAEAD(key []byte) (cipher.AEAD, error) {
+	block, blockErr := aes.NewCipher(key)
+	if blockErr != nil {
+		return nil, blockErr
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func encryptLegacy(pass []byte, msg []byte) (string, error) {
 	block, blockErr := aes.NewCipher(hashPass(pass))
 	if blockErr != nil {
 		return "", blockErr
@@ -33,7 +166,7 @@ func encrypt(pass []byte, msg []byte) (string, error) {
 	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
-func decrypt(pass []byte, encryptedText string) ([]byte, error) {
+func decryptLegacy(pass []byte, encryptedText string) ([]byte, error) {
 	cipherText, cipherTextErr := base64.StdEncoding.DecodeString(encryptedText)
 	if cipherTextErr != nil {
 		return nil, cipherTextErr
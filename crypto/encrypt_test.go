@@ -1,7 +1,10 @@
 package crypto
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -27,3 +30,123 @@ func TestEncrypt_decrypt_Success(t *testing.T) {
 		return
 	}
 }
+
+func TestEncrypt_decrypt_withInvalidPassword_returnsError(t *testing.T) {
+	// variables:
+	pass := []byte("this is the password used to generate the pk")
+	invalidPass := []byte("this is not the password used to generate the pk")
+	textToEncrypt := []byte("this is some text to encrypt... this is even longer text!")
+
+	encryptedText, encryptedTextErr := encrypt(pass, textToEncrypt)
+	if encryptedTextErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", encryptedTextErr.Error())
+		return
+	}
+
+	_, decryptedErr := decrypt(invalidPass, encryptedText)
+	if decryptedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestEncrypt_tamper_decrypt_returnsError(t *testing.T) {
+	// variables:
+	pass := []byte("this is the password used to generate the pk")
+	textToEncrypt := []byte("this is some text to encrypt... this is even longer text!")
+
+	encryptedText, encryptedTextErr := encrypt(pass, textToEncrypt)
+	if encryptedTextErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", encryptedTextErr.Error())
+		return
+	}
+
+	envelope, envelopeErr := base64.StdEncoding.DecodeString(strings.TrimPrefix(encryptedText, envelopePrefix))
+	if envelopeErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", envelopeErr.Error())
+		return
+	}
+
+	// tamper with the salt, then with the last byte of the ciphertext:
+	for _, index := range []int{envelopeHeaderLength - 1, len(envelope) - 1} {
+		tampered := make([]byte, len(envelope))
+		copy(tampered, envelope)
+		tampered[index] ^= 0xff
+
+		_, decryptedErr := decrypt(pass, envelopePrefix+base64.StdEncoding.EncodeToString(tampered))
+		if decryptedErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (tampered index: %d)", index)
+			return
+		}
+	}
+}
+
+func TestEncryptLegacy_decrypt_Success(t *testing.T) {
+	// variables:
+	pass := []byte("this is the password used to generate the pk")
+	textToEncrypt := []byte("this is some text to encrypt... this is even longer text!")
+
+	encryptedText, encryptedTextErr := encryptLegacy(pass, textToEncrypt)
+	if encryptedTextErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", encryptedTextErr.Error())
+		return
+	}
+
+	if !isLegacyEncryption(encryptedText) {
+		t.Errorf("the encrypted text was expected to use the legacy format")
+		return
+	}
+
+	// the legacy format can only be decrypted explicitly, in order to be migrated:
+	_, decryptedErr := decrypt(pass, encryptedText)
+	if decryptedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	decrypted, decryptedErr := decryptLegacy(pass, encryptedText)
+	if decryptedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", decryptedErr.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(textToEncrypt, decrypted) {
+		t.Errorf("the decrypted text was excpected to be the same as the original text")
+		return
+	}
+}
+
+func TestEncrypt_withTooExpensiveKeyDerivation_decrypt_returnsError(t *testing.T) {
+	// variables:
+	pass := []byte("this is the password used to generate the pk")
+	textToEncrypt := []byte("this is some text to encrypt... this is even longer text!")
+
+	encryptedText, encryptedTextErr := encrypt(pass, textToEncrypt)
+	if encryptedTextErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", encryptedTextErr.Error())
+		return
+	}
+
+	envelope, envelopeErr := base64.StdEncoding.DecodeString(strings.TrimPrefix(encryptedText, envelopePrefix))
+	if envelopeErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", envelopeErr.Error())
+		return
+	}
+
+	// request too many iterations, then too many threads:
+	iterations := make([]byte, len(envelope))
+	copy(iterations, envelope)
+	binary.BigEndian.PutUint32(iterations[1:5], kdfMaxTime+1)
+
+	threads := make([]byte, len(envelope))
+	copy(threads, envelope)
+	threads[9] = kdfMaxThreads + 1
+
+	for _, oneTampered := range [][]byte{iterations, threads} {
+		_, decryptedErr := decrypt(pass, envelopePrefix+base64.StdEncoding.EncodeToString(oneTampered))
+		if decryptedErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned")
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dedis/kyber"
+)
+
+/*
+ * ECIES:
+ * R = r * G -> the ephemeral PublicKey, r is discarded after encryption
+ * S = r * P = x * R -> the shared secret, P = x * G being the PublicKey of the recipient
+ * k = H(R || P || S) -> the key of the AES-GCM cipher
+ */
+
+// the version of the public-key encryption format:
+const encryptToVersion = byte(1)
+
+func deriveEncryptToKey(ephemeral kyber.Point, recipient kyber.Point, shared kyber.Point) []byte {
+	hasher := curve.Hash()
+	hasher.Write([]byte(ephemeral.String()))
+	hasher.Write([]byte(recipient.String()))
+	hasher.Write([]byte(shared.String()))
+	return hasher.Sum(nil)
+}
+
+func encryptTo(pubKey PublicKey, msg []byte) (string, error) {
+	// generate the ephemeral key:
+	r := curve.Scalar().Pick(curve.RandomStream())
+	ephemeral := curve.Point().Mul(r, curve.Point().Base())
+	shared := curve.Point().Mul(r, pubKey.Point())
+
+	ephemeralAsBytes, ephemeralAsBytesErr := ephemeral.MarshalBinary()
+	if ephemeralAsBytesErr != nil {
+		return "", ephemeralAsBytesErr
+	}
+
+	// derive the key and create the cipher:
+	key := deriveEncryptToKey(ephemeral, pubKey.Point(), shared)
+	aead, aeadErr := createAEAD(key)
+	if aeadErr != nil {
+		return "", aeadErr
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	// seal the message, the version and ephemeral key are authenticated along with it:
+	header := append([]byte{encryptToVersion}, ephemeralAsBytes...)
+	sealed := aead.Seal(nil, nonce, msg, header)
+
+	out := []byte{}
+	out = append(out, header...)
+	out = append(out, nonce...)
+	out = append(out, sealed...)
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+func decryptFrom(x kyber.Scalar, encryptedText string) ([]byte, error) {
+	encrypted, encryptedErr := base64.StdEncoding.DecodeString(encryptedText)
+	if encryptedErr != nil {
+		return nil, encryptedErr
+	}
+
+	headerLength := 1 + curve.PointLen()
+	if len(encrypted) < headerLength {
+		return nil, errors.New("the encrypted text cannot be decoded: the header is too short")
+	}
+
+	// read the header:
+	header := encrypted[:headerLength]
+	if header[0] != encryptToVersion {
+		str := fmt.Sprintf("the encryption version (%d) is not supported", header[0])
+		return nil, errors.New(str)
+	}
+
+	ephemeral := curve.Point()
+	ephemeralErr := ephemeral.UnmarshalBinary(header[1:])
+	if ephemeralErr != nil {
+		return nil, ephemeralErr
+	}
+
+	// derive the key and create the cipher:
+	recipient := curve.Point().Mul(x, curve.Point().Base())
+	shared := curve.Point().Mul(x, ephemeral)
+	key := deriveEncryptToKey(ephemeral, recipient, shared)
+	aead, aeadErr := createAEAD(key)
+	if aeadErr != nil {
+		return nil, aeadErr
+	}
+
+	body := encrypted[headerLength:]
+	if len(body) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("the encrypted text cannot be decoded: the body is too short")
+	}
+
+	// open the message:
+	nonce := body[:aead.NonceSize()]
+	out, outErr := aead.Open(nil, nonce, body[aead.NonceSize():], header)
+	if outErr != nil {
+		return nil, errors.New("the encrypted text was not encrypted to this PrivateKey or has been tampered with")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestEncryptTo_thenDecrypt_Success(t *testing.T) {
+	// variables:
+	msg := []byte("this is a private message")
+	pk := createPrivateKey()
+
+	encrypted, encryptedErr := encryptTo(pk.PublicKey(), msg)
+	if encryptedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", encryptedErr.Error())
+		return
+	}
+
+	decrypted, decryptedErr := pk.Decrypt(encrypted)
+	if decryptedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", decryptedErr.Error())
+		return
+	}
+
+	if !bytes.Equal(msg, decrypted) {
+		t.Errorf("the decrypted message is invalid.  Expected: %s, Returned: %s", msg, decrypted)
+		return
+	}
+
+	// encrypting the same message twice must not produce the same encrypted text:
+	secondEncrypted, secondEncryptedErr := encryptTo(pk.PublicKey(), msg)
+	if secondEncryptedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", secondEncryptedErr.Error())
+		return
+	}
+
+	if encrypted == secondEncrypted {
+		t.Errorf("the encrypted texts were expected to be different")
+		return
+	}
+}
+
+func TestEncryptTo_decryptWithAnotherPK_returnsError(t *testing.T) {
+	pk := createPrivateKey()
+	encrypted, encryptedErr := encryptTo(pk.PublicKey(), []byte("this is a private message"))
+	if encryptedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", encryptedErr.Error())
+		return
+	}
+
+	_, decryptedErr := createPrivateKey().Decrypt(encrypted)
+	if decryptedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestEncryptTo_tampered_returnsError(t *testing.T) {
+	pk := createPrivateKey()
+	encrypted, encryptedErr := encryptTo(pk.PublicKey(), []byte("this is a private message"))
+	if encryptedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", encryptedErr.Error())
+		return
+	}
+
+	decoded, _ := base64.StdEncoding.DecodeString(encrypted)
+	decoded[len(decoded)-1] ^= 0xff
+
+	_, decryptedErr := pk.Decrypt(base64.StdEncoding.EncodeToString(decoded))
+	if decryptedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -7,6 +7,8 @@ package crypto
  * k = s + e * x
  * s = k – e * x
  * k = H(m || x) -> to generate a new k, since nobody but us knows x
+ * k = Kd(x, d, m) -> instead of H(m || x), when the message is signed as bytes in the domain d
+ * e = Hd(d, m, k * G) -> instead of H(m || k * G), when the message is signed as bytes in the domain d
  * where ...
  * 1. H is a hash function, for instance SHA256.
  * 2. s and e are 2 numbers forming the ring signature
@@ -16,10 +18,15 @@ package crypto
  * 6. G is the random base
  * 7. k is a number chosen randomly.  A new one every time we sign must be generated
  * 8. x is the private key
+ * 9. Hd is an hmac of the lengths of d and m, followed by d, m and the point
+ * 10. Kd is an hmac, keyed with x, of a tag followed by the lengths of d and m, d and m
  */
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 
 	"github.com/dedis/kyber"
@@ -28,6 +35,14 @@ import (
 
 var curve = edwards25519.NewBlakeSHA256Ed25519()
 
+// the key of the hmac used to compute the challenges of the domain separated signatures.  Since the
+// challenges are hmacs, they can never be equal to a challenge computed on a string message:
+const domainHMACKey = "xmnsuite domain separated signature"
+
+// the tag of the nonces of the domain separated signatures.  Since these nonces are hmacs keyed with the private key,
+// they can never be equal to a nonce generated on a string message, even when the string is the domain message:
+const domainNonceTag = "xmnsuite domain separated nonce"
+
 func hash(msg string) kyber.Scalar {
 	sha256 := curve.Hash()
 	sha256.Reset()
@@ -40,6 +55,37 @@ func genK(x kyber.Scalar, msg string) kyber.Scalar {
 	return hash(msg + x.String())
 }
 
+func genDomainK(x kyber.Scalar, domain string, msg []byte, suffix string) kyber.Scalar {
+	lengths := make([]byte, 12)
+	binary.BigEndian.PutUint32(lengths[:4], uint32(len(domain)))
+	binary.BigEndian.PutUint32(lengths[4:8], uint32(len(msg)))
+	binary.BigEndian.PutUint32(lengths[8:], uint32(len(suffix)))
+
+	mac := hmac.New(sha256.New, []byte(x.String()))
+	mac.Write([]byte(domainNonceTag))
+	mac.Write(lengths)
+	mac.Write([]byte(domain))
+	mac.Write(msg)
+	mac.Write([]byte(suffix))
+	return curve.Scalar().SetBytes(mac.Sum(nil))
+}
+
+func hashWithDomain(domain string, msg []byte, points ...kyber.Point) kyber.Scalar {
+	lengths := make([]byte, 8)
+	binary.BigEndian.PutUint32(lengths[:4], uint32(len(domain)))
+	binary.BigEndian.PutUint32(lengths[4:], uint32(len(msg)))
+
+	mac := hmac.New(sha256.New, []byte(domainHMACKey))
+	mac.Write(lengths)
+	mac.Write([]byte(domain))
+	mac.Write(msg)
+	for _, onePoint := range points {
+		mac.Write([]byte(onePoint.String()))
+	}
+
+	return curve.Scalar().SetBytes(mac.Sum(nil))
+}
+
 func fromStringToScalar(str string) (kyber.Scalar, error) {
 	decoded, decodedErr := hex.DecodeString(str)
 	if decodedErr != nil {
@@ -67,17 +67,64 @@ func xDecrypt(encryptedPK *C.char, passWords **C.char, amountPassWords C.int) (r
 	}
 
 	// decrypt the pk:
-	pkAsString := string(crypto.SDKFunc.Decrypt(crypto.DecryptParams{
-		Pass:         []byte(strings.Join(passsWords, "|")),
-		EncryptedMsg: C.GoString(encryptedPK),
-	}))
+	decPK := decryptPK(C.GoString(encryptedPK), []byte(strings.Join(passsWords, "|")))
+	return C.CString(decPK.String())
+}
 
-	// make sure the decrypted pk is a real PK:
-	decPK := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{
-		PKAsString: pkAsString,
+//export xMigrateEncryptedPk
+func xMigrateEncryptedPk(encryptedPK *C.char, passWords **C.char, amountPassWords C.int) (ret *C.char) {
+
+	// returns an empty string if the PK can't be decoded using the given pass words:
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("xMigrateEncryptedPk panic: ", r)
+			ret = C.CString("")
+		}
+	}()
+
+	// retrieve the words from C:
+	const arrayLen = 1<<30 - 1
+	sliceWithCCHars := (*[arrayLen]*C.char)(unsafe.Pointer(passWords))[:arrayLen:arrayLen]
+
+	// convert the C string to Go strings:
+	passsWords := []string{}
+	goAmount := int(amountPassWords)
+	for i := 0; i < goAmount; i++ {
+		passsWords = append(passsWords, C.GoString(sliceWithCCHars[i]))
+	}
+
+	// decrypt the pk, then encrypt it again in the current encryption format:
+	pass := []byte(strings.Join(passsWords, "|"))
+	decPK := decryptPK(C.GoString(encryptedPK), pass)
+	encPK := crypto.SDKFunc.Encrypt(crypto.EncryptParams{
+		Pass: pass,
+		Msg:  []byte(decPK.String()),
 	})
 
-	return C.CString(decPK.String())
+	return C.CString(encPK)
+}
+
+// decryptPK decrypts the PK, the PKs encrypted in the legacy format by the previous versions are still decrypted so
+// that they can be migrated:
+func decryptPK(encryptedPK string, pass []byte) crypto.PrivateKey {
+	params := crypto.DecryptParams{
+		Pass:         pass,
+		EncryptedMsg: encryptedPK,
+	}
+
+	var pkAsBytes []byte
+	if crypto.SDKFunc.IsLegacyEncryption(crypto.IsLegacyEncryptionParams{
+		EncryptedMsg: encryptedPK,
+	}) {
+		pkAsBytes = crypto.SDKFunc.DecryptLegacy(params)
+	} else {
+		pkAsBytes = crypto.SDKFunc.Decrypt(params)
+	}
+
+	// make sure the decrypted pk is a real PK:
+	return crypto.SDKFunc.CreatePK(crypto.CreatePKParams{
+		PKAsString: string(pkAsBytes),
+	})
 }
 
 //export xPKGetPublicKey
@@ -100,6 +147,16 @@ func xPKSign(pk *C.char, msg *C.char) *C.char {
 	return C.CString(sig.String())
 }
 
+//export xPKSignBytes
+func xPKSignBytes(pk *C.char, domain *C.char, msg *C.char) *C.char {
+	lpk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{
+		PKAsString: C.GoString(pk),
+	})
+
+	sig := lpk.SignBytes(C.GoString(domain), []byte(C.GoString(msg)))
+	return C.CString(sig.String())
+}
+
 //export xPKRingSign
 func xPKRingSign(pk *C.char, msg *C.char, ringPubKeys []*C.char) *C.char {
 	lpk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{
@@ -121,5 +178,26 @@ func xPKRingSign(pk *C.char, msg *C.char, ringPubKeys []*C.char) *C.char {
 	return C.CString(ringSig.String())
 }
 
+//export xPKRingSignBytes
+func xPKRingSignBytes(pk *C.char, domain *C.char, msg *C.char, ringPubKeys []*C.char) *C.char {
+	lpk := crypto.SDKFunc.CreatePK(crypto.CreatePKParams{
+		PKAsString: C.GoString(pk),
+	})
+
+	pubKeys := []crypto.PublicKey{}
+	for _, onePubKey := range ringPubKeys {
+		pubKeys = append(pubKeys, crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+			PubKeyAsString: C.GoString(onePubKey),
+		}))
+	}
+
+	ringSig, ringSigErr := lpk.RingSignBytes(C.GoString(domain), []byte(C.GoString(msg)), pubKeys)
+	if ringSigErr != nil {
+		return nil
+	}
+
+	return C.CString(ringSig.String())
+}
+
 func main() {
 }
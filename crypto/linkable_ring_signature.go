@@ -0,0 +1,280 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dedis/kyber"
+)
+
+/*
+ * A LinkableRingSignature is a linkable spontaneous anonymous group (LSAG) signature:
+ * I = x * Hp(P) -> the key image, identical for every signature of the same PrivateKey
+ * L[i] = s[i] * G + c[i] * P[i]
+ * R[i] = s[i] * Hp(P[i]) + c[i] * I
+ * c[i+1] = H(ring || I || m || L[i] || R[i])
+ * where Hp is a hash function that maps a PublicKey to a point of the curve.  When signed in a domain, H is the HMAC of the
+ * domain separated signatures, and the nonces are derived like the ones of the domain separated signatures.
+ */
+
+// the cofactor of the curve, used to clear the small order component of the key images:
+const cofactor = 8
+
+type linkableRingSignature struct {
+	ring     []PublicKey
+	s        []kyber.Scalar
+	c        kyber.Scalar
+	keyImage kyber.Point
+}
+
+type jsonLinkableRingSignature struct {
+	RingAsStrings    []string `json:"ring"`
+	SAsStrings       []string `json:"s"`
+	CAsString        string   `json:"c"`
+	KeyImageAsString string   `json:"key_image"`
+}
+
+func createLinkableRingSignature(ring []PublicKey, s []kyber.Scalar, c kyber.Scalar, keyImage kyber.Point) LinkableRingSignature {
+	out := linkableRingSignature{
+		ring:     ring,
+		s:        s,
+		c:        c,
+		keyImage: keyImage,
+	}
+
+	return &out
+}
+
+func createLinkableRingSignatureFromString(str string) (LinkableRingSignature, error) {
+	decoded, decodedErr := base64.StdEncoding.DecodeString(str)
+	if decodedErr != nil {
+		return nil, decodedErr
+	}
+
+	ptr := new(linkableRingSignature)
+	err := cdc.UnmarshalJSON(decoded, ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ptr, nil
+}
+
+func hashToPoint(pubKey PublicKey) kyber.Point {
+	p := curve.Point().Pick(curve.XOF([]byte(pubKey.String())))
+	return curve.Point().Mul(curve.Scalar().SetInt64(cofactor), p)
+}
+
+func linkableRingPrefix(msg string, ring []PublicKey, keyImage kyber.Point) string {
+	ringAsStrings := []string{}
+	for _, onePubKey := range ring {
+		ringAsStrings = append(ringAsStrings, onePubKey.String())
+	}
+
+	return fmt.Sprintf("%s|%s|%s", strings.Join(ringAsStrings, ","), keyImage.String(), msg)
+}
+
+func linkableRingSign(
+	x kyber.Scalar,
+	ringPubKeys []PublicKey,
+	challengeFn func(keyImage kyber.Point, l kyber.Point, r kyber.Point) kyber.Scalar,
+	nonceFn func(keyImage kyber.Point, suffix string) kyber.Scalar,
+) (LinkableRingSignature, error) {
+	// retrieve our signerIndex:
+	g := curve.Point().Base()
+	pubKey := createPublicKey(curve.Point().Mul(x, g))
+	signerIndex := -1
+	for index, oneRingPubKey := range ringPubKeys {
+		if oneRingPubKey.Equals(pubKey) {
+			signerIndex = index
+			break
+		}
+	}
+
+	if signerIndex == -1 {
+		return nil, errors.New("the signer PublicKey is not in the ring")
+	}
+
+	// I = x * Hp(P):
+	keyImage := curve.Point().Mul(x, hashToPoint(pubKey))
+
+	// generate k:
+	k := nonceFn(keyImage, "")
+
+	// length:
+	r := len(ringPubKeys)
+
+	// initialize:
+	cs := make([]kyber.Scalar, r)
+	ss := make([]kyber.Scalar, r)
+	beginIndex := (signerIndex + 1) % r
+
+	// c[i+1] = H(prefix || k * G || k * Hp(P))
+	kg := curve.Point().Mul(k, g)
+	khp := curve.Point().Mul(k, hashToPoint(pubKey))
+	cs[beginIndex] = challengeFn(keyImage, kg, khp)
+
+	// loop:
+	for i := beginIndex; i != signerIndex; i = (i + 1) % r {
+		// si = random value
+		ss[i] = nonceFn(keyImage, fmt.Sprintf("%d", i))
+
+		// c[i+1] = H(prefix || L[i] || R[i])
+		l, rr := linkableRingPoints(ringPubKeys[i], keyImage, ss[i], cs[i])
+		cs[(i+1)%r] = challengeFn(keyImage, l, rr)
+	}
+
+	// close the ring:
+	cx := curve.Scalar().Mul(cs[signerIndex], x)
+	ss[signerIndex] = curve.Scalar().Sub(k, cx)
+	out := createLinkableRingSignature(ringPubKeys, ss, cs[0], keyImage)
+	return out, nil
+}
+
+func linkableRingPoints(pubKey PublicKey, keyImage kyber.Point, s kyber.Scalar, c kyber.Scalar) (kyber.Point, kyber.Point) {
+	// L = s * G + c * P
+	sg := curve.Point().Mul(s, curve.Point().Base())
+	cp := curve.Point().Mul(c, pubKey.Point())
+	l := curve.Point().Add(sg, cp)
+
+	// R = s * Hp(P) + c * I
+	shp := curve.Point().Mul(s, hashToPoint(pubKey))
+	ci := curve.Point().Mul(c, keyImage)
+	r := curve.Point().Add(shp, ci)
+	return l, r
+}
+
+// Ring returns the PublicKeys of the ring
+func (app *linkableRingSignature) Ring() []PublicKey {
+	return app.ring
+}
+
+// KeyImage returns the key image of the signer.  Two signatures made by the same PrivateKey have the same key image
+func (app *linkableRingSignature) KeyImage() string {
+	// the small order component is cleared, so that it cannot be used to forge another key image for the same PrivateKey:
+	cleared := curve.Point().Mul(curve.Scalar().SetInt64(cofactor), app.keyImage)
+	return cleared.String()
+}
+
+// IsLinked returns true if both signatures have been made by the same PrivateKey, false otherwise
+func (app *linkableRingSignature) IsLinked(sig LinkableRingSignature) bool {
+	return app.KeyImage() == sig.KeyImage()
+}
+
+// Verify verifies if the message has been signed by a member of the ring
+func (app *linkableRingSignature) Verify(msg string) bool {
+	prefix := linkableRingPrefix(msg, app.ring, app.keyImage)
+	return app.verify(func(l kyber.Point, r kyber.Point) kyber.Scalar {
+		return hash(prefix + l.String() + r.String())
+	})
+}
+
+// VerifyBytes verifies if the message bytes have been signed, in the given domain, by a member of the ring
+func (app *linkableRingSignature) VerifyBytes(domain string, msg []byte) bool {
+	prefixed := []byte(linkableRingPrefix(string(msg), app.ring, app.keyImage))
+	return app.verify(func(l kyber.Point, r kyber.Point) kyber.Scalar {
+		return hashWithDomain(domain, prefixed, l, r)
+	})
+}
+
+func (app *linkableRingSignature) verify(challengeFn func(l kyber.Point, r kyber.Point) kyber.Scalar) bool {
+	amount := len(app.ring)
+	if amount <= 0 || amount != len(app.s) {
+		return false
+	}
+
+	// the key image must not be in the small order subgroup:
+	if app.KeyImage() == curve.Point().Null().String() {
+		return false
+	}
+
+	c := app.c
+	for i := 0; i < amount; i++ {
+		l, r := linkableRingPoints(app.ring[i], app.keyImage, app.s[i], c)
+		c = challengeFn(l, r)
+	}
+
+	return app.c.Equal(c)
+}
+
+// String returns the string representation of the linkable ring signature
+func (app *linkableRingSignature) String() string {
+	js, jsErr := cdc.MarshalJSON(app)
+	if jsErr != nil {
+		panic(jsErr)
+	}
+
+	return base64.StdEncoding.EncodeToString(js)
+}
+
+// MarshalJSON returns a JSON representation of the object
+func (app *linkableRingSignature) MarshalJSON() ([]byte, error) {
+	rings := []string{}
+	for _, oneRing := range app.ring {
+		rings = append(rings, oneRing.String())
+	}
+
+	s := []string{}
+	for _, oneS := range app.s {
+		s = append(s, oneS.String())
+	}
+
+	return cdc.MarshalJSON(jsonLinkableRingSignature{
+		RingAsStrings:    rings,
+		SAsStrings:       s,
+		CAsString:        app.c.String(),
+		KeyImageAsString: app.keyImage.String(),
+	})
+}
+
+// UnmarshalJSON returns an object based on the JSON data
+func (app *linkableRingSignature) UnmarshalJSON(data []byte) error {
+	ptr := new(jsonLinkableRingSignature)
+	err := cdc.UnmarshalJSON(data, ptr)
+	if err != nil {
+		return err
+	}
+
+	rings := []PublicKey{}
+	for _, oneRingAsString := range ptr.RingAsStrings {
+		p, pErr := fromStringToPoint(oneRingAsString)
+		if pErr != nil {
+			return pErr
+		}
+
+		rings = append(rings, createPublicKey(p))
+	}
+
+	ss := []kyber.Scalar{}
+	for _, oneS := range ptr.SAsStrings {
+		s, sErr := fromStringToScalar(oneS)
+		if sErr != nil {
+			return sErr
+		}
+
+		ss = append(ss, s)
+	}
+
+	if len(rings) != len(ss) {
+		str := fmt.Sprintf("the ring contains %d PublicKeys, but %d s values were provided", len(rings), len(ss))
+		return errors.New(str)
+	}
+
+	c, cErr := fromStringToScalar(ptr.CAsString)
+	if cErr != nil {
+		return cErr
+	}
+
+	keyImage, keyImageErr := fromStringToPoint(ptr.KeyImageAsString)
+	if keyImageErr != nil {
+		return keyImageErr
+	}
+
+	app.ring = rings
+	app.s = ss
+	app.c = c
+	app.keyImage = keyImage
+	return nil
+}
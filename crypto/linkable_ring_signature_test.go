@@ -0,0 +1,169 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/xmnservices/xmnsuite/tests"
+)
+
+func TestLinkableRingSignature_Success(t *testing.T) {
+	// variables:
+	msg := "this is a message to sign"
+	pk := createPrivateKey()
+	secondPK := createPrivateKey()
+	thirdPK := createPrivateKey()
+	ringPubKeys := []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+		thirdPK.PublicKey(),
+	}
+
+	firstRing, firstRingErr := pk.LinkableRingSign(msg, ringPubKeys)
+	if firstRingErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", firstRingErr.Error())
+		return
+	}
+
+	secondRing, secondRingErr := secondPK.LinkableRingSign(msg, ringPubKeys)
+	if secondRingErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", secondRingErr.Error())
+		return
+	}
+
+	if !firstRing.Verify(msg) {
+		t.Errorf("the first ring was expected to be verified")
+		return
+	}
+
+	if !secondRing.Verify(msg) {
+		t.Errorf("the second ring was expected to be verified")
+		return
+	}
+
+	if firstRing.Verify("this is another message") {
+		t.Errorf("the first ring was expected to NOT be verified on another message")
+		return
+	}
+
+	if firstRing.IsLinked(secondRing) {
+		t.Errorf("the signatures of different PrivateKeys were expected to NOT be linked")
+		return
+	}
+
+	// encode to string, back and forth:
+	firstRingAsString := firstRing.String()
+	newRing, newRingErr := createLinkableRingSignatureFromString(firstRingAsString)
+	if newRingErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", newRingErr.Error())
+		return
+	}
+
+	if firstRingAsString != newRing.String() {
+		t.Errorf("the rings were expected to be the same.  Expected: %s, Actual: %s", firstRingAsString, newRing.String())
+		return
+	}
+
+	if !newRing.Verify(msg) {
+		t.Errorf("the decoded ring was expected to be verified")
+		return
+	}
+
+	// convert to json back and forth:
+	empty := new(linkableRingSignature)
+	tests.ConvertToJSON(t, firstRing, empty, cdc)
+}
+
+func TestLinkableRingSignature_sameSigner_isLinked(t *testing.T) {
+	// variables:
+	pk := createPrivateKey()
+	secondPK := createPrivateKey()
+	thirdPK := createPrivateKey()
+
+	firstRing, firstRingErr := pk.LinkableRingSign("this is a message to sign", []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+	})
+
+	if firstRingErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", firstRingErr.Error())
+		return
+	}
+
+	secondRing, secondRingErr := pk.LinkableRingSign("this is another message to sign", []PublicKey{
+		thirdPK.PublicKey(),
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+	})
+
+	if secondRingErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", secondRingErr.Error())
+		return
+	}
+
+	if !firstRing.IsLinked(secondRing) {
+		t.Errorf("the signatures of the same PrivateKey were expected to be linked, even on different messages and rings")
+		return
+	}
+}
+
+func TestLinkableRingSignature_PubKeyIsNotInTheRing_returnsError(t *testing.T) {
+	// variables:
+	msg := "this is a message to sign"
+	pk := createPrivateKey()
+	secondPK := createPrivateKey()
+	invalidPK := createPrivateKey()
+	ringPubKeys := []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+	}
+
+	_, err := invalidPK.LinkableRingSign(msg, ringPubKeys)
+	if err == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestLinkableRingSignature_signBytes_Success(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/tests/linkable"
+	msg := []byte("this is a message to sign")
+	pk := createPrivateKey()
+	secondPK := createPrivateKey()
+	ringPubKeys := []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+	}
+
+	ring, ringErr := pk.LinkableRingSignBytes(domain, msg, ringPubKeys)
+	if ringErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", ringErr.Error())
+		return
+	}
+
+	if !ring.VerifyBytes(domain, msg) {
+		t.Errorf("the ring was expected to be verified in its domain")
+		return
+	}
+
+	if ring.VerifyBytes("xmnsuite/tests/another", msg) {
+		t.Errorf("the ring was expected to NOT be verified in another domain")
+		return
+	}
+
+	if ring.Verify(string(msg)) {
+		t.Errorf("the ring was expected to NOT be verified without domain")
+		return
+	}
+
+	legacyRing, legacyRingErr := pk.LinkableRingSign(string(msg), ringPubKeys)
+	if legacyRingErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", legacyRingErr.Error())
+		return
+	}
+
+	if !ring.IsLinked(legacyRing) {
+		t.Errorf("the signatures of the same PrivateKey were expected to be linked, with or without domain")
+		return
+	}
+}
@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+// the key of the hmac used to derive the master key from a seed:
+const masterKeyHMACKey = "xmnsuite seed"
+
+// the offset added to the indexes of hardened derivations:
+const hardenedOffset = uint32(0x80000000)
+
+/*
+ * Mnemonic
+ */
+
+func generateMnemonic(bitSize int) (string, error) {
+	entropy, entropyErr := bip39.NewEntropy(bitSize)
+	if entropyErr != nil {
+		return "", entropyErr
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+func createSeedFromMnemonic(mnemonic string, passphrase string) ([]byte, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("the mnemonic is invalid: either a word is not in the wordlist or its checksum is invalid")
+	}
+
+	return bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+}
+
+func createPrivateKeyFromMnemonic(mnemonic string, passphrase string, path string) (PrivateKey, error) {
+	seed, seedErr := createSeedFromMnemonic(mnemonic, passphrase)
+	if seedErr != nil {
+		return nil, seedErr
+	}
+
+	key, keyErr := createMasterKey(seed).derivePath(path)
+	if keyErr != nil {
+		return nil, keyErr
+	}
+
+	return key.privateKey(), nil
+}
+
+/*
+ * Extended key
+ *
+ * Only hardened derivations are supported, since the public keys of the curve
+ * cannot be used to derive child public keys without the private key.
+ */
+
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+func createMasterKey(seed []byte) *extendedKey {
+	mac := hmac.New(sha512.New, []byte(masterKeyHMACKey))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	out := extendedKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+	}
+
+	return &out
+}
+
+func (app *extendedKey) derive(index uint32) *extendedKey {
+	indexAsBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexAsBytes, index+hardenedOffset)
+
+	mac := hmac.New(sha512.New, app.chainCode)
+	mac.Write([]byte{0})
+	mac.Write(app.key)
+	mac.Write(indexAsBytes)
+	sum := mac.Sum(nil)
+
+	out := extendedKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+	}
+
+	return &out
+}
+
+func (app *extendedKey) derivePath(path string) (*extendedKey, error) {
+	sections := strings.Split(path, "/")
+	if sections[0] != "m" {
+		str := fmt.Sprintf("the derivation path (%s) must begin with m", path)
+		return nil, errors.New(str)
+	}
+
+	out := app
+	for _, oneSection := range sections[1:] {
+		if !strings.HasSuffix(oneSection, "'") {
+			str := fmt.Sprintf("the derivation path (%s) contains a non-hardened index, which is not supported: %s", path, oneSection)
+			return nil, errors.New(str)
+		}
+
+		index, indexErr := strconv.ParseUint(strings.TrimSuffix(oneSection, "'"), 10, 32)
+		if indexErr != nil || uint32(index) >= hardenedOffset {
+			str := fmt.Sprintf("the derivation path (%s) contains an invalid index: %s", path, oneSection)
+			return nil, errors.New(str)
+		}
+
+		out = out.derive(uint32(index))
+	}
+
+	return out, nil
+}
+
+func (app *extendedKey) privateKey() PrivateKey {
+	out := privateKey{
+		x: curve.Scalar().SetBytes(app.key),
+	}
+
+	return &out
+}
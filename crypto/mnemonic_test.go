@@ -0,0 +1,95 @@
+package crypto
+
+import (
+	"testing"
+)
+
+func TestMnemonic_derive_isDeterministic_Success(t *testing.T) {
+	// variables:
+	passphrase := "this is a passphrase"
+	mnemonic, mnemonicErr := generateMnemonic(256)
+	if mnemonicErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", mnemonicErr.Error())
+		return
+	}
+
+	walletPK, walletPKErr := createPrivateKeyFromMnemonic(mnemonic, passphrase, WalletKeyPath)
+	if walletPKErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", walletPKErr.Error())
+		return
+	}
+
+	restoredWalletPK, restoredWalletPKErr := createPrivateKeyFromMnemonic(mnemonic, passphrase, WalletKeyPath)
+	if restoredWalletPKErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", restoredWalletPKErr.Error())
+		return
+	}
+
+	if walletPK.String() != restoredWalletPK.String() {
+		t.Errorf("the restored private key was expected to be the same as the derived private key")
+		return
+	}
+
+	nodePK, nodePKErr := createPrivateKeyFromMnemonic(mnemonic, passphrase, NodeKeyPath)
+	if nodePKErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", nodePKErr.Error())
+		return
+	}
+
+	if walletPK.String() == nodePK.String() {
+		t.Errorf("the private keys derived on different paths were expected to be different")
+		return
+	}
+
+	otherPK, otherPKErr := createPrivateKeyFromMnemonic(mnemonic, "this is another passphrase", WalletKeyPath)
+	if otherPKErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", otherPKErr.Error())
+		return
+	}
+
+	if walletPK.String() == otherPK.String() {
+		t.Errorf("the private keys derived with different passphrases were expected to be different")
+		return
+	}
+
+	// the derived key must be able to sign:
+	msg := "this is a message to sign"
+	sig := walletPK.Sign(msg)
+	if !sig.PublicKey(msg).Equals(walletPK.PublicKey()) {
+		t.Errorf("the signature was expected to be signed by the derived private key")
+		return
+	}
+}
+
+func TestMnemonic_withInvalidMnemonic_returnsError(t *testing.T) {
+	_, pkErr := createPrivateKeyFromMnemonic("this is not a valid mnemonic", "", WalletKeyPath)
+	if pkErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestMnemonic_withInvalidPath_returnsError(t *testing.T) {
+	mnemonic, mnemonicErr := generateMnemonic(128)
+	if mnemonicErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", mnemonicErr.Error())
+		return
+	}
+
+	invalidPaths := []string{
+		"",
+		"44'/0'",
+		"m/a'",
+		"m/2147483648'",
+		"m/44'/0'/0",
+		"m/44/0'/0'",
+	}
+
+	for _, onePath := range invalidPaths {
+		_, pkErr := createPrivateKeyFromMnemonic(mnemonic, "", onePath)
+		if pkErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (path: %s)", onePath)
+			return
+		}
+	}
+}
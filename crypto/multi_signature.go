@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+/*
+ * A MultiSignature is a k-of-n multi-signature: every co-signer signs the same message,
+ * in the same domain, off-chain, then the signatures are aggregated in a single MultiSignature that is
+ * verified against the n PublicKeys allowed to sign.
+ */
+
+type multiSignature struct {
+	sigs []Signature
+}
+
+type jsonMultiSignature struct {
+	SigsAsStrings []string `json:"sigs"`
+}
+
+func createMultiSignature(sigs []Signature) (MultiSignature, error) {
+	if len(sigs) <= 0 {
+		return nil, errors.New("the multi-signature must contain at least 1 signature")
+	}
+
+	out := multiSignature{
+		sigs: sigs,
+	}
+
+	return &out, nil
+}
+
+func createMultiSignatureFromString(str string) (MultiSignature, error) {
+	decoded, decodedErr := base64.StdEncoding.DecodeString(str)
+	if decodedErr != nil {
+		return nil, decodedErr
+	}
+
+	ptr := new(multiSignature)
+	err := cdc.UnmarshalJSON(decoded, ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ptr, nil
+}
+
+// Signatures returns the signatures
+func (app *multiSignature) Signatures() []Signature {
+	return app.sigs
+}
+
+// Add returns a new MultiSignature containing the current signatures and the given signature
+func (app *multiSignature) Add(sig Signature) MultiSignature {
+	sigs := []Signature{}
+	sigs = append(sigs, app.sigs...)
+	sigs = append(sigs, sig)
+
+	out := multiSignature{
+		sigs: sigs,
+	}
+
+	return &out
+}
+
+// Signers returns the PublicKeys, among the given PublicKeys, that signed the message in the domain, in the order of the signatures
+func (app *multiSignature) Signers(domain string, msg []byte, pubKeys []PublicKey) ([]PublicKey, error) {
+	out := []PublicKey{}
+	for index, oneSig := range app.sigs {
+		var signer PublicKey
+		for _, onePubKey := range pubKeys {
+			if oneSig.VerifyBytes(domain, msg, onePubKey) {
+				signer = onePubKey
+				break
+			}
+		}
+
+		if signer == nil {
+			str := fmt.Sprintf("the signature (index: %d) cannot be verified on the given message by any of the given PublicKeys", index)
+			return nil, errors.New(str)
+		}
+
+		for _, oneSigner := range out {
+			if oneSigner.Equals(signer) {
+				str := fmt.Sprintf("the PublicKey (%s) signed the message more than once", signer.String())
+				return nil, errors.New(str)
+			}
+		}
+
+		out = append(out, signer)
+	}
+
+	return out, nil
+}
+
+// Verify returns true if at least threshold of the given PublicKeys, and none other, signed the message in the domain
+func (app *multiSignature) Verify(domain string, msg []byte, pubKeys []PublicKey, threshold int) bool {
+	signers, signersErr := app.Signers(domain, msg, pubKeys)
+	if signersErr != nil {
+		return false
+	}
+
+	return len(signers) >= threshold
+}
+
+// String returns the string representation of the multi-signature
+func (app *multiSignature) String() string {
+	js, jsErr := cdc.MarshalJSON(app)
+	if jsErr != nil {
+		panic(jsErr)
+	}
+
+	return base64.StdEncoding.EncodeToString(js)
+}
+
+// MarshalJSON returns a JSON representation of the object
+func (app *multiSignature) MarshalJSON() ([]byte, error) {
+	sigs := []string{}
+	for _, oneSig := range app.sigs {
+		sigs = append(sigs, oneSig.String())
+	}
+
+	return cdc.MarshalJSON(jsonMultiSignature{
+		SigsAsStrings: sigs,
+	})
+}
+
+// UnmarshalJSON returns an object based on the JSON data
+func (app *multiSignature) UnmarshalJSON(data []byte) error {
+	ptr := new(jsonMultiSignature)
+	err := cdc.UnmarshalJSON(data, ptr)
+	if err != nil {
+		return err
+	}
+
+	if len(ptr.SigsAsStrings) <= 0 {
+		return errors.New("the multi-signature must contain at least 1 signature")
+	}
+
+	sigs := []Signature{}
+	for _, oneSigAsString := range ptr.SigsAsStrings {
+		sig, sigErr := createSignatureFromString(oneSigAsString)
+		if sigErr != nil {
+			return sigErr
+		}
+
+		sigs = append(sigs, sig)
+	}
+
+	app.sigs = sigs
+	return nil
+}
@@ -0,0 +1,161 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/xmnservices/xmnsuite/tests"
+)
+
+func TestMultiSignature_Success(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/crypto/multisig/test"
+	msg := []byte("this is a message to sign")
+	firstPK := createPrivateKey()
+	secondPK := createPrivateKey()
+	thirdPK := createPrivateKey()
+	pubKeys := []PublicKey{
+		firstPK.PublicKey(),
+		secondPK.PublicKey(),
+		thirdPK.PublicKey(),
+	}
+
+	// aggregate 2 signatures:
+	multiSig, multiSigErr := createMultiSignature([]Signature{
+		firstPK.SignBytes(domain, msg),
+	})
+
+	if multiSigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", multiSigErr.Error())
+		return
+	}
+
+	multiSig = multiSig.Add(thirdPK.SignBytes(domain, msg))
+
+	if !multiSig.Verify(domain, msg, pubKeys, 2) {
+		t.Errorf("the multi-signature was expected to be verified with a threshold of 2")
+		return
+	}
+
+	if multiSig.Verify(domain, msg, pubKeys, 3) {
+		t.Errorf("the multi-signature was expected to NOT be verified with a threshold of 3")
+		return
+	}
+
+	if multiSig.Verify(domain, []byte("this is another message"), pubKeys, 2) {
+		t.Errorf("the multi-signature was expected to NOT be verified on another message")
+		return
+	}
+
+	signers, signersErr := multiSig.Signers(domain, msg, pubKeys)
+	if signersErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", signersErr.Error())
+		return
+	}
+
+	if len(signers) != 2 || !signers[0].Equals(firstPK.PublicKey()) || !signers[1].Equals(thirdPK.PublicKey()) {
+		t.Errorf("the signers were expected to be the first and third PublicKeys")
+		return
+	}
+
+	// encode to string, back and forth:
+	multiSigAsString := multiSig.String()
+	newMultiSig, newMultiSigErr := createMultiSignatureFromString(multiSigAsString)
+	if newMultiSigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", newMultiSigErr.Error())
+		return
+	}
+
+	if multiSigAsString != newMultiSig.String() {
+		t.Errorf("the multi-signatures were expected to be the same.  Expected: %s, Actual: %s", multiSigAsString, newMultiSig.String())
+		return
+	}
+
+	// convert to json back and forth:
+	empty := new(multiSignature)
+	tests.ConvertToJSON(t, multiSig, empty, cdc)
+}
+
+func TestMultiSignature_signerNotAllowed_isNotVerified(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/crypto/multisig/test"
+	msg := []byte("this is a message to sign")
+	firstPK := createPrivateKey()
+	secondPK := createPrivateKey()
+	outsiderPK := createPrivateKey()
+	pubKeys := []PublicKey{
+		firstPK.PublicKey(),
+		secondPK.PublicKey(),
+	}
+
+	multiSig, multiSigErr := createMultiSignature([]Signature{
+		firstPK.SignBytes(domain, msg),
+		outsiderPK.SignBytes(domain, msg),
+	})
+
+	if multiSigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", multiSigErr.Error())
+		return
+	}
+
+	if multiSig.Verify(domain, msg, pubKeys, 1) {
+		t.Errorf("the multi-signature was expected to NOT be verified, since it contains the signature of a PublicKey that is not allowed")
+		return
+	}
+}
+
+func TestMultiSignature_signedInAnotherDomain_isNotVerified(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/crypto/multisig/test"
+	msg := []byte("this is a message to sign")
+	pk := createPrivateKey()
+
+	multiSig, multiSigErr := createMultiSignature([]Signature{
+		pk.SignBytes("xmnsuite/crypto/multisig/another", msg),
+	})
+
+	if multiSigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", multiSigErr.Error())
+		return
+	}
+
+	if multiSig.Verify(domain, msg, []PublicKey{pk.PublicKey()}, 1) {
+		t.Errorf("the multi-signature was expected to NOT be verified, since it was signed in another domain")
+		return
+	}
+}
+
+func TestMultiSignature_signedTwice_returnsError(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/crypto/multisig/test"
+	msg := []byte("this is a message to sign")
+	pk := createPrivateKey()
+
+	multiSig, multiSigErr := createMultiSignature([]Signature{
+		pk.SignBytes(domain, msg),
+		pk.SignBytes(domain, msg),
+	})
+
+	if multiSigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", multiSigErr.Error())
+		return
+	}
+
+	_, signersErr := multiSig.Signers(domain, msg, []PublicKey{pk.PublicKey()})
+	if signersErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	if multiSig.Verify(domain, msg, []PublicKey{pk.PublicKey()}, 2) {
+		t.Errorf("the multi-signature was expected to NOT be verified, since the same PublicKey signed twice")
+		return
+	}
+}
+
+func TestMultiSignature_withoutSignature_returnsError(t *testing.T) {
+	_, multiSigErr := createMultiSignature([]Signature{})
+	if multiSigErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -1,9 +1,6 @@
 package crypto
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/dedis/kyber"
 )
 
@@ -25,16 +22,30 @@ func createPrivateKey() PrivateKey {
 }
 
 func createPrivateKeyFromString(str string) (PrivateKey, error) {
+	return createPrivateKeyFromStringWithDecoder(str, decodeString)
+}
+
+func createPrivateKeyFromInput(str string) (PrivateKey, error) {
+	return createPrivateKeyFromStringWithDecoder(str, decodeInputString)
+}
+
+func createPrivateKeyFromStringWithDecoder(str string, decode func(kind string, str string) ([]byte, error)) (PrivateKey, error) {
+	// the String() of a PrivateKey is parsed first, then the format is detected:
 	x, xErr := fromStringToScalar(str)
-	if xErr != nil {
-		return nil, xErr
+	if xErr == nil {
+		out := privateKey{
+			x: x,
+		}
+
+		return &out, nil
 	}
 
-	out := privateKey{
-		x: x,
+	data, dataErr := decode(PKKind, str)
+	if dataErr != nil {
+		return nil, dataErr
 	}
 
-	return &out, nil
+	return fromBytesToPK(data)
 }
 
 // PublicKey returns the public key
@@ -46,58 +57,59 @@ func (app *privateKey) PublicKey() PublicKey {
 
 // RingSign signs a ring signature on the given message, in the given ring pubKey
 func (app *privateKey) RingSign(msg string, ringPubKeys []PublicKey) (RingSignature, error) {
-
-	retrieveSignerIndexFn := func(ringPubKeys []PublicKey, pk PrivateKey) int {
-		pubKey := pk.PublicKey()
-		for index, oneRingPubKey := range ringPubKeys {
-			if oneRingPubKey.Equals(pubKey) {
-				return index
-			}
-		}
-
-		return -1
+	challengeFn := func(p kyber.Point) kyber.Scalar {
+		return hash(msg + p.String())
 	}
 
-	// retrieve our signerIndex:
-	signerIndex := retrieveSignerIndexFn(ringPubKeys, app)
-	if signerIndex == -1 {
-		return nil, errors.New("the signer PublicKey is not in the ring")
+	nonceFn := func(suffix string) kyber.Scalar {
+		return genK(app.x, msg+suffix)
 	}
 
-	// generate k:
-	k := genK(app.x, msg)
+	return ringSign(app.x, ringPubKeys, challengeFn, nonceFn)
+}
 
-	// random base:
-	g := curve.Point().Base()
+// RingSignBytes signs a ring signature on the given message bytes, in the given domain and ring pubKey
+func (app *privateKey) RingSignBytes(domain string, msg []byte, ringPubKeys []PublicKey) (RingSignature, error) {
+	challengeFn := func(p kyber.Point) kyber.Scalar {
+		return hashWithDomain(domain, msg, p)
+	}
 
-	// length:
-	r := len(ringPubKeys)
+	nonceFn := func(suffix string) kyber.Scalar {
+		return genDomainK(app.x, domain, msg, suffix)
+	}
 
-	// initialize:
-	es := make([]kyber.Scalar, r)
-	ss := make([]kyber.Scalar, r)
-	beginIndex := (signerIndex + 1) % r
+	return ringSign(app.x, ringPubKeys, challengeFn, nonceFn)
+}
 
-	// ei = H(m || k * G)
-	es[beginIndex] = hash(msg + curve.Point().Mul(k, g).String())
+// LinkableRingSign signs a linkable ring signature on the given message, in the given ring pubKey
+func (app *privateKey) LinkableRingSign(msg string, ringPubKeys []PublicKey) (LinkableRingSignature, error) {
+	challengeFn := func(keyImage kyber.Point, l kyber.Point, r kyber.Point) kyber.Scalar {
+		return hash(linkableRingPrefix(msg, ringPubKeys, keyImage) + l.String() + r.String())
+	}
 
-	// loop:
-	for i := beginIndex; i != signerIndex; i = (i + 1) % r {
-		// si = random value
-		ss[i] = genK(app.x, fmt.Sprintf("%s%d", msg, i))
+	nonceFn := func(keyImage kyber.Point, suffix string) kyber.Scalar {
+		return genK(app.x, linkableRingPrefix(msg, ringPubKeys, keyImage)+suffix)
+	}
 
-		//eiPlus1ModR = H(m || si * G + ei * Pi)
-		sig := curve.Point().Mul(ss[i], g)
-		eipi := curve.Point().Mul(es[i], ringPubKeys[i].Point())
-		es[(i+1)%r] = hash(msg + curve.Point().Add(sig, eipi).String())
+	return linkableRingSign(app.x, ringPubKeys, challengeFn, nonceFn)
+}
+
+// LinkableRingSignBytes signs a linkable ring signature on the given message bytes, in the given domain and ring pubKey
+func (app *privateKey) LinkableRingSignBytes(domain string, msg []byte, ringPubKeys []PublicKey) (LinkableRingSignature, error) {
+	challengeFn := func(keyImage kyber.Point, l kyber.Point, r kyber.Point) kyber.Scalar {
+		return hashWithDomain(domain, []byte(linkableRingPrefix(string(msg), ringPubKeys, keyImage)), l, r)
+	}
 
+	nonceFn := func(keyImage kyber.Point, suffix string) kyber.Scalar {
+		return genDomainK(app.x, domain, []byte(linkableRingPrefix(string(msg), ringPubKeys, keyImage)), suffix)
 	}
 
-	// close the ring:
-	esx := curve.Scalar().Mul(es[signerIndex], app.x)
-	ss[signerIndex] = curve.Scalar().Sub(k, esx)
-	out := createRingSignature(ringPubKeys, ss, es[0])
-	return out, nil
+	return linkableRingSign(app.x, ringPubKeys, challengeFn, nonceFn)
+}
+
+// Decrypt decrypts a message encrypted to the PublicKey of the PrivateKey
+func (app *privateKey) Decrypt(encryptedText string) ([]byte, error) {
+	return decryptFrom(app.x, encryptedText)
 }
 
 // Sign signs a message
@@ -123,6 +135,22 @@ func (app *privateKey) Sign(msg string) Signature {
 	return out
 }
 
+// SignBytes signs the given message bytes, in the given domain
+func (app *privateKey) SignBytes(domain string, msg []byte) Signature {
+	// generate k:
+	k := genDomainK(app.x, domain, msg, "")
+
+	// r = k * G
+	r := curve.Point().Mul(k, curve.Point().Base())
+
+	// Hd(d, m, r)
+	e := hashWithDomain(domain, msg, r)
+
+	// s = k - e * x
+	s := curve.Scalar().Sub(k, curve.Scalar().Mul(e, app.x))
+	return createSignature(createPublicKey(r), s)
+}
+
 // String returns the string representation of the PrivateKey
 func (app *privateKey) String() string {
 	return app.x.String()
@@ -21,12 +21,26 @@ func createPublicKey(p kyber.Point) PublicKey {
 }
 
 func createPublicKeyFromString(str string) (PublicKey, error) {
+	return createPublicKeyFromStringWithDecoder(str, decodeString)
+}
+
+func createPublicKeyFromInput(str string) (PublicKey, error) {
+	return createPublicKeyFromStringWithDecoder(str, decodeInputString)
+}
+
+func createPublicKeyFromStringWithDecoder(str string, decode func(kind string, str string) ([]byte, error)) (PublicKey, error) {
+	// the String() of a PublicKey is parsed first, then the format is detected:
 	p, pErr := fromStringToPoint(str)
-	if pErr != nil {
-		return nil, pErr
+	if pErr == nil {
+		return createPublicKey(p), nil
+	}
+
+	data, dataErr := decode(PubKeyKind, str)
+	if dataErr != nil {
+		return nil, dataErr
 	}
 
-	return createPublicKey(p), nil
+	return fromBytesToPubKey(data)
 }
 
 // Point returns the point
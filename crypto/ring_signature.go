@@ -2,6 +2,8 @@ package crypto
 
 import (
 	"encoding/base64"
+	"errors"
+	"fmt"
 
 	"github.com/dedis/kyber"
 )
@@ -28,6 +30,57 @@ func createRingSignature(ring []PublicKey, s []kyber.Scalar, e kyber.Scalar) Rin
 	return &out
 }
 
+func ringSign(x kyber.Scalar, ringPubKeys []PublicKey, challengeFn func(p kyber.Point) kyber.Scalar, nonceFn func(suffix string) kyber.Scalar) (RingSignature, error) {
+	// random base:
+	g := curve.Point().Base()
+
+	// retrieve our signerIndex:
+	pubKey := createPublicKey(curve.Point().Mul(x, g))
+	signerIndex := -1
+	for index, oneRingPubKey := range ringPubKeys {
+		if oneRingPubKey.Equals(pubKey) {
+			signerIndex = index
+			break
+		}
+	}
+
+	if signerIndex == -1 {
+		return nil, errors.New("the signer PublicKey is not in the ring")
+	}
+
+	// generate k:
+	k := nonceFn("")
+
+	// length:
+	r := len(ringPubKeys)
+
+	// initialize:
+	es := make([]kyber.Scalar, r)
+	ss := make([]kyber.Scalar, r)
+	beginIndex := (signerIndex + 1) % r
+
+	// ei = H(m || k * G)
+	es[beginIndex] = challengeFn(curve.Point().Mul(k, g))
+
+	// loop:
+	for i := beginIndex; i != signerIndex; i = (i + 1) % r {
+		// si = random value
+		ss[i] = nonceFn(fmt.Sprintf("%d", i))
+
+		//eiPlus1ModR = H(m || si * G + ei * Pi)
+		sig := curve.Point().Mul(ss[i], g)
+		eipi := curve.Point().Mul(es[i], ringPubKeys[i].Point())
+		es[(i+1)%r] = challengeFn(curve.Point().Add(sig, eipi))
+
+	}
+
+	// close the ring:
+	esx := curve.Scalar().Mul(es[signerIndex], x)
+	ss[signerIndex] = curve.Scalar().Sub(k, esx)
+	out := createRingSignature(ringPubKeys, ss, es[0])
+	return out, nil
+}
+
 func createRingSignatureFromString(str string) (RingSignature, error) {
 	decoded, decodedErr := base64.StdEncoding.DecodeString(str)
 	if decodedErr != nil {
@@ -45,6 +98,19 @@ func createRingSignatureFromString(str string) (RingSignature, error) {
 
 // Verify verifies if the message has been signed by at least 1 shared signature
 func (app *ringSignature) Verify(msg string) bool {
+	return app.verify(func(p kyber.Point) kyber.Scalar {
+		return hash(msg + p.String())
+	})
+}
+
+// VerifyBytes verifies if the message bytes have been signed, in the given domain, by at least 1 shared signature
+func (app *ringSignature) VerifyBytes(domain string, msg []byte) bool {
+	return app.verify(func(p kyber.Point) kyber.Scalar {
+		return hashWithDomain(domain, msg, p)
+	})
+}
+
+func (app *ringSignature) verify(challengeFn func(p kyber.Point) kyber.Scalar) bool {
 	// random base:
 	g := curve.Point().Base()
 
@@ -53,11 +119,15 @@ func (app *ringSignature) Verify(msg string) bool {
 
 	//e = H(m || s[i] * G + e * P[i]);
 	amount := len(app.ring)
+	if amount != len(app.s) {
+		return false
+	}
+
 	for i := 0; i < amount; i++ {
 		sg := curve.Point().Mul(app.s[i], g)
 		ep := curve.Point().Mul(e, app.ring[i].Point())
 		added := curve.Point().Add(sg, ep)
-		e = hash(msg + added.String())
+		e = challengeFn(added)
 	}
 
 	return app.e.Equal(e)
@@ -73,3 +73,36 @@ func TestRingSignature_PubKeyIsNotInTheRing_returnsError(t *testing.T) {
 		return
 	}
 }
+
+func TestRingSignature_signBytes_Success(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/tests"
+	msg := []byte("this is a message to sign")
+	pk := createPrivateKey()
+	secondPK := createPrivateKey()
+	ringPubKeys := []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+	}
+
+	ring, ringErr := secondPK.RingSignBytes(domain, msg, ringPubKeys)
+	if ringErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", ringErr.Error())
+		return
+	}
+
+	if !ring.VerifyBytes(domain, msg) {
+		t.Errorf("the ring was expected to be verified")
+		return
+	}
+
+	if ring.VerifyBytes("xmnsuite/tests/another", msg) {
+		t.Errorf("the ring was expected to NOT be verified in another domain")
+		return
+	}
+
+	if ring.Verify(string(msg)) {
+		t.Errorf("the ring was expected to NOT be verified on the string message")
+		return
+	}
+}
@@ -1,17 +1,70 @@
 package crypto
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/dedis/kyber"
 )
 
+const (
+	// WalletKeyPath represents the derivation path of the wallet key
+	WalletKeyPath = "m/44'/0'/0'"
+
+	// NodeKeyPath represents the derivation path of the node key
+	NodeKeyPath = "m/44'/0'/1'"
+
+	// AppKeysPath represents the derivation path under which the per-application keys are derived
+	AppKeysPath = "m/44'/1'"
+)
+
+const (
+	// HexEncoding represents the hex encoding, which is also the encoding of the String() of the keys
+	HexEncoding = "hex"
+
+	// Base58Encoding represents the base58check encoding, prefixed by a version byte per kind
+	Base58Encoding = "base58"
+
+	// PEMEncoding represents the PEM encoding
+	PEMEncoding = "pem"
+
+	// JSONLDEncoding represents the JSON-LD encoding
+	JSONLDEncoding = "jsonld"
+)
+
+const (
+	// PubKeyKind represents the kind of an encoded PublicKey
+	PubKeyKind = "pubkey"
+
+	// PKKind represents the kind of an encoded PrivateKey
+	PKKind = "pk"
+
+	// SigKind represents the kind of an encoded Signature
+	SigKind = "sig"
+
+	// AddressKind represents the kind of an address
+	AddressKind = "address"
+)
+
 // PrivateKey represents a private key
 type PrivateKey interface {
 	PublicKey() PublicKey
 	Sign(msg string) Signature
+	SignBytes(domain string, msg []byte) Signature
 	RingSign(msg string, ringPubKeys []PublicKey) (RingSignature, error)
+	RingSignBytes(domain string, msg []byte, ringPubKeys []PublicKey) (RingSignature, error)
+	LinkableRingSign(msg string, ringPubKeys []PublicKey) (LinkableRingSignature, error)
+	LinkableRingSignBytes(domain string, msg []byte, ringPubKeys []PublicKey) (LinkableRingSignature, error)
+	Decrypt(encryptedText string) ([]byte, error)
 	String() string
 }
 
+// Signer represents a signer whose PrivateKey may live in another process
+type Signer interface {
+	PublicKey() PublicKey
+	Sign(domain string, msg []byte) (Signature, error)
+}
+
 // PublicKey represents the public key
 type PublicKey interface {
 	Point() kyber.Point
@@ -23,20 +76,89 @@ type PublicKey interface {
 type Signature interface {
 	PublicKey(msg string) PublicKey
 	Verify(msg string) bool
+	VerifyBytes(domain string, msg []byte, pubKey PublicKey) bool
 	String() string
 }
 
 // RingSignature represents a RingSignature
 type RingSignature interface {
 	Verify(msg string) bool
+	VerifyBytes(domain string, msg []byte) bool
+	String() string
+}
+
+// LinkableRingSignature represents a RingSignature whose signatures made by the same PrivateKey can be linked
+type LinkableRingSignature interface {
+	Ring() []PublicKey
+	KeyImage() string
+	IsLinked(sig LinkableRingSignature) bool
+	Verify(msg string) bool
+	VerifyBytes(domain string, msg []byte) bool
 	String() string
 }
 
+// MultiSignature represents a k-of-n multi-signature
+type MultiSignature interface {
+	Signatures() []Signature
+	Add(sig Signature) MultiSignature
+	Signers(domain string, msg []byte, pubKeys []PublicKey) ([]PublicKey, error)
+	Verify(domain string, msg []byte, pubKeys []PublicKey, threshold int) bool
+	String() string
+}
+
+// Encoding represents an encoding of the binary representation of the keys and signatures
+type Encoding interface {
+	Name() string
+	Encode(kind string, data []byte) (string, error)
+	Decode(kind string, str string) ([]byte, error)
+	Detect(str string) bool
+}
+
 // CreatePKParams represents the CreatePK func params
 type CreatePKParams struct {
 	PKAsString string
 }
 
+// RegisterEncodingParams represents the RegisterEncoding params.  The registered encodings are only detected by the
+// ParsePubKey, ParsePK and ParseSig funcs, which parse the inputs of the users, never by the Create funcs used by the consensus
+type RegisterEncodingParams struct {
+	Encoding Encoding
+}
+
+// EncodePubKeyParams represents the EncodePubKey params
+type EncodePubKeyParams struct {
+	PubKey   PublicKey
+	Encoding string
+}
+
+// EncodePKParams represents the EncodePK params
+type EncodePKParams struct {
+	PK       PrivateKey
+	Encoding string
+}
+
+// EncodeSigParams represents the EncodeSig params
+type EncodeSigParams struct {
+	Sig      Signature
+	Encoding string
+}
+
+// CreateAddressParams represents the CreateAddress params
+type CreateAddressParams struct {
+	PubKey PublicKey
+}
+
+// VerifyAddressParams represents the VerifyAddress params
+type VerifyAddressParams struct {
+	Address string
+	PubKey  PublicKey
+}
+
+// CreateLocalSignerParams represents the CreateLocalSigner func params
+type CreateLocalSignerParams struct {
+	PK PrivateKey
+}
+
 // CreatePubKeyParams represents the CreatePubKey func params
 type CreatePubKeyParams struct {
 	PubKeyAsString string
@@ -52,27 +174,93 @@ type CreateRingSigParams struct {
 	RingSigAsString string
 }
 
+// CreateLinkableRingSigParams represents the CreateLinkableRingSig params
+type CreateLinkableRingSigParams struct {
+	LinkableRingSigAsString string
+}
+
+// CreateMultiSigParams represents the CreateMultiSig params
+type CreateMultiSigParams struct {
+	Sigs             []Signature
+	MultiSigAsString string
+}
+
+// BatchVerifyParams represents the BatchVerify params.  The signature at an index is verified on the message and PublicKey at the same index
+type BatchVerifyParams struct {
+	Domain  string
+	Msgs    [][]byte
+	Sigs    []Signature
+	PubKeys []PublicKey
+}
+
 // EncryptParams represents the Encrypt params
 type EncryptParams struct {
 	Pass []byte
 	Msg  []byte
 }
 
+// EncryptToParams represents the EncryptTo params
+type EncryptToParams struct {
+	PubKey PublicKey
+	Msg    []byte
+}
+
 // DecryptParams represents the Decrypt params
 type DecryptParams struct {
 	Pass         []byte
 	EncryptedMsg string
 }
 
+// IsLegacyEncryptionParams represents the IsLegacyEncryption params
+type IsLegacyEncryptionParams struct {
+	EncryptedMsg string
+}
+
+// GenerateMnemonicParams represents the GenerateMnemonic params
+type GenerateMnemonicParams struct {
+	BitSize int
+}
+
+// CreatePKFromMnemonicParams represents the CreatePKFromMnemonic params
+type CreatePKFromMnemonicParams struct {
+	Mnemonic   string
+	Passphrase string
+	Path       string
+}
+
+// CreateAppKeyPathParams represents the CreateAppKeyPath params
+type CreateAppKeyPathParams struct {
+	Index int
+}
+
 // SDKFunc represents the crypto SDK func
 var SDKFunc = struct {
-	GenPK         func() PrivateKey
-	CreatePK      func(params CreatePKParams) PrivateKey
-	CreatePubKey  func(params CreatePubKeyParams) PublicKey
-	CreateSig     func(params CreateSigParams) Signature
-	CreateRingSig func(params CreateRingSigParams) RingSignature
-	Encrypt       func(params EncryptParams) string
-	Decrypt       func(params DecryptParams) []byte
+	GenPK                 func() PrivateKey
+	CreatePK              func(params CreatePKParams) PrivateKey
+	CreatePubKey          func(params CreatePubKeyParams) PublicKey
+	CreateLocalSigner     func(params CreateLocalSignerParams) Signer
+	CreateSig             func(params CreateSigParams) Signature
+	CreateRingSig         func(params CreateRingSigParams) RingSignature
+	CreateLinkableRingSig func(params CreateLinkableRingSigParams) LinkableRingSignature
+	CreateMultiSig        func(params CreateMultiSigParams) MultiSignature
+	BatchVerify           func(params BatchVerifyParams) bool
+	Encrypt               func(params EncryptParams) string
+	EncryptTo             func(params EncryptToParams) string
+	Decrypt               func(params DecryptParams) []byte
+	DecryptLegacy         func(params DecryptParams) []byte
+	IsLegacyEncryption    func(params IsLegacyEncryptionParams) bool
+	GenerateMnemonic      func(params GenerateMnemonicParams) string
+	CreatePKFromMnemonic  func(params CreatePKFromMnemonicParams) PrivateKey
+	CreateAppKeyPath      func(params CreateAppKeyPathParams) string
+	RegisterEncoding      func(params RegisterEncodingParams)
+	ParsePubKey           func(params CreatePubKeyParams) PublicKey
+	ParsePK               func(params CreatePKParams) PrivateKey
+	ParseSig              func(params CreateSigParams) Signature
+	EncodePubKey          func(params EncodePubKeyParams) string
+	EncodePK              func(params EncodePKParams) string
+	EncodeSig             func(params EncodeSigParams) string
+	CreateAddress         func(params CreateAddressParams) string
+	VerifyAddress         func(params VerifyAddressParams) bool
 }{
 	GenPK: func() PrivateKey {
 		return createPrivateKey()
@@ -100,6 +288,15 @@ var SDKFunc = struct {
 		return pubKey
 	},
 
+	CreateLocalSigner: func(params CreateLocalSignerParams) Signer {
+		out, outErr := createLocalSigner(params.PK)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+
 	CreateSig: func(params CreateSigParams) Signature {
 		sig, sigErr := createSignatureFromString(params.SigAsString)
 		if sigErr != nil {
@@ -116,6 +313,39 @@ var SDKFunc = struct {
 
 		return ringSig
 	},
+	CreateLinkableRingSig: func(params CreateLinkableRingSigParams) LinkableRingSignature {
+		ringSig, ringSigErr := createLinkableRingSignatureFromString(params.LinkableRingSigAsString)
+		if ringSigErr != nil {
+			panic(ringSigErr)
+		}
+
+		return ringSig
+	},
+	CreateMultiSig: func(params CreateMultiSigParams) MultiSignature {
+		if params.MultiSigAsString != "" {
+			multiSig, multiSigErr := createMultiSignatureFromString(params.MultiSigAsString)
+			if multiSigErr != nil {
+				panic(multiSigErr)
+			}
+
+			return multiSig
+		}
+
+		multiSig, multiSigErr := createMultiSignature(params.Sigs)
+		if multiSigErr != nil {
+			panic(multiSigErr)
+		}
+
+		return multiSig
+	},
+	BatchVerify: func(params BatchVerifyParams) bool {
+		out, outErr := batchVerify(params.Domain, params.Msgs, params.Sigs, params.PubKeys)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
 	Encrypt: func(params EncryptParams) string {
 		out, outErr := encrypt(params.Pass, params.Msg)
 		if outErr != nil {
@@ -124,12 +354,147 @@ var SDKFunc = struct {
 
 		return out
 	},
+	EncryptTo: func(params EncryptToParams) string {
+		out, outErr := encryptTo(params.PubKey, params.Msg)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
 	Decrypt: func(params DecryptParams) []byte {
 		out, outErr := decrypt(params.Pass, params.EncryptedMsg)
 		if outErr != nil {
 			panic(outErr)
 		}
 
+		return out
+	},
+	DecryptLegacy: func(params DecryptParams) []byte {
+		out, outErr := decryptLegacy(params.Pass, params.EncryptedMsg)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	IsLegacyEncryption: func(params IsLegacyEncryptionParams) bool {
+		return isLegacyEncryption(params.EncryptedMsg)
+	},
+	GenerateMnemonic: func(params GenerateMnemonicParams) string {
+		if params.BitSize == 0 {
+			params.BitSize = 256
+		}
+
+		out, outErr := generateMnemonic(params.BitSize)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreatePKFromMnemonic: func(params CreatePKFromMnemonicParams) PrivateKey {
+		if params.Path == "" {
+			params.Path = WalletKeyPath
+		}
+
+		out, outErr := createPrivateKeyFromMnemonic(params.Mnemonic, params.Passphrase, params.Path)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateAppKeyPath: func(params CreateAppKeyPathParams) string {
+		return fmt.Sprintf("%s/%d'", AppKeysPath, params.Index)
+	},
+	RegisterEncoding: func(params RegisterEncodingParams) {
+		if params.Encoding == nil {
+			panic(errors.New("the Encoding is mandatory in order to register an encoding"))
+		}
+
+		err := registerEncoding(params.Encoding)
+		if err != nil {
+			panic(err)
+		}
+	},
+	ParsePubKey: func(params CreatePubKeyParams) PublicKey {
+		pubKey, pubKeyErr := createPublicKeyFromInput(params.PubKeyAsString)
+		if pubKeyErr != nil {
+			panic(pubKeyErr)
+		}
+
+		return pubKey
+	},
+	ParsePK: func(params CreatePKParams) PrivateKey {
+		pk, pkErr := createPrivateKeyFromInput(params.PKAsString)
+		if pkErr != nil {
+			panic(pkErr)
+		}
+
+		return pk
+	},
+	ParseSig: func(params CreateSigParams) Signature {
+		sig, sigErr := createSignatureFromInput(params.SigAsString)
+		if sigErr != nil {
+			panic(sigErr)
+		}
+
+		return sig
+	},
+	EncodePubKey: func(params EncodePubKeyParams) string {
+		data, dataErr := fromPubKeyToBytes(params.PubKey)
+		if dataErr != nil {
+			panic(dataErr)
+		}
+
+		out, outErr := encodeToString(params.Encoding, PubKeyKind, data)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	EncodePK: func(params EncodePKParams) string {
+		data, dataErr := fromPKToBytes(params.PK)
+		if dataErr != nil {
+			panic(dataErr)
+		}
+
+		out, outErr := encodeToString(params.Encoding, PKKind, data)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	EncodeSig: func(params EncodeSigParams) string {
+		data, dataErr := fromSigToBytes(params.Sig)
+		if dataErr != nil {
+			panic(dataErr)
+		}
+
+		out, outErr := encodeToString(params.Encoding, SigKind, data)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateAddress: func(params CreateAddressParams) string {
+		out, outErr := createAddress(params.PubKey)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	VerifyAddress: func(params VerifyAddressParams) bool {
+		out, outErr := verifyAddress(params.Address, params.PubKey)
+		if outErr != nil {
+			panic(outErr)
+		}
+
 		return out
 	},
 }
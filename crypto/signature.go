@@ -22,18 +22,30 @@ func createSignature(r PublicKey, s kyber.Scalar) Signature {
 }
 
 func createSignatureFromString(sigAsString string) (Signature, error) {
+	return createSignatureFromStringWithDecoder(sigAsString, decodeString)
+}
+
+func createSignatureFromInput(sigAsString string) (Signature, error) {
+	return createSignatureFromStringWithDecoder(sigAsString, decodeInputString)
+}
+
+func createSignatureFromStringWithDecoder(sigAsString string, decode func(kind string, str string) ([]byte, error)) (Signature, error) {
+	// the String() of a Signature is parsed first, then the format is detected:
 	decoded, decodedErr := base64.StdEncoding.DecodeString(sigAsString)
-	if decodedErr != nil {
-		return nil, decodedErr
+	if decodedErr == nil {
+		ptr := new(signature)
+		err := cdc.UnmarshalJSON(decoded, ptr)
+		if err == nil {
+			return ptr, nil
+		}
 	}
 
-	ptr := new(signature)
-	err := cdc.UnmarshalJSON(decoded, ptr)
-	if err != nil {
-		return nil, err
+	data, dataErr := decode(SigKind, sigAsString)
+	if dataErr != nil {
+		return nil, dataErr
 	}
 
-	return ptr, nil
+	return fromBytesToSig(data)
 }
 
 // PublicKey returns the public key of the signature
@@ -73,6 +85,17 @@ func (app *signature) Verify(msg string) bool {
 	return sG.Equal(sGv)
 }
 
+// VerifyBytes verifies if the signature has been made by the given public key, on the message bytes, in the given domain
+func (app *signature) VerifyBytes(domain string, msg []byte, pubKey PublicKey) bool {
+	// e = Hd(d, m, r)
+	e := hashWithDomain(domain, msg, app.r.Point())
+
+	// s * G = r - e * p
+	sGv := curve.Point().Sub(app.r.Point(), curve.Point().Mul(e, pubKey.Point()))
+	sG := curve.Point().Mul(app.s, curve.Point().Base())
+	return sG.Equal(sGv)
+}
+
 // String returns the string representation of the signature
 func (app *signature) String() string {
 	js, jsErr := cdc.MarshalJSON(app)
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"fmt"
 	"testing"
 
 	tests "github.com/xmnservices/xmnsuite/tests"
@@ -47,3 +48,131 @@ func TestSignature_Success(t *testing.T) {
 	tests.ConvertToJSON(t, sig, empty, cdc)
 
 }
+
+func TestSignature_signBytes_Success(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/tests/first"
+	msg := []byte("this is a message to sign")
+	pk := createPrivateKey()
+
+	// create the signature:
+	sig := pk.SignBytes(domain, msg)
+
+	if !sig.VerifyBytes(domain, msg, pk.PublicKey()) {
+		t.Errorf("the signature was expected to be verified using this domain, message and PublicKey")
+		return
+	}
+
+	if sig.VerifyBytes("xmnsuite/tests/second", msg, pk.PublicKey()) {
+		t.Errorf("the signature was expected to NOT be verified in another domain")
+		return
+	}
+
+	if sig.VerifyBytes(domain, []byte("this is another message"), pk.PublicKey()) {
+		t.Errorf("the signature was expected to NOT be verified on another message")
+		return
+	}
+
+	if sig.VerifyBytes(domain, msg, createPrivateKey().PublicKey()) {
+		t.Errorf("the signature was expected to NOT be verified using another PublicKey")
+		return
+	}
+
+	// a signature on a string message is not valid on the same message bytes:
+	if pk.Sign(string(msg)).VerifyBytes(domain, msg, pk.PublicKey()) {
+		t.Errorf("the signature made on a string message was expected to NOT be verified on the message bytes")
+		return
+	}
+}
+
+func TestSignature_batchVerify_Success(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/tests"
+	msgs := [][]byte{}
+	sigs := []Signature{}
+	pubKeys := []PublicKey{}
+	for i := 0; i < 5; i++ {
+		pk := createPrivateKey()
+		msg := []byte(fmt.Sprintf("this is the message %d", i))
+		msgs = append(msgs, msg)
+		sigs = append(sigs, pk.SignBytes(domain, msg))
+		pubKeys = append(pubKeys, pk.PublicKey())
+	}
+
+	isValid, isValidErr := batchVerify(domain, msgs, sigs, pubKeys)
+	if isValidErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", isValidErr.Error())
+		return
+	}
+
+	if !isValid {
+		t.Errorf("the signatures were expected to be verified")
+		return
+	}
+
+	// swap 2 signatures:
+	sigs[0], sigs[1] = sigs[1], sigs[0]
+	isValid, isValidErr = batchVerify(domain, msgs, sigs, pubKeys)
+	if isValidErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", isValidErr.Error())
+		return
+	}
+
+	if isValid {
+		t.Errorf("the signatures were expected to NOT be verified")
+		return
+	}
+
+	// the amounts must match:
+	_, invalidErr := batchVerify(domain, msgs[1:], sigs, pubKeys)
+	if invalidErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+// createDomainMessage creates a string message that contains the domain, as the signatures of the previous versions did:
+func createDomainMessage(domain string, msg []byte) string {
+	return fmt.Sprintf("%d:%s%x", len(domain), domain, msg)
+}
+
+func TestSignature_signBytes_doesNotReuseNonce(t *testing.T) {
+	// variables:
+	domain := "xmnsuite/tests/first"
+	msg := []byte("this is a message to sign")
+	pk := createPrivateKey()
+
+	// sign the domain message as a string, then as bytes in the domain:
+	legacySig := pk.Sign(createDomainMessage(domain, msg)).(*signature)
+	sig := pk.SignBytes(domain, msg).(*signature)
+
+	if legacySig.r.Equals(sig.r) {
+		t.Errorf("the signatures were expected to use different nonces")
+		return
+	}
+
+	// the ring signatures do not reuse the nonce either:
+	ringPubKeys := []PublicKey{pk.PublicKey(), createPrivateKey().PublicKey()}
+	legacyRingSig, legacyRingSigErr := pk.RingSign(createDomainMessage(domain, msg), ringPubKeys)
+	if legacyRingSigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", legacyRingSigErr.Error())
+		return
+	}
+
+	ringSig, ringSigErr := pk.RingSignBytes(domain, msg, ringPubKeys)
+	if ringSigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", ringSigErr.Error())
+		return
+	}
+
+	// the signer is the first member, so the second number of the ring is a nonce:
+	if legacyRingSig.(*ringSignature).s[1].Equal(ringSig.(*ringSignature).s[1]) {
+		t.Errorf("the ring signatures were expected to use different nonces")
+		return
+	}
+
+	if !ringSig.VerifyBytes(domain, msg) {
+		t.Errorf("the ring signature was expected to be verified using this domain and message")
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package crypto
+
+import (
+	"errors"
+)
+
+type localSigner struct {
+	pk PrivateKey
+}
+
+func createLocalSigner(pk PrivateKey) (Signer, error) {
+	if pk == nil {
+		return nil, errors.New("the PrivateKey is mandatory in order to create a local Signer instance")
+	}
+
+	out := localSigner{
+		pk: pk,
+	}
+
+	return &out, nil
+}
+
+// PublicKey returns the PublicKey of the signer
+func (app *localSigner) PublicKey() PublicKey {
+	return app.pk.PublicKey()
+}
+
+// Sign signs the message, separated by the given domain
+func (app *localSigner) Sign(domain string, msg []byte) (Signature, error) {
+	return app.pk.SignBytes(domain, msg), nil
+}
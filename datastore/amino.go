@@ -0,0 +1,33 @@
+package datastore
+
+import (
+	amino "github.com/tendermint/go-amino"
+	"github.com/xmnservices/xmnsuite/datastore/keys"
+)
+
+var cdc = amino.NewCodec()
+
+const (
+
+	// XMNSuiteDataStoreProof represents the xmnsuite datastore Proof resource
+	XMNSuiteDataStoreProof = "xmnsuite/DataStore/Proof"
+)
+
+func init() {
+	Register(cdc)
+}
+
+// Register registers all the interface -> struct to amino
+func Register(codec *amino.Codec) {
+	// Dependencies
+	keys.Register(codec)
+
+	// Proof
+	func() {
+		defer func() {
+			recover()
+		}()
+		codec.RegisterInterface((*Proof)(nil), nil)
+		codec.RegisterConcrete(&proof{}, XMNSuiteDataStoreProof, nil)
+	}()
+}
@@ -22,4 +22,5 @@ func RegisterGob() {
 	users.RegisterGob()
 	roles.RegisterGob()
 	gob.Register(&concreteDataStore{})
+	gob.Register(&proof{})
 }
@@ -0,0 +1,27 @@
+package keys
+
+import (
+	amino "github.com/tendermint/go-amino"
+	"github.com/xmnservices/xmnsuite/hashtree"
+)
+
+const (
+
+	// XMNSuiteKeysProof represents the xmnsuite keys Proof resource
+	XMNSuiteKeysProof = "xmnsuite/Keys/Proof"
+)
+
+// Register registers all the interface -> struct to amino
+func Register(codec *amino.Codec) {
+	// Dependencies
+	hashtree.Register(codec)
+
+	// Proof
+	func() {
+		defer func() {
+			recover()
+		}()
+		codec.RegisterInterface((*Proof)(nil), nil)
+		codec.RegisterConcrete(&proof{}, XMNSuiteKeysProof, nil)
+	}()
+}
@@ -15,4 +15,5 @@ func RegisterGob() {
 	hashtree.RegisterGob()
 	gob.Register(&storedInstance{})
 	gob.Register(&concreteKeys{})
+	gob.Register(&proof{})
 }
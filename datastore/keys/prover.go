@@ -0,0 +1,104 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xmnservices/xmnsuite/hashtree"
+)
+
+/*
+ * Prover
+ */
+
+type prover struct {
+	ht        hashtree.HashTree
+	blocks    [][]byte
+	positions map[string]int
+}
+
+func createProver(ht hashtree.HashTree, blocks [][]byte, positions map[string]int) Prover {
+	out := prover{
+		ht:        ht,
+		blocks:    blocks,
+		positions: positions,
+	}
+
+	return &out
+}
+
+// Head returns the head hash of the proven keys
+func (app *prover) Head() hashtree.Hash {
+	return app.ht.Head()
+}
+
+// Prove creates a proof that the entry at key is part of the keys
+func (app *prover) Prove(key string) (Proof, error) {
+	position, ok := app.positions[key]
+	if !ok {
+		str := fmt.Sprintf("the key (%s) does not exists and therefore cannot be proven", key)
+		return nil, errors.New(str)
+	}
+
+	keyProof, keyProofErr := app.ht.Prove(position)
+	if keyProofErr != nil {
+		return nil, keyProofErr
+	}
+
+	entryProof, entryProofErr := app.ht.Prove(position + 1)
+	if entryProofErr != nil {
+		return nil, entryProofErr
+	}
+
+	return createProof(key, app.blocks[position+1], keyProof, entryProof), nil
+}
+
+/*
+ * Proof
+ */
+
+type proof struct {
+	K      string         `json:"key"`
+	Ent    []byte         `json:"entry"`
+	KeyPrf hashtree.Proof `json:"key_proof"`
+	EntPrf hashtree.Proof `json:"entry_proof"`
+}
+
+func createProof(key string, entry []byte, keyProof hashtree.Proof, entryProof hashtree.Proof) Proof {
+	out := proof{
+		K:      key,
+		Ent:    entry,
+		KeyPrf: keyProof,
+		EntPrf: entryProof,
+	}
+
+	return &out
+}
+
+// Key returns the key
+func (obj *proof) Key() string {
+	return obj.K
+}
+
+// Entry returns the entry, as it was hashed in the keys
+func (obj *proof) Entry() []byte {
+	return obj.Ent
+}
+
+// Head computes the head hash of the keys from the proof, and makes sure the entry is stored at the key
+func (obj *proof) Head() (hashtree.Hash, error) {
+	// the first block is the root, then every key is followed by its entry:
+	keyIndex := obj.KeyPrf.Index()
+	if keyIndex%2 != 1 || obj.EntPrf.Index() != keyIndex+1 {
+		str := fmt.Sprintf("the entry (index: %d) does not directly follow the key (index: %d)", obj.EntPrf.Index(), keyIndex)
+		return nil, errors.New(str)
+	}
+
+	head := obj.KeyPrf.Head([]byte(obj.K))
+	if !head.Compare(obj.EntPrf.Head(obj.Ent)) {
+		str := fmt.Sprintf("the key (%s) and its entry do not lead to the same head", obj.K)
+		return nil, errors.New(str)
+	}
+
+	return head, nil
+}
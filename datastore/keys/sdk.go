@@ -2,6 +2,19 @@ package keys
 
 import "github.com/xmnservices/xmnsuite/hashtree"
 
+// Proof represents a proof that an entry is stored at a key
+type Proof interface {
+	Key() string
+	Entry() []byte
+	Head() (hashtree.Hash, error)
+}
+
+// Prover creates proofs of the keys, as they were when the prover was created
+type Prover interface {
+	Head() hashtree.Hash
+	Prove(key string) (Proof, error)
+}
+
 // Keys represents the keys datastore
 type Keys interface {
 	Head() hashtree.HashTree
@@ -14,6 +27,7 @@ type Keys interface {
 	Search(pattern string) []string
 	Save(key string, data interface{})
 	Delete(key ...string) int
+	Prover() Prover
 }
 
 // SDKFunc represents the Keys SDK func
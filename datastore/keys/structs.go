@@ -48,6 +48,8 @@ type concreteKeys struct {
 	IsUpdated bool
 	HD        hashtree.HashTree
 	Dat       map[string]*storedInstance
+	blocks    [][]byte
+	positions map[string]int
 }
 
 func createConcreteKeys() Keys {
@@ -175,22 +177,43 @@ func (app *concreteKeys) Delete(key ...string) int {
 	return cpt
 }
 
+// Prover returns a prover of the keys, as they are now
+func (app *concreteKeys) Prover() Prover {
+	// a loaded instance has an head, but no blocks:
+	if app.positions == nil {
+		app.IsUpdated = true
+	}
+
+	app.rebuildHead()
+	return createProver(app.HD, app.blocks, app.positions)
+}
+
 func (app *concreteKeys) rebuildHead() {
 	if !app.IsUpdated {
 		return
 	}
 
+	// the keys are sorted so that the head is deterministic:
+	keynames := []string{}
+	for keyname := range app.Dat {
+		keynames = append(keynames, keyname)
+	}
+
+	sort.Strings(keynames)
+
 	blocks := [][]byte{
 		[]byte("root"),
 	}
 
-	for keyname, ins := range app.Dat {
-		blks, blksErr := helpers.GetBytes(ins.Data)
+	positions := map[string]int{}
+	for _, keyname := range keynames {
+		blks, blksErr := helpers.GetBytes(app.Dat[keyname].Data)
 		if blksErr != nil {
 			str := fmt.Sprintf("the data could not be converted to []byte: %s", blksErr.Error())
 			panic(errors.New(str))
 		}
 
+		positions[keyname] = len(blocks)
 		blocks = append(blocks, []byte(keyname))
 		blocks = append(blocks, blks)
 	}
@@ -200,5 +223,7 @@ func (app *concreteKeys) rebuildHead() {
 	})
 
 	app.HD = ht
+	app.blocks = blocks
+	app.positions = positions
 	app.IsUpdated = false
 }
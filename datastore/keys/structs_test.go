@@ -193,3 +193,58 @@ func TestSearch_patternIsInvalid_Panics(t *testing.T) {
 	//search:
 	app.Search("\\K")
 }
+
+func TestProver_thenVerifyEveryKey_Success(t *testing.T) {
+	//variables:
+	keynames := []string{"first", "second", "third"}
+
+	//create the application:
+	app := createConcreteKeys()
+	for _, oneKeyname := range keynames {
+		app.Save(oneKeyname, []byte(fmt.Sprintf("the data of %s", oneKeyname)))
+	}
+
+	// create the prover, then modify the keys:
+	prover := app.Prover()
+	app.Save("fourth", []byte("this is added after the prover"))
+
+	if !prover.Head().Compare(createConcreteKeysWith(keynames).Head().Head()) {
+		t.Errorf("the prover head was expected to match the keys as they were when the prover was created")
+		return
+	}
+
+	for _, oneKeyname := range keynames {
+		prf, prfErr := prover.Prove(oneKeyname)
+		if prfErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", prfErr.Error())
+			return
+		}
+
+		head, headErr := prf.Head()
+		if headErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", headErr.Error())
+			return
+		}
+
+		if !head.Compare(prover.Head()) {
+			t.Errorf("the proof of the key (%s) was expected to lead to the head of the keys", oneKeyname)
+			return
+		}
+	}
+
+	_, notThereErr := prover.Prove("fourth")
+	if notThereErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func createConcreteKeysWith(keynames []string) Keys {
+	// the insertion order must not change the head:
+	app := createConcreteKeys()
+	for i := len(keynames) - 1; i >= 0; i-- {
+		app.Save(keynames[i], []byte(fmt.Sprintf("the data of %s", keynames[i])))
+	}
+
+	return app
+}
@@ -0,0 +1,34 @@
+package datastore
+
+import (
+	"errors"
+	"fmt"
+)
+
+type memoryService struct {
+	stores map[string]DataStore
+}
+
+func createMemoryService() Service {
+	out := memoryService{
+		stores: map[string]DataStore{},
+	}
+
+	return &out
+}
+
+// Save saves a copy of the datastore in memory
+func (app *memoryService) Save(ds DataStore, filePath string) error {
+	app.stores[filePath] = ds.Copy()
+	return nil
+}
+
+// Retrieve retrieves a copy of a datastore stored in memory
+func (app *memoryService) Retrieve(filePath string) (DataStore, error) {
+	if ds, ok := app.stores[filePath]; ok {
+		return ds.Copy(), nil
+	}
+
+	str := fmt.Sprintf("there is no datastore saved in memory under the name: %s", filePath)
+	return nil, errors.New(str)
+}
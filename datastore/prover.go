@@ -0,0 +1,109 @@
+package datastore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/xmnservices/xmnsuite/datastore/keys"
+	"github.com/xmnservices/xmnsuite/hashtree"
+	"github.com/xmnservices/xmnsuite/helpers"
+)
+
+// the index of the objects head in the datastore head:
+const objectsIndexInHead = 3
+
+/*
+ * Prover
+ */
+
+type prover struct {
+	head  hashtree.Hash
+	objs  keys.Prover
+	store hashtree.Proof
+}
+
+func createProver(head hashtree.HashTree, objs keys.Prover) (Prover, error) {
+	store, storeErr := head.Prove(objectsIndexInHead)
+	if storeErr != nil {
+		return nil, storeErr
+	}
+
+	out := prover{
+		head:  head.Head(),
+		objs:  objs,
+		store: store,
+	}
+
+	return &out, nil
+}
+
+// Head returns the head hash of the proven datastore
+func (app *prover) Head() hashtree.Hash {
+	return app.head
+}
+
+// Prove creates a proof that the object at key is stored in the datastore
+func (app *prover) Prove(key string) (Proof, error) {
+	objs, objsErr := app.objs.Prove(key)
+	if objsErr != nil {
+		return nil, objsErr
+	}
+
+	return createProof(objs, app.store), nil
+}
+
+/*
+ * Proof
+ */
+
+type proof struct {
+	Objs  keys.Proof     `json:"objects"`
+	Store hashtree.Proof `json:"store"`
+}
+
+func createProof(objs keys.Proof, store hashtree.Proof) Proof {
+	out := proof{
+		Objs:  objs,
+		Store: store,
+	}
+
+	return &out
+}
+
+// Key returns the key of the proven object
+func (obj *proof) Key() string {
+	return obj.Objs.Key()
+}
+
+// Object decodes the proven object into the given pointer
+func (obj *proof) Object(ptr interface{}) error {
+	data := []byte{}
+	dataErr := helpers.Marshal(obj.Objs.Entry(), &data)
+	if dataErr != nil {
+		return dataErr
+	}
+
+	return helpers.Marshal(data, ptr)
+}
+
+// Verify verifies that the proof leads to the given datastore head
+func (obj *proof) Verify(head []byte) error {
+	if obj.Store.Index() != objectsIndexInHead {
+		str := fmt.Sprintf("the proof must be made on the objects (index: %d) of the datastore, index: %d", objectsIndexInHead, obj.Store.Index())
+		return errors.New(str)
+	}
+
+	objsHead, objsHeadErr := obj.Objs.Head()
+	if objsHeadErr != nil {
+		return objsHeadErr
+	}
+
+	storeHead := obj.Store.Head(objsHead.Get())
+	if !bytes.Equal(storeHead.Get(), head) {
+		str := fmt.Sprintf("the proof of the key (%s) leads to the head %s, which does not match the expected head", obj.Key(), storeHead.String())
+		return errors.New(str)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/xmnservices/xmnsuite/datastore/objects"
+)
+
+type objectForTests struct {
+	Title string
+}
+
+func TestProve_thenVerify_thenDecode_Success(t *testing.T) {
+	//variables:
+	key := "/messages/first"
+	obj := objectForTests{
+		Title: "this is a title",
+	}
+
+	// create datastore:
+	ds := createConcreteDataStore()
+	ds.Keys().Save("some_data", "this is some data")
+	ds.Objects().Save(&objects.ObjInKey{
+		Key: key,
+		Obj: &obj,
+	})
+
+	// create the prover, then modify the datastore:
+	prover, proverErr := ds.Prover()
+	if proverErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", proverErr.Error())
+		return
+	}
+
+	head := ds.Head().Head().Get()
+	ds.Objects().Save(&objects.ObjInKey{
+		Key: "/messages/second",
+		Obj: &obj,
+	})
+
+	// prove, then convert the proof back and forth:
+	prf, prfErr := prover.Prove(key)
+	if prfErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", prfErr.Error())
+		return
+	}
+
+	js, jsErr := cdc.MarshalJSON(prf)
+	if jsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", jsErr.Error())
+		return
+	}
+
+	retPrf := SDKFunc.CreateProof(CreateProofParams{
+		JS: js,
+	})
+
+	// verify:
+	verifyErr := retPrf.Verify(head)
+	if verifyErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", verifyErr.Error())
+		return
+	}
+
+	invalidErr := retPrf.Verify(ds.Head().Head().Get())
+	if invalidErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// decode:
+	retObj := new(objectForTests)
+	objErr := retPrf.Object(retObj)
+	if objErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", objErr.Error())
+		return
+	}
+
+	if retObj.Title != obj.Title {
+		t.Errorf("the decoded object was expected to have the title: %s, returned: %s", obj.Title, retObj.Title)
+		return
+	}
+}
@@ -11,16 +11,31 @@ import (
 	"github.com/xmnservices/xmnsuite/hashtree"
 )
 
+// Proof represents a proof that an object is stored in a datastore
+type Proof interface {
+	Key() string
+	Object(ptr interface{}) error
+	Verify(head []byte) error
+}
+
+// Prover creates proofs of the objects, as they were stored when the prover was created
+type Prover interface {
+	Head() hashtree.Hash
+	Prove(key string) (Proof, error)
+}
+
 // DataStore represents the datastore
 type DataStore interface {
 	Head() hashtree.HashTree
 	Copy() DataStore
+	Replace(ds DataStore)
 	Keys() keys.Keys
 	Lists() lists.Lists
 	Sets() lists.Lists
 	Objects() objects.Objects
 	Users() users.Users
 	Roles() roles.Roles
+	Prover() (Prover, error)
 }
 
 // Service saves and retrieves datastores
@@ -45,11 +60,19 @@ type StoredDataStoreParams struct {
 	FilePath string
 }
 
+// CreateProofParams represents the CreateProof params
+type CreateProofParams struct {
+	JS []byte
+}
+
 // SDKFunc represents the datastore SDK func
 var SDKFunc = struct {
 	Create                func() DataStore
 	CreateService         func(params ServiceParams) Service
 	CreateStoredDataStore func(params StoredDataStoreParams) StoredDataStore
+	CreateMemoryService   func() Service
+	CreateMemoryStore     func() StoredDataStore
+	CreateProof           func(params CreateProofParams) Proof
 }{
 	Create: func() DataStore {
 		return createConcreteDataStore()
@@ -69,4 +92,21 @@ var SDKFunc = struct {
 		st := createConcreteStoredDataStore(ds, serv, fileName)
 		return st
 	},
+	CreateMemoryService: func() Service {
+		return createMemoryService()
+	},
+	CreateMemoryStore: func() StoredDataStore {
+		serv := createMemoryService()
+		st := createConcreteStoredDataStore(createConcreteDataStore(), serv, "db.xmn")
+		return st
+	},
+	CreateProof: func(params CreateProofParams) Proof {
+		ptr := new(proof)
+		jsErr := cdc.UnmarshalJSON(params.JS, ptr)
+		if jsErr != nil {
+			panic(jsErr)
+		}
+
+		return ptr
+	},
 }
@@ -88,6 +88,23 @@ func (app *concreteDataStore) Head() hashtree.HashTree {
 	return head
 }
 
+// Prover returns a prover of the objects stored in the datastore, as they are now
+func (app *concreteDataStore) Prover() (Prover, error) {
+	objs := app.Objects().Keys().Prover()
+	head := hashtree.SDKFunc.CreateHashTree(hashtree.CreateHashTreeParams{
+		Blocks: [][]byte{
+			app.Keys().Head().Head().Get(),
+			app.Lists().Objects().Keys().Head().Head().Get(),
+			app.Sets().Objects().Keys().Head().Head().Get(),
+			objs.Head().Get(),
+			app.Users().Objects().Keys().Head().Head().Get(),
+			app.Roles().Lists().Objects().Keys().Head().Head().Get(),
+		},
+	})
+
+	return createProver(head, objs)
+}
+
 // Copy copies the datastore
 func (app *concreteDataStore) Copy() DataStore {
 	ck := app.K.Copy()
@@ -108,6 +125,16 @@ func (app *concreteDataStore) Copy() DataStore {
 	return &out
 }
 
+// Replace replaces the content of the datastore by the content of the given datastore, usually a modified copy of it
+func (app *concreteDataStore) Replace(ds DataStore) {
+	app.K = ds.Keys()
+	app.L = ds.Lists()
+	app.S = ds.Sets()
+	app.Objs = ds.Objects()
+	app.Usrs = ds.Users()
+	app.Rols = ds.Roles()
+}
+
 // Keys returns the keys datastore
 func (app *concreteDataStore) Keys() keys.Keys {
 	return app.K
@@ -0,0 +1,32 @@
+package datastore
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDataStore_replaceWithCopy_Success(t *testing.T) {
+	// create datastore:
+	ds := createConcreteDataStore()
+	ds.Keys().Save("first", "this is some data")
+
+	// modify a copy:
+	copied := ds.Copy()
+	copied.Keys().Save("second", "this is some other data")
+	if ds.Keys().Exists("second") != 0 {
+		t.Errorf("the copy was expected to NOT modify the datastore")
+		return
+	}
+
+	// replace:
+	ds.Replace(copied)
+	if ds.Keys().Exists("first", "second") != 2 {
+		t.Errorf("the replaced datastore was expected to contain the keys of the copy")
+		return
+	}
+
+	if !bytes.Equal(ds.Head().Head().Get(), copied.Head().Head().Get()) {
+		t.Errorf("the replaced datastore was expected to have the head of the copy")
+		return
+	}
+}
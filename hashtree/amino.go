@@ -26,6 +26,9 @@ const (
 	// XMNSuiteHashTreeCompact represents the xmnsuite Compact resource
 	XMNSuiteHashTreeCompact = "xmnsuite/Compact"
 
+	// XMNSuiteHashTreeProof represents the xmnsuite Proof resource
+	XMNSuiteHashTreeProof = "xmnsuite/Proof"
+
 	// XMNSuiteHashTreeHashTree represents the xmnsuite HashTree resource
 	XMNSuiteHashTreeHashTree = "xmnsuite/HashTree"
 )
@@ -91,6 +94,15 @@ func Register(codec *amino.Codec) {
 		codec.RegisterConcrete(&compact{}, XMNSuiteHashTreeCompact, nil)
 	}()
 
+	// Proof
+	func() {
+		defer func() {
+			recover()
+		}()
+		codec.RegisterInterface((*Proof)(nil), nil)
+		codec.RegisterConcrete(&proof{}, XMNSuiteHashTreeProof, nil)
+	}()
+
 	// HashTree
 	func() {
 		defer func() {
@@ -13,5 +13,6 @@ func RegisterGob() {
 	gob.Register(&leaf{})
 	gob.Register(&leaves{})
 	gob.Register(&compact{})
+	gob.Register(&proof{})
 	gob.Register(&hashTree{})
 }
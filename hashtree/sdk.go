@@ -86,6 +86,13 @@ type Compact interface {
 	Length() int
 }
 
+// Proof represents a proof that a block is part of an hashtree
+type Proof interface {
+	Index() int
+	Siblings() []Hash
+	Head(block []byte) Hash
+}
+
 // HashTree represents an hashtree
 type HashTree interface {
 	Height() int
@@ -94,4 +101,5 @@ type HashTree interface {
 	Parent() ParentLeaf
 	Compact() Compact
 	Order(data [][]byte) ([][]byte, error)
+	Prove(index int) (Proof, error)
 }
@@ -366,6 +366,58 @@ func (obj *leaves) createChildrenLeaves() Leaves {
 	return createLeaves(childrenLeaves)
 }
 
+/*
+* Proof
+ */
+
+type proof struct {
+	Idx  int    `json:"index"`
+	Sibs []Hash `json:"siblings"`
+}
+
+func createProof(index int, siblings []Hash) Proof {
+	out := proof{
+		Idx:  index,
+		Sibs: siblings,
+	}
+
+	return &out
+}
+
+// Index returns the index of the proven block
+func (obj *proof) Index() int {
+	return obj.Idx
+}
+
+// Siblings returns the sibling hashes, from the block leaf to the head
+func (obj *proof) Siblings() []Hash {
+	return obj.Sibs
+}
+
+// Head computes the head hash of the hashtree, using the given block
+func (obj *proof) Head(block []byte) Hash {
+	current := createHashFromData(block)
+	index := obj.Idx
+	for _, oneSibling := range obj.Sibs {
+		left := current
+		right := oneSibling
+		if index%2 != 0 {
+			left = oneSibling
+			right = current
+		}
+
+		data := bytes.Join([][]byte{
+			left.Get(),
+			right.Get(),
+		}, []byte{})
+
+		current = createHashFromData(data)
+		index = index / 2
+	}
+
+	return current
+}
+
 /*
 * Compact
  */
@@ -456,6 +508,46 @@ func (obj *hashTree) Compact() Compact {
 	return createCompact(obj.Hd, blockLeaves)
 }
 
+// Prove creates a proof that the block at the given index is part of the hashtree
+func (obj *hashTree) Prove(index int) (Proof, error) {
+	length := obj.Length()
+	if index < 0 || index >= length {
+		str := fmt.Sprintf("the index (%d) must be between 0 and the length of the hashtree (%d)", index, length)
+		return nil, errors.New(str)
+	}
+
+	// walk from the head to the block leaf:
+	siblings := []Hash{}
+	parent := obj.Pt
+	half := length / 2
+	relIndex := index
+	for {
+		next := parent.Left()
+		if relIndex < half {
+			siblings = append(siblings, parent.Right().Head())
+		} else {
+			siblings = append(siblings, parent.Left().Head())
+			next = parent.Right()
+			relIndex -= half
+		}
+
+		if !next.HasParent() {
+			break
+		}
+
+		parent = next.Parent()
+		half = half / 2
+	}
+
+	// the siblings must go from the block leaf to the head:
+	reversed := []Hash{}
+	for i := len(siblings) - 1; i >= 0; i-- {
+		reversed = append(reversed, siblings[i])
+	}
+
+	return createProof(index, reversed), nil
+}
+
 // Order orders data that matches the leafs of the HashTree
 func (obj *hashTree) Order(data [][]byte) ([][]byte, error) {
 	hashed := map[string][]byte{}
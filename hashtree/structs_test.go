@@ -181,3 +181,48 @@ func TestCreate_convertToJSON_convertToBinary_backAndForth_Success(t *testing.T)
 		return
 	}
 }
+
+func TestProve_thenVerifyEveryBlock_Success(t *testing.T) {
+	//variables:
+	blks := [][]byte{
+		[]byte("this"),
+		[]byte("is"),
+		[]byte("some"),
+		[]byte("blocks"),
+		[]byte("to"),
+		[]byte("prove"),
+	}
+
+	h, htErr := createHashTreeFromBlocks(blks)
+	if htErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", htErr.Error())
+		return
+	}
+
+	for index, oneBlock := range blks {
+		prf, prfErr := h.Prove(index)
+		if prfErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", prfErr.Error())
+			return
+		}
+
+		if !prf.Head(oneBlock).Compare(h.Head()) {
+			t.Errorf("the proof of the block (index: %d) was expected to lead to the head of the hashtree", index)
+			return
+		}
+
+		if prf.Head([]byte("invalid")).Compare(h.Head()) {
+			t.Errorf("the proof of the block (index: %d) was not expected to be valid for another block", index)
+			return
+		}
+
+		// convert with amino:
+		convert.ConvertToJSON(t, prf, new(proof), cdc)
+	}
+
+	_, invalidErr := h.Prove(len(blks) + 10)
+	if invalidErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
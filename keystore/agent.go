@@ -0,0 +1,190 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	agentActionUnlock   = "unlock"
+	agentActionRetrieve = "retrieve"
+	agentActionLock     = "lock"
+	agentActionLockAll  = "lock_all"
+)
+
+type agentRequest struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Pass   string `json:"pass"`
+}
+
+type agentResponse struct {
+	Error     string `json:"error"`
+	Name      string `json:"name"`
+	Label     string `json:"label"`
+	CreatedOn int64  `json:"created_on"`
+	Conf      string `json:"configs"`
+}
+
+type unlockedKey struct {
+	key       Key
+	expiresOn time.Time
+}
+
+type agent struct {
+	ks         Keystore
+	socketPath string
+	ttl        time.Duration
+	listener   net.Listener
+	mut        *sync.Mutex
+	unlocked   map[string]*unlockedKey
+}
+
+func createAgent(ks Keystore, socketPath string, ttl time.Duration) Agent {
+	out := agent{
+		ks:         ks,
+		socketPath: socketPath,
+		ttl:        ttl,
+		listener:   nil,
+		mut:        &sync.Mutex{},
+		unlocked:   map[string]*unlockedKey{},
+	}
+
+	return &out
+}
+
+// Start starts listening on the agent socket, in the background
+func (app *agent) Start() error {
+	if app.listener != nil {
+		return errors.New("the agent is already started")
+	}
+
+	// a socket that answers belongs to an agent that is already running:
+	if conn, connErr := net.DialTimeout("unix", app.socketPath, time.Second); connErr == nil {
+		conn.Close()
+		str := fmt.Sprintf("an agent is already listening on the socket (%s)", app.socketPath)
+		return errors.New(str)
+	}
+
+	// remove the socket left by an agent that was not stopped properly:
+	os.Remove(app.socketPath)
+
+	listener, listenerErr := net.Listen("unix", app.socketPath)
+	if listenerErr != nil {
+		return listenerErr
+	}
+
+	// only the owner of the keystore can talk to the agent:
+	chmodErr := os.Chmod(app.socketPath, 0600)
+	if chmodErr != nil {
+		listener.Close()
+		return chmodErr
+	}
+
+	app.listener = listener
+	go app.serve(listener)
+	return nil
+}
+
+// Stop stops the agent and forgets every unlocked key
+func (app *agent) Stop() error {
+	if app.listener == nil {
+		return errors.New("the agent is not started")
+	}
+
+	app.mut.Lock()
+	app.unlocked = map[string]*unlockedKey{}
+	app.mut.Unlock()
+
+	closeErr := app.listener.Close()
+	app.listener = nil
+	os.Remove(app.socketPath)
+	return closeErr
+}
+
+func (app *agent) serve(listener net.Listener) {
+	for {
+		conn, connErr := listener.Accept()
+		if connErr != nil {
+			return
+		}
+
+		go app.handle(conn)
+	}
+}
+
+func (app *agent) handle(conn net.Conn) {
+	defer conn.Close()
+
+	req := new(agentRequest)
+	decErr := json.NewDecoder(conn).Decode(req)
+	if decErr != nil {
+		json.NewEncoder(conn).Encode(agentResponse{
+			Error: fmt.Sprintf("the agent request is invalid: %s", decErr.Error()),
+		})
+
+		return
+	}
+
+	resp, respErr := app.execute(req)
+	if respErr != nil {
+		resp = &agentResponse{
+			Error: respErr.Error(),
+		}
+	}
+
+	json.NewEncoder(conn).Encode(resp)
+}
+
+func (app *agent) execute(req *agentRequest) (*agentResponse, error) {
+	app.mut.Lock()
+	defer app.mut.Unlock()
+
+	switch req.Action {
+	case agentActionUnlock:
+		key, keyErr := app.ks.Retrieve(req.Name, req.Pass)
+		if keyErr != nil {
+			return nil, keyErr
+		}
+
+		app.unlocked[req.Name] = &unlockedKey{
+			key:       key,
+			expiresOn: time.Now().Add(app.ttl),
+		}
+
+		return &agentResponse{}, nil
+	case agentActionRetrieve:
+		unlocked, ok := app.unlocked[req.Name]
+		if !ok {
+			str := fmt.Sprintf("the key (%s) is not unlocked", req.Name)
+			return nil, errors.New(str)
+		}
+
+		if time.Now().After(unlocked.expiresOn) {
+			delete(app.unlocked, req.Name)
+			str := fmt.Sprintf("the key (%s) is no longer unlocked: its session expired", req.Name)
+			return nil, errors.New(str)
+		}
+
+		return &agentResponse{
+			Name:      unlocked.key.Name(),
+			Label:     unlocked.key.Label(),
+			CreatedOn: unlocked.key.CreatedOn().Unix(),
+			Conf:      unlocked.key.Configs().String(),
+		}, nil
+	case agentActionLock:
+		delete(app.unlocked, req.Name)
+		return &agentResponse{}, nil
+	case agentActionLockAll:
+		app.unlocked = map[string]*unlockedKey{}
+		return &agentResponse{}, nil
+	}
+
+	str := fmt.Sprintf("the agent action (%s) is not supported", req.Action)
+	return nil, errors.New(str)
+}
@@ -0,0 +1,104 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/xmnservices/xmnsuite/configs"
+)
+
+type agentClient struct {
+	socketPath string
+}
+
+func createAgentClient(socketPath string) AgentClient {
+	out := agentClient{
+		socketPath: socketPath,
+	}
+
+	return &out
+}
+
+// Unlock unlocks a key in the agent, for the duration of its session
+func (app *agentClient) Unlock(name string, pass string) error {
+	_, respErr := app.call(&agentRequest{
+		Action: agentActionUnlock,
+		Name:   name,
+		Pass:   pass,
+	})
+
+	return respErr
+}
+
+// Retrieve retrieves an unlocked key from the agent
+func (app *agentClient) Retrieve(name string) (out Key, err error) {
+	resp, respErr := app.call(&agentRequest{
+		Action: agentActionRetrieve,
+		Name:   name,
+	})
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the agent returned invalid configs: %v", r)
+		}
+	}()
+
+	conf := configs.SDKFunc.Create(configs.CreateParams{
+		Encoded: resp.Conf,
+	})
+
+	return createKey(resp.Name, resp.Label, time.Unix(resp.CreatedOn, 0).UTC(), conf)
+}
+
+// Lock locks a key in the agent
+func (app *agentClient) Lock(name string) error {
+	_, respErr := app.call(&agentRequest{
+		Action: agentActionLock,
+		Name:   name,
+	})
+
+	return respErr
+}
+
+// LockAll locks every key in the agent
+func (app *agentClient) LockAll() error {
+	_, respErr := app.call(&agentRequest{
+		Action: agentActionLockAll,
+	})
+
+	return respErr
+}
+
+func (app *agentClient) call(req *agentRequest) (*agentResponse, error) {
+	conn, connErr := net.DialTimeout("unix", app.socketPath, time.Second)
+	if connErr != nil {
+		str := fmt.Sprintf("the agent is not running on the socket (%s): %s", app.socketPath, connErr.Error())
+		return nil, errors.New(str)
+	}
+	defer conn.Close()
+
+	encErr := json.NewEncoder(conn).Encode(req)
+	if encErr != nil {
+		return nil, encErr
+	}
+
+	resp := new(agentResponse)
+	decErr := json.NewDecoder(conn).Decode(resp)
+	if decErr != nil {
+		return nil, decErr
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,156 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/xmnservices/xmnsuite/configs"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+)
+
+const (
+	keyFileExtension = ".key"
+	masterFileName   = "master"
+	agentSocketName  = "agent.sock"
+
+	// the message encrypted in the master file, used to validate the master password:
+	masterPassCheck = "xmnsuite/keystore/master"
+)
+
+var namePattern = regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+
+func isNameValid(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+func keyFilePath(dirPath string, name string) string {
+	return filepath.Join(dirPath, fmt.Sprintf("%s%s", name, keyFileExtension))
+}
+
+func masterFilePath(dirPath string) string {
+	return filepath.Join(dirPath, masterFileName)
+}
+
+func agentSocketPath(dirPath string) string {
+	return filepath.Join(dirPath, agentSocketName)
+}
+
+func validatePass(pass string, retypedPass string) error {
+	if len(pass) < 6 {
+		return errors.New("The password must contain at least 6 characters")
+	}
+
+	if pass != retypedPass {
+		return errors.New("The passwords do not match")
+	}
+
+	return nil
+}
+
+func encryptConfigs(conf configs.Configs, pass string, retypedPass string) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = ""
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	out = configs.SDKFunc.Encrypt(configs.EncryptParams{
+		Conf:        conf,
+		Pass:        pass,
+		RetypedPass: retypedPass,
+	})
+
+	return out, nil
+}
+
+func decryptConfigs(encrypted string, pass string) (out configs.Configs, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = errors.New("the password is invalid or the encrypted data has been tampered with")
+		}
+	}()
+
+	out = configs.SDKFunc.Decrypt(configs.DecryptParams{
+		Data: encrypted,
+		Pass: pass,
+	})
+
+	return out, nil
+}
+
+func decryptBytes(encrypted string, pass string) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	out = crypto.SDKFunc.Decrypt(crypto.DecryptParams{
+		Pass:         []byte(pass),
+		EncryptedMsg: encrypted,
+	})
+
+	return out, nil
+}
+
+func readStorableKey(filePath string) (*storableKey, error) {
+	data, dataErr := ioutil.ReadFile(filePath)
+	if dataErr != nil {
+		return nil, dataErr
+	}
+
+	ptr := new(storableKey)
+	jsErr := json.Unmarshal(data, ptr)
+	if jsErr != nil {
+		str := fmt.Sprintf("the file (%s) does not contain a valid key: %s", filePath, jsErr.Error())
+		return nil, errors.New(str)
+	}
+
+	return ptr, nil
+}
+
+func writeFile(dirPath string, filePath string, data []byte) error {
+	if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+		mkErr := os.MkdirAll(dirPath, 0700)
+		if mkErr != nil {
+			return mkErr
+		}
+	}
+
+	// write in a temporary file first, so that an interrupted write never corrupts a key:
+	tmpFilePath := fmt.Sprintf("%s.tmp", filePath)
+	writeErr := ioutil.WriteFile(tmpFilePath, data, 0600)
+	if writeErr != nil {
+		return writeErr
+	}
+
+	return os.Rename(tmpFilePath, filePath)
+}
+
+func retrieveConfigs(dirPath string, name string, pass string) (configs.Configs, error) {
+	// without a password, the key must have been unlocked in the agent:
+	if pass == "" {
+		key, keyErr := createAgentClient(agentSocketPath(dirPath)).Retrieve(name)
+		if keyErr != nil {
+			str := fmt.Sprintf("no password was given and the key (%s) could not be retrieved from the agent: %s", name, keyErr.Error())
+			return nil, errors.New(str)
+		}
+
+		return key.Configs(), nil
+	}
+
+	key, keyErr := createKeystore(dirPath).Retrieve(name, pass)
+	if keyErr != nil {
+		return nil, keyErr
+	}
+
+	return key.Configs(), nil
+}
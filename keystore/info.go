@@ -0,0 +1,47 @@
+package keystore
+
+import (
+	"time"
+)
+
+type info struct {
+	name           string
+	label          string
+	createdOn      time.Time
+	usesMasterPass bool
+}
+
+func createInfo(name string, label string, createdOn time.Time, usesMasterPass bool) Info {
+	out := info{
+		name:           name,
+		label:          label,
+		createdOn:      createdOn,
+		usesMasterPass: usesMasterPass,
+	}
+
+	return &out
+}
+
+func fromStorableToInfo(storable *storableKey) Info {
+	return createInfo(storable.Name, storable.Label, time.Unix(storable.CreatedOn, 0).UTC(), storable.UsesMasterPass)
+}
+
+// Name returns the name
+func (obj *info) Name() string {
+	return obj.name
+}
+
+// Label returns the label
+func (obj *info) Label() string {
+	return obj.label
+}
+
+// CreatedOn returns the creation time
+func (obj *info) CreatedOn() time.Time {
+	return obj.createdOn
+}
+
+// UsesMasterPass returns true if the key is encrypted with the master password, false otherwise
+func (obj *info) UsesMasterPass() bool {
+	return obj.usesMasterPass
+}
@@ -0,0 +1,56 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xmnservices/xmnsuite/configs"
+)
+
+type key struct {
+	name      string
+	label     string
+	createdOn time.Time
+	conf      configs.Configs
+}
+
+func createKey(name string, label string, createdOn time.Time, conf configs.Configs) (Key, error) {
+	if !isNameValid(name) {
+		str := fmt.Sprintf("the name (%s) is invalid: it must only contain letters, digits, dashes and underscores", name)
+		return nil, errors.New(str)
+	}
+
+	if conf == nil {
+		return nil, errors.New("the configs are mandatory in order to create a Key instance")
+	}
+
+	out := key{
+		name:      name,
+		label:     label,
+		createdOn: createdOn,
+		conf:      conf,
+	}
+
+	return &out, nil
+}
+
+// Name returns the name
+func (obj *key) Name() string {
+	return obj.name
+}
+
+// Label returns the label
+func (obj *key) Label() string {
+	return obj.label
+}
+
+// CreatedOn returns the creation time
+func (obj *key) CreatedOn() time.Time {
+	return obj.createdOn
+}
+
+// Configs returns the configs
+func (obj *key) Configs() configs.Configs {
+	return obj.conf
+}
@@ -0,0 +1,270 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+)
+
+type keystore struct {
+	dirPath string
+}
+
+func createKeystore(dirPath string) Keystore {
+	out := keystore{
+		dirPath: dirPath,
+	}
+
+	return &out
+}
+
+// Exists returns true if a key with the given name exists, false otherwise
+func (app *keystore) Exists(name string) bool {
+	if !isNameValid(name) {
+		return false
+	}
+
+	_, err := os.Stat(keyFilePath(app.dirPath, name))
+	return err == nil
+}
+
+// List lists the information of the keys, ordered by name
+func (app *keystore) List() ([]Info, error) {
+	out := []Info{}
+	files, filesErr := ioutil.ReadDir(app.dirPath)
+	if filesErr != nil {
+		if os.IsNotExist(filesErr) {
+			return out, nil
+		}
+
+		return nil, filesErr
+	}
+
+	for _, oneFile := range files {
+		if oneFile.IsDir() || !strings.HasSuffix(oneFile.Name(), keyFileExtension) {
+			continue
+		}
+
+		name := strings.TrimSuffix(oneFile.Name(), keyFileExtension)
+		storable, storableErr := readStorableKey(keyFilePath(app.dirPath, name))
+		if storableErr != nil {
+			return nil, storableErr
+		}
+
+		out = append(out, fromStorableToInfo(storable))
+	}
+
+	return out, nil
+}
+
+// Retrieve decrypts and returns a key
+func (app *keystore) Retrieve(name string, pass string) (Key, error) {
+	storable, storableErr := app.retrieveStorable(name)
+	if storableErr != nil {
+		return nil, storableErr
+	}
+
+	conf, confErr := decryptConfigs(storable.Encrypted, pass)
+	if confErr != nil {
+		str := fmt.Sprintf("the key (%s) could not be decrypted: %s", name, confErr.Error())
+		return nil, errors.New(str)
+	}
+
+	return createKey(storable.Name, storable.Label, time.Unix(storable.CreatedOn, 0).UTC(), conf)
+}
+
+// Save encrypts and saves a new key, either with its own password or with the master password
+func (app *keystore) Save(ins Key, pass string, retypedPass string, useMasterPass bool) error {
+	if app.Exists(ins.Name()) {
+		str := fmt.Sprintf("the key (%s) already exists", ins.Name())
+		return errors.New(str)
+	}
+
+	if useMasterPass {
+		masterErr := app.verifyMasterPass(pass, retypedPass, true)
+		if masterErr != nil {
+			return masterErr
+		}
+	}
+
+	encrypted, encryptedErr := encryptConfigs(ins.Configs(), pass, retypedPass)
+	if encryptedErr != nil {
+		return encryptedErr
+	}
+
+	return app.saveStorable(createStorableKey(ins, useMasterPass, encrypted))
+}
+
+// Delete deletes a key, after validating its password
+func (app *keystore) Delete(name string, pass string) error {
+	_, keyErr := app.Retrieve(name, pass)
+	if keyErr != nil {
+		return keyErr
+	}
+
+	return os.Remove(keyFilePath(app.dirPath, name))
+}
+
+// ChangePass changes the password of a key that does not use the master password
+func (app *keystore) ChangePass(name string, pass string, newPass string, retypedNewPass string) error {
+	storable, storableErr := app.retrieveStorable(name)
+	if storableErr != nil {
+		return storableErr
+	}
+
+	if storable.UsesMasterPass {
+		str := fmt.Sprintf("the key (%s) is encrypted with the master password, change the master password instead", name)
+		return errors.New(str)
+	}
+
+	key, keyErr := app.Retrieve(name, pass)
+	if keyErr != nil {
+		return keyErr
+	}
+
+	encrypted, encryptedErr := encryptConfigs(key.Configs(), newPass, retypedNewPass)
+	if encryptedErr != nil {
+		return encryptedErr
+	}
+
+	return app.saveStorable(createStorableKey(key, false, encrypted))
+}
+
+// ChangeMasterPass changes the master password and re-encrypts every key that uses it
+func (app *keystore) ChangeMasterPass(masterPass string, newMasterPass string, retypedNewMasterPass string) error {
+	masterErr := app.verifyMasterPass(masterPass, masterPass, false)
+	if masterErr != nil {
+		return masterErr
+	}
+
+	passErr := validatePass(newMasterPass, retypedNewMasterPass)
+	if passErr != nil {
+		return passErr
+	}
+
+	infos, infosErr := app.List()
+	if infosErr != nil {
+		return infosErr
+	}
+
+	// re-encrypt every key before writing any of them, so that a failure leaves the keystore untouched:
+	storables := []*storableKey{}
+	for _, oneInfo := range infos {
+		if !oneInfo.UsesMasterPass() {
+			continue
+		}
+
+		key, keyErr := app.Retrieve(oneInfo.Name(), masterPass)
+		if keyErr != nil {
+			return keyErr
+		}
+
+		encrypted, encryptedErr := encryptConfigs(key.Configs(), newMasterPass, retypedNewMasterPass)
+		if encryptedErr != nil {
+			return encryptedErr
+		}
+
+		storables = append(storables, createStorableKey(key, true, encrypted))
+	}
+
+	for _, oneStorable := range storables {
+		saveErr := app.saveStorable(oneStorable)
+		if saveErr != nil {
+			return saveErr
+		}
+	}
+
+	return app.saveMasterPass(newMasterPass)
+}
+
+// Export returns a key encrypted with the export password, in the format of an encrypted configuration file
+func (app *keystore) Export(name string, pass string, exportPass string, retypedExportPass string) (string, error) {
+	key, keyErr := app.Retrieve(name, pass)
+	if keyErr != nil {
+		return "", keyErr
+	}
+
+	return encryptConfigs(key.Configs(), exportPass, retypedExportPass)
+}
+
+// Import decrypts an exported key, or an encrypted configuration file, then saves it under the given name
+func (app *keystore) Import(name string, label string, exported string, exportPass string, pass string, retypedPass string, useMasterPass bool) (Key, error) {
+	conf, confErr := decryptConfigs(strings.TrimSpace(exported), exportPass)
+	if confErr != nil {
+		str := fmt.Sprintf("the exported key could not be decrypted: %s", confErr.Error())
+		return nil, errors.New(str)
+	}
+
+	key, keyErr := createKey(name, label, time.Now().UTC(), conf)
+	if keyErr != nil {
+		return nil, keyErr
+	}
+
+	saveErr := app.Save(key, pass, retypedPass, useMasterPass)
+	if saveErr != nil {
+		return nil, saveErr
+	}
+
+	return key, nil
+}
+
+func (app *keystore) retrieveStorable(name string) (*storableKey, error) {
+	if !app.Exists(name) {
+		str := fmt.Sprintf("the key (%s) does not exist in the keystore (%s)", name, app.dirPath)
+		return nil, errors.New(str)
+	}
+
+	return readStorableKey(keyFilePath(app.dirPath, name))
+}
+
+func (app *keystore) saveStorable(storable *storableKey) error {
+	js, jsErr := json.Marshal(storable)
+	if jsErr != nil {
+		return jsErr
+	}
+
+	return writeFile(app.dirPath, keyFilePath(app.dirPath, storable.Name), js)
+}
+
+func (app *keystore) verifyMasterPass(masterPass string, retypedMasterPass string, createIfMissing bool) error {
+	data, dataErr := ioutil.ReadFile(masterFilePath(app.dirPath))
+	if dataErr != nil {
+		if !os.IsNotExist(dataErr) {
+			return dataErr
+		}
+
+		if !createIfMissing {
+			return errors.New("the keystore does not have a master password")
+		}
+
+		// the first key that uses the master password sets it:
+		passErr := validatePass(masterPass, retypedMasterPass)
+		if passErr != nil {
+			return passErr
+		}
+
+		return app.saveMasterPass(masterPass)
+	}
+
+	decrypted, decryptedErr := decryptBytes(string(data), masterPass)
+	if decryptedErr != nil || string(decrypted) != masterPassCheck {
+		return errors.New("the master password is invalid")
+	}
+
+	return nil
+}
+
+func (app *keystore) saveMasterPass(masterPass string) error {
+	encrypted := crypto.SDKFunc.Encrypt(crypto.EncryptParams{
+		Pass: []byte(masterPass),
+		Msg:  []byte(masterPassCheck),
+	})
+
+	return writeFile(app.dirPath, masterFilePath(app.dirPath), []byte(encrypted))
+}
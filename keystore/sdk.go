@@ -0,0 +1,146 @@
+package keystore
+
+import (
+	"time"
+
+	"github.com/xmnservices/xmnsuite/configs"
+)
+
+const (
+	// DefaultDir represents the default keystore directory
+	DefaultDir = "./keystore"
+
+	// DefaultAgentTTL represents the default duration a key stays unlocked in the agent
+	DefaultAgentTTL = time.Hour
+)
+
+// Info represents the public information of a key, readable without its password
+type Info interface {
+	Name() string
+	Label() string
+	CreatedOn() time.Time
+	UsesMasterPass() bool
+}
+
+// Key represents an unlocked key of the keystore
+type Key interface {
+	Name() string
+	Label() string
+	CreatedOn() time.Time
+	Configs() configs.Configs
+}
+
+// Keystore represents a directory of named, encrypted keys
+type Keystore interface {
+	Exists(name string) bool
+	List() ([]Info, error)
+	Retrieve(name string, pass string) (Key, error)
+	Save(ins Key, pass string, retypedPass string, useMasterPass bool) error
+	Delete(name string, pass string) error
+	ChangePass(name string, pass string, newPass string, retypedNewPass string) error
+	ChangeMasterPass(masterPass string, newMasterPass string, retypedNewMasterPass string) error
+	Export(name string, pass string, exportPass string, retypedExportPass string) (string, error)
+	Import(name string, label string, exported string, exportPass string, pass string, retypedPass string, useMasterPass bool) (Key, error)
+}
+
+// Agent represents an agent that caches the unlocked keys of a keystore for a session
+type Agent interface {
+	Start() error
+	Stop() error
+}
+
+// AgentClient represents a client of a running agent
+type AgentClient interface {
+	Unlock(name string, pass string) error
+	Retrieve(name string) (Key, error)
+	Lock(name string) error
+	LockAll() error
+}
+
+// CreateParams represents the create params
+type CreateParams struct {
+	Name  string
+	Label string
+	Conf  configs.Configs
+}
+
+// CreateKeystoreParams represents the CreateKeystore params
+type CreateKeystoreParams struct {
+	Dir string
+}
+
+// CreateAgentParams represents the CreateAgent params
+type CreateAgentParams struct {
+	Dir string
+	TTL time.Duration
+}
+
+// CreateAgentClientParams represents the CreateAgentClient params
+type CreateAgentClientParams struct {
+	Dir string
+}
+
+// RetrieveConfigsParams represents the RetrieveConfigs params
+type RetrieveConfigsParams struct {
+	Dir  string
+	Name string
+	Pass string
+}
+
+// SDKFunc represents the keystore SDK func
+var SDKFunc = struct {
+	Create            func(params CreateParams) Key
+	CreateKeystore    func(params CreateKeystoreParams) Keystore
+	CreateAgent       func(params CreateAgentParams) Agent
+	CreateAgentClient func(params CreateAgentClientParams) AgentClient
+	RetrieveConfigs   func(params RetrieveConfigsParams) configs.Configs
+}{
+	Create: func(params CreateParams) Key {
+		out, outErr := createKey(params.Name, params.Label, time.Now().UTC(), params.Conf)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateKeystore: func(params CreateKeystoreParams) Keystore {
+		if params.Dir == "" {
+			params.Dir = DefaultDir
+		}
+
+		out := createKeystore(params.Dir)
+		return out
+	},
+	CreateAgent: func(params CreateAgentParams) Agent {
+		if params.Dir == "" {
+			params.Dir = DefaultDir
+		}
+
+		if params.TTL <= 0 {
+			params.TTL = DefaultAgentTTL
+		}
+
+		out := createAgent(createKeystore(params.Dir), agentSocketPath(params.Dir), params.TTL)
+		return out
+	},
+	CreateAgentClient: func(params CreateAgentClientParams) AgentClient {
+		if params.Dir == "" {
+			params.Dir = DefaultDir
+		}
+
+		out := createAgentClient(agentSocketPath(params.Dir))
+		return out
+	},
+	RetrieveConfigs: func(params RetrieveConfigsParams) configs.Configs {
+		if params.Dir == "" {
+			params.Dir = DefaultDir
+		}
+
+		out, outErr := retrieveConfigs(params.Dir, params.Name, params.Pass)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+}
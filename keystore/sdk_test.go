@@ -0,0 +1,293 @@
+package keystore
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/xmnservices/xmnsuite/configs"
+)
+
+func TestSave_ThenRetrieve_ThenList_Success(t *testing.T) {
+	// variables:
+	dirPath := "test_files"
+	pass := "this-is-a-password"
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	// create the keys:
+	firstKey := SDKFunc.Create(CreateParams{
+		Name:  "first",
+		Label: "This is the first key",
+		Conf:  configs.SDKFunc.Generate(),
+	})
+
+	secondKey := SDKFunc.Create(CreateParams{
+		Name:  "second",
+		Label: "This is the second key",
+		Conf:  configs.SDKFunc.Generate(),
+	})
+
+	// save:
+	ks := SDKFunc.CreateKeystore(CreateKeystoreParams{
+		Dir: dirPath,
+	})
+
+	firstErr := ks.Save(firstKey, pass, pass, false)
+	if firstErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", firstErr.Error())
+		return
+	}
+
+	secondErr := ks.Save(secondKey, pass, pass, true)
+	if secondErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", secondErr.Error())
+		return
+	}
+
+	// saving the same name again must fail:
+	againErr := ks.Save(firstKey, pass, pass, false)
+	if againErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// retrieve:
+	retKey, retKeyErr := ks.Retrieve("first", pass)
+	if retKeyErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retKeyErr.Error())
+		return
+	}
+
+	if retKey.Configs().String() != firstKey.Configs().String() {
+		t.Errorf("the retrieved configs did not match the saved configs")
+		return
+	}
+
+	if retKey.Label() != firstKey.Label() || retKey.CreatedOn().Unix() != firstKey.CreatedOn().Unix() {
+		t.Errorf("the retrieved key information did not match the saved key information")
+		return
+	}
+
+	// retrieve with an invalid password:
+	_, invalidErr := ks.Retrieve("first", "not-the-password")
+	if invalidErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// list:
+	infos, infosErr := ks.List()
+	if infosErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", infosErr.Error())
+		return
+	}
+
+	if len(infos) != 2 {
+		t.Errorf("%d keys were expected, %d returned", 2, len(infos))
+		return
+	}
+
+	if infos[0].Name() != "first" || infos[0].UsesMasterPass() {
+		t.Errorf("the first key was expected to be named first and to use its own password")
+		return
+	}
+
+	if infos[1].Name() != "second" || !infos[1].UsesMasterPass() {
+		t.Errorf("the second key was expected to be named second and to use the master password")
+		return
+	}
+}
+
+func TestChangePass_ThenChangeMasterPass_Success(t *testing.T) {
+	// variables:
+	dirPath := "test_files"
+	pass := "this-is-a-password"
+	newPass := "this-is-a-new-password"
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	ks := SDKFunc.CreateKeystore(CreateKeystoreParams{
+		Dir: dirPath,
+	})
+
+	ks.Save(SDKFunc.Create(CreateParams{
+		Name: "own",
+		Conf: configs.SDKFunc.Generate(),
+	}), pass, pass, false)
+
+	ks.Save(SDKFunc.Create(CreateParams{
+		Name: "master",
+		Conf: configs.SDKFunc.Generate(),
+	}), pass, pass, true)
+
+	// a key that uses the master password cannot change its own password:
+	masterKeyErr := ks.ChangePass("master", pass, newPass, newPass)
+	if masterKeyErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// change the password of the key:
+	changeErr := ks.ChangePass("own", pass, newPass, newPass)
+	if changeErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", changeErr.Error())
+		return
+	}
+
+	_, ownErr := ks.Retrieve("own", newPass)
+	if ownErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", ownErr.Error())
+		return
+	}
+
+	// change the master password:
+	changeMasterErr := ks.ChangeMasterPass(pass, newPass, newPass)
+	if changeMasterErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", changeMasterErr.Error())
+		return
+	}
+
+	_, masterErr := ks.Retrieve("master", newPass)
+	if masterErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", masterErr.Error())
+		return
+	}
+
+	// the old master password is no longer valid:
+	_, oldErr := ks.Retrieve("master", pass)
+	if oldErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestExport_ThenImport_Success(t *testing.T) {
+	// variables:
+	dirPath := "test_files"
+	pass := "this-is-a-password"
+	exportPass := "this-is-the-export-password"
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	ks := SDKFunc.CreateKeystore(CreateKeystoreParams{
+		Dir: dirPath,
+	})
+
+	key := SDKFunc.Create(CreateParams{
+		Name: "first",
+		Conf: configs.SDKFunc.Generate(),
+	})
+
+	ks.Save(key, pass, pass, false)
+
+	// export:
+	exported, exportedErr := ks.Export("first", pass, exportPass, exportPass)
+	if exportedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", exportedErr.Error())
+		return
+	}
+
+	// import:
+	imported, importedErr := ks.Import("imported", "Imported key", exported, exportPass, pass, pass, false)
+	if importedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", importedErr.Error())
+		return
+	}
+
+	if imported.Configs().String() != key.Configs().String() {
+		t.Errorf("the imported configs did not match the exported configs")
+		return
+	}
+
+	// an encrypted configuration file can also be imported:
+	confFile := configs.SDKFunc.Encrypt(configs.EncryptParams{
+		Conf:        configs.SDKFunc.Generate(),
+		Pass:        exportPass,
+		RetypedPass: exportPass,
+	})
+
+	_, confErr := ks.Import("legacy", "", confFile, exportPass, pass, pass, false)
+	if confErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", confErr.Error())
+		return
+	}
+}
+
+func TestAgent_unlock_retrieve_lock_Success(t *testing.T) {
+	// variables:
+	dirPath := "test_files"
+	pass := "this-is-a-password"
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	ks := SDKFunc.CreateKeystore(CreateKeystoreParams{
+		Dir: dirPath,
+	})
+
+	key := SDKFunc.Create(CreateParams{
+		Name: "first",
+		Conf: configs.SDKFunc.Generate(),
+	})
+
+	ks.Save(key, pass, pass, false)
+
+	// start the agent:
+	agent := SDKFunc.CreateAgent(CreateAgentParams{
+		Dir: dirPath,
+		TTL: time.Minute,
+	})
+
+	startErr := agent.Start()
+	if startErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", startErr.Error())
+		return
+	}
+	defer agent.Stop()
+
+	client := SDKFunc.CreateAgentClient(CreateAgentClientParams{
+		Dir: dirPath,
+	})
+
+	// the key is not unlocked yet:
+	_, lockedErr := client.Retrieve("first")
+	if lockedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// unlock:
+	unlockErr := client.Unlock("first", pass)
+	if unlockErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", unlockErr.Error())
+		return
+	}
+
+	// retrieve the configs without the password:
+	conf := SDKFunc.RetrieveConfigs(RetrieveConfigsParams{
+		Dir:  dirPath,
+		Name: "first",
+	})
+
+	if conf.String() != key.Configs().String() {
+		t.Errorf("the configs retrieved from the agent did not match the saved configs")
+		return
+	}
+
+	// lock:
+	lockErr := client.Lock("first")
+	if lockErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", lockErr.Error())
+		return
+	}
+
+	_, relockedErr := client.Retrieve("first")
+	if relockedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
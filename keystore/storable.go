@@ -0,0 +1,21 @@
+package keystore
+
+type storableKey struct {
+	Name           string `json:"name"`
+	Label          string `json:"label"`
+	CreatedOn      int64  `json:"created_on"`
+	UsesMasterPass bool   `json:"uses_master_pass"`
+	Encrypted      string `json:"encrypted"`
+}
+
+func createStorableKey(ins Key, usesMasterPass bool, encrypted string) *storableKey {
+	out := storableKey{
+		Name:           ins.Name(),
+		Label:          ins.Label(),
+		CreatedOn:      ins.CreatedOn().Unix(),
+		UsesMasterPass: usesMasterPass,
+		Encrypted:      encrypted,
+	}
+
+	return &out
+}
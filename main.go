@@ -33,6 +33,14 @@ func main() {
 	app.Usage = "This is the xmn core application"
 	app.Commands = []cliapp.Command{
 		*cli.SDKFunc.Spawn(),
+		*cli.SDKFunc.Run(),
+		*cli.SDKFunc.Test(),
+		*cli.SDKFunc.Script(),
+		*cli.SDKFunc.History(),
+		*cli.SDKFunc.Keys(),
+		*cli.SDKFunc.Keystore(),
+		*cli.SDKFunc.Signer(),
+		*cli.SDKFunc.Offline(),
 	}
 
 	err := app.Run(os.Args)
@@ -4,17 +4,24 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
 	"strconv"
 
 	uuid "github.com/satori/go.uuid"
 	tcrypto "github.com/tendermint/tendermint/crypto"
+	ed25519 "github.com/tendermint/tendermint/crypto/ed25519"
 	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
 	tendermint "github.com/xmnservices/xmnsuite/blockchains/tendermint"
 	crypto "github.com/xmnservices/xmnsuite/crypto"
 	datastore "github.com/xmnservices/xmnsuite/datastore"
 	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	events_module "github.com/xmnservices/xmnsuite/modules/events"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
 	"github.com/xmnservices/xmnsuite/routers"
 	lua "github.com/yuin/gopher-lua"
+	luajson "layeh.com/gopher-json"
 )
 
 const luaChain = "chain"
@@ -25,14 +32,20 @@ const luaRoute = "route"
 type chain struct {
 	namespace string
 	name      string
+	budget    int
 	apps      []*application
 }
 
 type application struct {
-	version    string
-	beginIndex int
-	endIndex   int
-	router     *router
+	version          string
+	beginIndex       int
+	endIndex         int
+	router           *router
+	beginBlock       *lua.LFunction
+	endBlock         *lua.LFunction
+	onCommit         *lua.LFunction
+	isUpgradable     bool
+	legacySignatures bool
 }
 
 type router struct {
@@ -45,6 +58,13 @@ type route struct {
 	saveTrx  *lua.LFunction
 	delTrx   *lua.LFunction
 	queryTrx *lua.LFunction
+	schema   routers.Schema
+	native   *routers.CreateRouteParams
+}
+
+// nativeRoutes represents a value, such as an entity declared in lua, that can be added to the routes of a router
+type nativeRoutes interface {
+	Routes() []routers.CreateRouteParams
 }
 
 type module struct {
@@ -55,6 +75,8 @@ type module struct {
 	rootPubKeys []crypto.PublicKey
 	nodePK      tcrypto.PrivKey
 	ds          datastore_module.Datastore
+	evts        events_module.Events
+	budget      int
 	ch          *chain
 }
 
@@ -66,6 +88,7 @@ func createModule(
 	rootPubKeys []crypto.PublicKey,
 	nodePK tcrypto.PrivKey,
 	ds datastore_module.Datastore,
+	evts events_module.Events,
 ) Chain {
 	out := module{
 		context:     context,
@@ -75,6 +98,8 @@ func createModule(
 		rootPubKeys: rootPubKeys,
 		nodePK:      nodePK,
 		ds:          ds,
+		evts:        evts,
+		budget:      sandbox.DefaultBudget,
 		ch:          nil,
 	}
 
@@ -128,9 +153,21 @@ func (app *module) registerChain(context *lua.LState) int {
 
 		}
 
+		// the budget is part of the chain, so that every node aborts the same lua calls:
+		budget := sandbox.DefaultBudget
+		if rawBudget := tb.RawGet(lua.LString("budget")); rawBudget != lua.LNil {
+			number, ok := rawBudget.(lua.LNumber)
+			if !ok || int(number) <= 0 || lua.LNumber(int(number)) != number {
+				return nil, errors.New("the budget of the chain was expected to be a positive integer")
+			}
+
+			budget = int(number)
+		}
+
 		return &chain{
 			namespace: namespace.String(),
 			name:      name.String(),
+			budget:    budget,
 			apps:      apps,
 		}, nil
 	}
@@ -153,12 +190,14 @@ func (app *module) registerChain(context *lua.LState) int {
 
 		chain, chainErr := fromTableToChainFn(l)
 		if chainErr != nil {
-			l.ArgError(1, "the passed table argument is invalid")
+			str := fmt.Sprintf("the passed table argument is invalid: %s", chainErr.Error())
+			l.ArgError(1, str)
 			return 1
 		}
 
 		// add the chain params to the chain:
 		app.ch = chain
+		app.budget = chain.budget
 
 		// set the value:
 		ud.Value = chain
@@ -191,23 +230,56 @@ func (app *module) registerApp(context *lua.LState) int {
 		rterTable := tb.RawGet(lua.LString("router")).(*lua.LUserData)
 		if router, ok := rterTable.Value.(*router); ok {
 
+			// the block lifecycle hooks are optional:
+			hooks := map[string]int{
+				"beginBlock": 1,
+				"endBlock":   1,
+				"onCommit":   2,
+			}
+
+			hookFns := map[string]*lua.LFunction{}
+			for name, amountParams := range hooks {
+				value := tb.RawGet(lua.LString(name))
+				if value == lua.LNil {
+					continue
+				}
+
+				fn, ok := value.(*lua.LFunction)
+				if !ok {
+					str := fmt.Sprintf("the %s hook is not a valid func", name)
+					return nil, errors.New(str)
+				}
+
+				if fn.Proto.NumParameters != uint8(amountParams) {
+					str := fmt.Sprintf("the %s hook is expected to have %d parameters", name, amountParams)
+					return nil, errors.New(str)
+				}
+
+				hookFns[name] = fn
+			}
+
 			beginIndexAsInt, beginIndexAsIntErr := strconv.Atoi(beginIndex.String())
 			if beginIndexAsIntErr != nil {
-				str := fmt.Sprintf("the given beginIndex (%d) is not a valid integer", beginIndex)
+				str := fmt.Sprintf("the given beginIndex (%s) is not a valid integer", beginIndex.String())
 				return nil, errors.New(str)
 			}
 
 			endIndexAsInt, endIndexAsIntErr := strconv.Atoi(endIndex.String())
 			if endIndexAsIntErr != nil {
-				str := fmt.Sprintf("the given beginIndex (%d) is not a valid integer", beginIndex)
+				str := fmt.Sprintf("the given endIndex (%s) is not a valid integer", endIndex.String())
 				return nil, errors.New(str)
 			}
 
 			return &application{
-				version:    version.String(),
-				beginIndex: beginIndexAsInt,
-				endIndex:   endIndexAsInt,
-				router:     router,
+				version:          version.String(),
+				beginIndex:       beginIndexAsInt,
+				endIndex:         endIndexAsInt,
+				router:           router,
+				beginBlock:       hookFns["beginBlock"],
+				endBlock:         hookFns["endBlock"],
+				onCommit:         hookFns["onCommit"],
+				isUpgradable:     lua.LVAsBool(tb.RawGet(lua.LString("upgradable"))),
+				legacySignatures: lua.LVAsBool(tb.RawGet(lua.LString("legacySignatures"))),
 			}, nil
 		}
 
@@ -266,6 +338,16 @@ func (app *module) registerRouter(context *lua.LState) int {
 						routes = append(routes, oneRoute)
 					}
 
+					if natives, ok := oneRouteUD.Value.(nativeRoutes); ok {
+						for _, oneNative := range natives.Routes() {
+							native := oneNative
+							routes = append(routes, &route{
+								pattern: native.Pattern,
+								native:  &native,
+							})
+						}
+					}
+
 				}
 			})
 
@@ -319,8 +401,8 @@ func (app *module) registerRoute(context *lua.LState) int {
 		ud := l.NewUserData()
 
 		amount := l.GetTop()
-		if amount != 3 {
-			l.ArgError(1, "the exists func expected 3 parameters")
+		if amount != 3 && amount != 4 {
+			l.ArgError(1, "the exists func expected 3 or 4 parameters")
 			return 1
 		}
 
@@ -376,6 +458,27 @@ func (app *module) registerRoute(context *lua.LState) int {
 			newRte.saveTrx = luaHandlr
 		}
 
+		// if there is an options table:
+		if amount == 4 {
+			options := l.CheckTable(4)
+			luaSchema := options.RawGetString("schema")
+			if luaSchema != lua.LNil {
+				if rteTypeAsString != "save" {
+					l.ArgError(4, "the schema can only be added to a save route")
+					return 1
+				}
+
+				schema, schemaErr := fromLuaToSchema(luaSchema)
+				if schemaErr != nil {
+					str := fmt.Sprintf("the passed schema is invalid: %s", schemaErr.Error())
+					l.ArgError(4, str)
+					return 1
+				}
+
+				newRte.schema = schema
+			}
+		}
+
 		// set the value:
 		ud.Value = &newRte
 
@@ -399,6 +502,51 @@ func (app *module) registerRoute(context *lua.LState) int {
 
 // Spawn spawns a new blockchain node.  A lua script with the blockchain contract must be executed first
 func (app *module) Spawn() (applications.Node, error) {
+	// create the applications:
+	apps, appsErr := app.Applications()
+	if appsErr != nil {
+		return nil, appsErr
+	}
+
+	// create the blockchain:
+	blkChain := tendermint.SDKFunc.CreateBlockchain(tendermint.CreateBlockchainParams{
+		Namespace: app.ch.namespace,
+		Name:      app.ch.name,
+		ID:        app.instanceID,
+		PrivKey:   app.nodePK,
+	})
+
+	// create the blockchain service:
+	blkChainService := tendermint.SDKFunc.CreateBlockchainService(tendermint.CreateBlockchainServiceParams{
+		RootDirPath: app.dbPath,
+	})
+
+	// save the blockchain:
+	saveBlkChainErr := blkChainService.Save(blkChain)
+	if saveBlkChainErr != nil {
+		return nil, saveBlkChainErr
+	}
+
+	// create the application service:
+	appService := tendermint.SDKFunc.CreateApplicationService()
+
+	// spawn the node:
+	node, nodeErr := appService.Spawn(app.port, nil, app.dbPath, blkChain, apps)
+	if nodeErr != nil {
+		return nil, nodeErr
+	}
+
+	// start the node:
+	startNodeErr := node.Start()
+	if startNodeErr != nil {
+		return nil, startNodeErr
+	}
+
+	return node, nil
+}
+
+// Applications creates the applications of the blockchain, without spawning a node.  A lua script with the blockchain contract must be executed first
+func (app *module) Applications() (applications.Applications, error) {
 	// make sure the chain is set:
 	if app.ch == nil {
 		return nil, errors.New("the chain has not been loaded")
@@ -407,130 +555,59 @@ func (app *module) Spawn() (applications.Node, error) {
 	// create the router data store:
 	routerDS := datastore.SDKFunc.Create()
 
+	// create the data store shared by the applications:
+	store := datastore.SDKFunc.CreateStoredDataStore(datastore.StoredDataStoreParams{
+		FilePath: filepath.Join(app.dbPath, "db.xmn"),
+	})
+
 	appsSlice := []applications.Application{}
 	for _, oneApp := range app.ch.apps {
 		// create the route params:
-		rteParams := []routers.CreateRouteParams{}
-		for _, oneRte := range oneApp.router.rtes {
-			var saveTrx routers.SaveTransactionFn
-			if oneRte.saveTrx != nil {
-				luaSaveTrxFn := oneRte.saveTrx
-				saveTrx = func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
-
-					//replace the datastore:
-					app.replaceDS(store)
-
-					// from:
-					fromAsBytes, fromAsBytesErr := cdc.MarshalBinaryBare(from)
-					if fromAsBytesErr != nil {
-						return nil, fromAsBytesErr
-					}
-
-					pubKeyAsString := hex.EncodeToString(fromAsBytes)
-
-					// params:
-					luaParams := lua.LTable{}
-					for keyname, value := range params {
-						luaParams.RawSet(lua.LString(keyname), lua.LString(value))
-					}
-
-					// json data as string:
-					dataAsString := string(data)
-
-					// sig:
-					sigAsString := sig.String()
-
-					// call the func and return the value:
-					return callLuaTrxFunc(
-						luaSaveTrxFn,
-						app.context,
-						lua.LString(pubKeyAsString),
-						lua.LString(path),
-						&luaParams,
-						lua.LString(dataAsString),
-						lua.LString(sigAsString),
-					)
-				}
+		rteParams := app.createRouteParams(oneApp.router)
+
+		// create the block lifecycle hooks:
+		var beginBlock applications.BeginBlock
+		if oneApp.beginBlock != nil {
+			luaBeginBlockFn := oneApp.beginBlock
+			beginBlock = func(store datastore.DataStore, height int64) error {
+				//replace the datastore:
+				app.replaceDS(store)
+
+				// call the func:
+				_, callErr := callLuaBlockFunc("beginBlock", luaBeginBlockFn, app.context, app.budget, lua.LNumber(height))
+				return callErr
 			}
+		}
 
-			var delTrx routers.DeleteTransactionFn
-			if oneRte.delTrx != nil {
-				luaDelTrxFn := oneRte.delTrx
-				delTrx = func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.TransactionResponse, error) {
-					//replace the datastore:
-					app.replaceDS(store)
-
-					// from:
-					fromAsBytes, fromAsBytesErr := cdc.MarshalBinaryBare(from)
-					if fromAsBytesErr != nil {
-						return nil, fromAsBytesErr
-					}
-
-					pubKeyAsString := hex.EncodeToString(fromAsBytes)
-
-					// params:
-					luaParams := lua.LTable{}
-					for keyname, value := range params {
-						luaParams.RawSet(lua.LString(keyname), lua.LString(value))
-					}
-
-					// sig:
-					sigAsString := sig.String()
-
-					// call the func and return the value:
-					return callLuaTrxFunc(
-						luaDelTrxFn,
-						app.context,
-						lua.LString(pubKeyAsString),
-						lua.LString(path),
-						&luaParams,
-						lua.LString(sigAsString),
-					)
+		var endBlock applications.EndBlock
+		if oneApp.endBlock != nil {
+			luaEndBlockFn := oneApp.endBlock
+			endBlock = func(store datastore.DataStore, height int64) ([]applications.Validator, error) {
+				//replace the datastore:
+				app.replaceDS(store)
+
+				// call the func:
+				value, callErr := callLuaBlockFunc("endBlock", luaEndBlockFn, app.context, app.budget, lua.LNumber(height))
+				if callErr != nil {
+					return nil, callErr
 				}
-			}
 
-			var queryTrx routers.QueryFn
-			if oneRte.queryTrx != nil {
-				luaQueryFn := oneRte.queryTrx
-				queryTrx = func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
-					//replace the datastore:
-					app.replaceDS(store)
-
-					// from:
-					fromAsBytes, fromAsBytesErr := cdc.MarshalBinaryBare(from)
-					if fromAsBytesErr != nil {
-						return nil, fromAsBytesErr
-					}
-
-					pubKeyAsString := hex.EncodeToString(fromAsBytes)
+				// convert the returned validator updates:
+				return fromLuaToValidators(value)
+			}
+		}
 
-					// params:
-					luaParams := lua.LTable{}
-					for keyname, value := range params {
-						luaParams.RawSet(lua.LString(keyname), lua.LString(value))
-					}
+		var onCommit applications.OnCommit
+		if oneApp.onCommit != nil {
+			luaOnCommitFn := oneApp.onCommit
+			onCommit = func(store datastore.DataStore, height int64, appHash []byte) error {
+				//replace the datastore:
+				app.replaceDS(store)
 
-					// sig:
-					sigAsString := sig.String()
-
-					// call the func and return the value:
-					return callLuaQueryFunc(
-						luaQueryFn,
-						app.context,
-						lua.LString(pubKeyAsString),
-						lua.LString(path),
-						&luaParams,
-						lua.LString(sigAsString),
-					)
-				}
+				// call the func:
+				_, callErr := callLuaBlockFunc("onCommit", luaOnCommitFn, app.context, app.budget, lua.LNumber(height), lua.LString(hex.EncodeToString(appHash)))
+				return callErr
 			}
-
-			rteParams = append(rteParams, routers.CreateRouteParams{
-				Pattern:  oneRte.pattern,
-				SaveTrx:  saveTrx,
-				DelTrx:   delTrx,
-				QueryTrx: queryTrx,
-			})
 		}
 
 		// setup the router role key:
@@ -543,8 +620,22 @@ func (app *module) Spawn() (applications.Node, error) {
 			routerDS.Roles().EnableWriteAccess(routerRoleKey, ".*")
 		}
 
+		// an upgradable application routes the requests to the code of its latest activated upgrade:
+		var rter routers.Router
+		var upgr *upgrader
+		if oneApp.isUpgradable {
+			createdUpgr, upgrErr := createUpgrader(app, oneApp.version, routerDS, routerRoleKey, rteParams, store.DataStore())
+			if upgrErr != nil {
+				return nil, upgrErr
+			}
+
+			upgr = createdUpgr
+			rter = upgr
+			beginBlock = upgr.wrapBeginBlock(beginBlock)
+		}
+
 		// create one application and put it in the list:
-		appsSlice = append(appsSlice, applications.SDKFunc.CreateApplication(applications.CreateApplicationParams{
+		createdApp := applications.SDKFunc.CreateApplication(applications.CreateApplicationParams{
 			Namespace:      app.ch.namespace,
 			Name:           app.ch.name,
 			ID:             app.instanceID,
@@ -552,13 +643,25 @@ func (app *module) Spawn() (applications.Node, error) {
 			ToBlockIndex:   int64(oneApp.endIndex),
 			Version:        oneApp.version,
 			DirPath:        app.dbPath,
+			Store:          store,
 			RouterParams: routers.CreateRouterParams{
 				DataStore:  routerDS,
 				RoleKey:    routerRoleKey,
 				RtesParams: rteParams,
 			},
-		}))
+			Router:           rter,
+			BeginBlock:       beginBlock,
+			EndBlock:         endBlock,
+			OnCommit:         onCommit,
+			LegacySignatures: oneApp.legacySignatures,
+		})
+
+		// the upgrades saved before the first block of the node are compared to the last committed height:
+		if upgr != nil {
+			upgr.height = createdApp.GetBlockIndex()
+		}
 
+		appsSlice = append(appsSlice, createdApp)
 	}
 
 	// create the applications:
@@ -566,52 +669,161 @@ func (app *module) Spawn() (applications.Node, error) {
 		Apps: appsSlice,
 	})
 
-	// create the blockchain:
-	blkChain := tendermint.SDKFunc.CreateBlockchain(tendermint.CreateBlockchainParams{
-		Namespace: app.ch.namespace,
-		Name:      app.ch.name,
-		ID:        app.instanceID,
-		PrivKey:   app.nodePK,
-	})
+	return apps, nil
+}
 
-	// create the blockchain service:
-	blkChainService := tendermint.SDKFunc.CreateBlockchainService(tendermint.CreateBlockchainServiceParams{
-		RootDirPath: app.dbPath,
-	})
+// createRouteParams converts the routes of a lua router to route params
+func (app *module) createRouteParams(rtr *router) []routers.CreateRouteParams {
+	rteParams := []routers.CreateRouteParams{}
+	for _, oneRte := range rtr.rtes {
+		// the native routes are added as is:
+		if oneRte.native != nil {
+			rteParams = append(rteParams, *oneRte.native)
+			continue
+		}
 
-	// save the blockchain:
-	saveBlkChainErr := blkChainService.Save(blkChain)
-	if saveBlkChainErr != nil {
-		return nil, saveBlkChainErr
-	}
+		var saveTrx routers.SaveTransactionFn
+		if oneRte.saveTrx != nil {
+			luaSaveTrxFn := oneRte.saveTrx
+			saveTrx = func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
 
-	// create the application service:
-	appService := tendermint.SDKFunc.CreateApplicationService()
+				//replace the datastore:
+				app.replaceDS(store)
 
-	// spawn the node:
-	node, nodeErr := appService.Spawn(app.port, app.dbPath, blkChain, apps)
-	if nodeErr != nil {
-		return nil, nodeErr
-	}
+				// from:
+				fromAsBytes, fromAsBytesErr := cdc.MarshalBinaryBare(from)
+				if fromAsBytesErr != nil {
+					return nil, fromAsBytesErr
+				}
 
-	// start the node:
-	startNodeErr := node.Start()
-	if startNodeErr != nil {
-		return nil, startNodeErr
+				pubKeyAsString := hex.EncodeToString(fromAsBytes)
+
+				// params:
+				luaParams := fromParamsToLua(params)
+
+				// json data as string:
+				dataAsString := string(data)
+
+				// sig:
+				sigAsString := sig.String()
+
+				// call the func and return the value:
+				return callLuaTrxFunc(
+					luaSaveTrxFn,
+					app.context,
+					app.evts,
+					app.budget,
+					lua.LString(pubKeyAsString),
+					lua.LString(path),
+					luaParams,
+					lua.LString(dataAsString),
+					lua.LString(sigAsString),
+				)
+			}
+		}
+
+		var delTrx routers.DeleteTransactionFn
+		if oneRte.delTrx != nil {
+			luaDelTrxFn := oneRte.delTrx
+			delTrx = func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.TransactionResponse, error) {
+				//replace the datastore:
+				app.replaceDS(store)
+
+				// from:
+				fromAsBytes, fromAsBytesErr := cdc.MarshalBinaryBare(from)
+				if fromAsBytesErr != nil {
+					return nil, fromAsBytesErr
+				}
+
+				pubKeyAsString := hex.EncodeToString(fromAsBytes)
+
+				// params:
+				luaParams := fromParamsToLua(params)
+
+				// sig:
+				sigAsString := sig.String()
+
+				// call the func and return the value:
+				return callLuaTrxFunc(
+					luaDelTrxFn,
+					app.context,
+					app.evts,
+					app.budget,
+					lua.LString(pubKeyAsString),
+					lua.LString(path),
+					luaParams,
+					lua.LString(sigAsString),
+				)
+			}
+		}
+
+		var queryTrx routers.QueryFn
+		if oneRte.queryTrx != nil {
+			luaQueryFn := oneRte.queryTrx
+			queryTrx = func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+				//replace the datastore:
+				app.replaceDS(store)
+
+				// from:
+				fromAsBytes, fromAsBytesErr := cdc.MarshalBinaryBare(from)
+				if fromAsBytesErr != nil {
+					return nil, fromAsBytesErr
+				}
+
+				pubKeyAsString := hex.EncodeToString(fromAsBytes)
+
+				// params:
+				luaParams := fromParamsToLua(params)
+
+				// sig:
+				sigAsString := sig.String()
+
+				// call the func and return the value:
+				return callLuaQueryFunc(
+					luaQueryFn,
+					app.context,
+					app.budget,
+					lua.LString(pubKeyAsString),
+					lua.LString(path),
+					luaParams,
+					lua.LString(sigAsString),
+				)
+			}
+		}
+
+		rteParams = append(rteParams, routers.CreateRouteParams{
+			Pattern:  oneRte.pattern,
+			SaveTrx:  saveTrx,
+			DelTrx:   delTrx,
+			QueryTrx: queryTrx,
+			Schema:   oneRte.schema,
+		})
 	}
 
-	return node, nil
+	return rteParams
 }
 
-func callLuaQueryFunc(fn *lua.LFunction, context *lua.LState, args ...lua.LValue) (routers.QueryResponse, error) {
+func callLuaQueryFunc(fn *lua.LFunction, context *lua.LState, budget int, args ...lua.LValue) (routers.QueryResponse, error) {
 	luaP := lua.P{
 		Fn:      fn,
 		NRet:    1,
 		Protect: true,
 	}
 
-	// call the func:
-	callErr := context.CallByParam(luaP, args...)
+	// call the func, in its budget:
+	meter := sandbox.SDKFunc.CreateMeter(sandbox.CreateMeterParams{
+		Context: context,
+		Budget:  budget,
+	})
+
+	callErr := meter.Call(luaP, args...)
+	if meter.IsExhausted() {
+		return routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+			Code: routers.ServerError,
+			Log:  fmt.Sprintf("the query handler has been aborted since it exhausted its budget of %d lua instructions", budget),
+		}), nil
+	}
+
 	if callErr != nil {
 		return nil, callErr
 	}
@@ -648,15 +860,33 @@ func callLuaQueryFunc(fn *lua.LFunction, context *lua.LState, args ...lua.LValue
 	return nil, errors.New("the query response is not a valid table")
 }
 
-func callLuaTrxFunc(fn *lua.LFunction, context *lua.LState, args ...lua.LValue) (routers.TransactionResponse, error) {
+func callLuaTrxFunc(fn *lua.LFunction, context *lua.LState, evts events_module.Events, budget int, args ...lua.LValue) (routers.TransactionResponse, error) {
 	luaP := lua.P{
 		Fn:      fn,
 		NRet:    1,
 		Protect: true,
 	}
 
-	// call the func:
-	callErr := context.CallByParam(luaP, args...)
+	// forget the events emitted outside of a transaction handler:
+	evts.Flush()
+
+	// call the func, in its budget:
+	meter := sandbox.SDKFunc.CreateMeter(sandbox.CreateMeterParams{
+		Context: context,
+		Budget:  budget,
+	})
+
+	callErr := meter.Call(luaP, args...)
+
+	// the events emitted by the handler:
+	evtTags := evts.Flush()
+	if meter.IsExhausted() {
+		return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+			Code: routers.ServerError,
+			Log:  fmt.Sprintf("the transaction handler has been aborted since it exhausted its budget of %d lua instructions", budget),
+		}), nil
+	}
+
 	if callErr != nil {
 		return nil, callErr
 	}
@@ -677,8 +907,8 @@ func callLuaTrxFunc(fn *lua.LFunction, context *lua.LState, args ...lua.LValue)
 			return nil, errors.New(str)
 		}
 
+		tags := map[string][]byte{}
 		if gazUsedAsLua != lua.LNil && luaTags != lua.LNil {
-			tags := map[string][]byte{}
 			if rawTags, ok := luaTags.(*lua.LTable); ok {
 				rawTags.ForEach(func(key lua.LValue, luaKeyValueTable lua.LValue) {
 					if rawKeyValueTable, ok := luaKeyValueTable.(*lua.LTable); ok {
@@ -689,13 +919,30 @@ func callLuaTrxFunc(fn *lua.LFunction, context *lua.LState, args ...lua.LValue)
 				})
 
 			}
+		}
 
-			gazUsed, gazUsedErr := strconv.Atoi(gazUsedAsLua.String())
+		// add the events to the tags:
+		for key, value := range evtTags {
+			if _, ok := tags[key]; ok {
+				str := fmt.Sprintf("the tag (%s) of an emitted event conflicts with a tag of the return table", key)
+				return nil, errors.New(str)
+			}
+
+			tags[key] = value
+		}
+
+		gazUsed := 0
+		if gazUsedAsLua != lua.LNil {
+			parsedGazUsed, gazUsedErr := strconv.Atoi(gazUsedAsLua.String())
 			if gazUsedErr != nil {
 				str := fmt.Sprintf("the gazUsed (%s) in the return table is not a valid integer", gazUsedAsLua.String())
 				return nil, errors.New(str)
 			}
 
+			gazUsed = parsedGazUsed
+		}
+
+		if len(tags) > 0 {
 			return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
 				Code:    code,
 				Log:     log.String(),
@@ -705,14 +952,151 @@ func callLuaTrxFunc(fn *lua.LFunction, context *lua.LState, args ...lua.LValue)
 		}
 
 		return routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
-			Code: code,
-			Log:  log.String(),
+			Code:    code,
+			Log:     log.String(),
+			GazUsed: int64(gazUsed),
 		}), nil
 	}
 
 	return nil, errors.New("the transaction response is not a valid table")
 }
 
+func callLuaBlockFunc(name string, fn *lua.LFunction, context *lua.LState, budget int, args ...lua.LValue) (lua.LValue, error) {
+	luaP := lua.P{
+		Fn:      fn,
+		NRet:    1,
+		Protect: true,
+	}
+
+	// call the func, in its budget:
+	meter := sandbox.SDKFunc.CreateMeter(sandbox.CreateMeterParams{
+		Context: context,
+		Budget:  budget,
+	})
+
+	callErr := meter.Call(luaP, args...)
+	if meter.IsExhausted() {
+		str := fmt.Sprintf("the %s hook has been aborted since it exhausted its budget of %d lua instructions", name, budget)
+		return nil, errors.New(str)
+	}
+
+	if callErr != nil {
+		return nil, callErr
+	}
+
+	// retrieve the returned value:
+	value := context.Get(-1)
+	context.Pop(1)
+	return value, nil
+}
+
+func fromLuaToValidators(value lua.LValue) ([]applications.Validator, error) {
+	out := []applications.Validator{}
+	if value == lua.LNil {
+		return out, nil
+	}
+
+	luaVals, ok := value.(*lua.LTable)
+	if !ok {
+		return nil, errors.New("the endBlock hook was expected to return a table of validator updates")
+	}
+
+	var valErr error
+	luaVals.ForEach(func(key lua.LValue, luaValue lua.LValue) {
+		if valErr != nil {
+			return
+		}
+
+		luaVal, ok := luaValue.(*lua.LTable)
+		if !ok {
+			valErr = errors.New("the validator update was expected to be a table")
+			return
+		}
+
+		// the pubKey is the hex encoded ed25519 public key of the validator node:
+		pubKeyAsString := luaVal.RawGetString("pubKey").String()
+		pubKeyAsBytes, pubKeyAsBytesErr := hex.DecodeString(pubKeyAsString)
+		if pubKeyAsBytesErr != nil || len(pubKeyAsBytes) != ed25519.PubKeyEd25519Size {
+			str := fmt.Sprintf("the validator pubKey (%s) is not a valid hex encoded ed25519 public key", pubKeyAsString)
+			valErr = errors.New(str)
+			return
+		}
+
+		powerAsLua := luaVal.RawGetString("power")
+		power, powerErr := strconv.Atoi(powerAsLua.String())
+		if powerErr != nil || power < 0 {
+			str := fmt.Sprintf("the validator power (%s) is not a valid positive integer", powerAsLua.String())
+			valErr = errors.New(str)
+			return
+		}
+
+		var ip net.IP
+		if luaIP, ok := luaVal.RawGetString("ip").(lua.LString); ok {
+			ip = net.ParseIP(string(luaIP))
+		}
+
+		pubKey := ed25519.PubKeyEd25519{}
+		copy(pubKey[:], pubKeyAsBytes)
+		out = append(out, applications.SDKFunc.CreateValidator(applications.CreateValidatorParams{
+			IP:     ip,
+			PubKey: &pubKey,
+			Power:  int64(power),
+		}))
+	})
+
+	if valErr != nil {
+		return nil, valErr
+	}
+
+	return out, nil
+}
+
+func fromLuaToSchema(value lua.LValue) (out routers.Schema, err error) {
+	// the schema is either a json string or a lua table:
+	js := []byte(value.String())
+	if tb, ok := value.(*lua.LTable); ok {
+		encoded, encodedErr := luajson.Encode(tb)
+		if encodedErr != nil {
+			return nil, encodedErr
+		}
+
+		js = encoded
+	} else if value.Type() != lua.LTString {
+		str := fmt.Sprintf("the schema was expected to be a table or a json string, %s given", value.Type().String())
+		return nil, errors.New(str)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			str := fmt.Sprintf("%v", r)
+			err = errors.New(str)
+		}
+	}()
+
+	out = routers.SDKFunc.CreateSchema(routers.CreateSchemaParams{
+		JS: js,
+	})
+
+	return
+}
+
+// fromParamsToLua converts the params to a table, in the order of their keynames so that pairs iterates them in the same order on every node
+func fromParamsToLua(params map[string]string) *lua.LTable {
+	keynames := []string{}
+	for keyname := range params {
+		keynames = append(keynames, keyname)
+	}
+
+	sort.Strings(keynames)
+
+	out := lua.LTable{}
+	for _, oneKeyname := range keynames {
+		out.RawSet(lua.LString(oneKeyname), lua.LString(params[oneKeyname]))
+	}
+
+	return &out
+}
+
 func (app *module) replaceDS(store datastore.DataStore) *module {
 	app.ds.Replace(store)
 	return app
@@ -1,6 +1,9 @@
 package chain
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"math/rand"
 	"os"
 	"testing"
@@ -9,8 +12,13 @@ import (
 	ed25519 "github.com/tendermint/tendermint/crypto/ed25519"
 	crypto "github.com/xmnservices/xmnsuite/crypto"
 	datastore "github.com/xmnservices/xmnsuite/datastore"
+	crypto_module "github.com/xmnservices/xmnsuite/modules/crypto"
 	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	events_module "github.com/xmnservices/xmnsuite/modules/events"
 	json_module "github.com/xmnservices/xmnsuite/modules/json"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	uuid_module "github.com/xmnservices/xmnsuite/modules/uuid"
+	routers "github.com/xmnservices/xmnsuite/routers"
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -45,8 +53,13 @@ func TestModule_Success(t *testing.T) {
 		Datastore: ds,
 	})
 
+	// create the events module:
+	evts := events_module.SDKFunc.Create(events_module.CreateParams{
+		Context: context,
+	})
+
 	// create module:
-	module := createModule(context, dbPath, port, &instanceID, rootPubKeys, nodePK, dsMod)
+	module := createModule(context, dbPath, port, &instanceID, rootPubKeys, nodePK, dsMod, evts)
 
 	//execute the script:
 	doFileErr := context.DoFile(scriptPath)
@@ -62,3 +75,533 @@ func TestModule_Success(t *testing.T) {
 		return
 	}
 }
+
+func TestModule_withBlockHooks_Success(t *testing.T) {
+	// variables:
+	dbPath := "./test_files"
+	instanceID := uuid.NewV4()
+	nodePK := ed25519.GenPrivKey()
+	rootPubKeys := []crypto.PublicKey{}
+	scriptPath := "tests/lua/hooks.lua"
+	defer func() {
+		os.RemoveAll(dbPath)
+	}()
+
+	//create lua state:
+	context := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// create the datastore module:
+	dsMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+		Context:   context,
+		Datastore: datastore.SDKFunc.Create(),
+	})
+
+	// create the events module:
+	evts := events_module.SDKFunc.Create(events_module.CreateParams{
+		Context: context,
+	})
+
+	// create module:
+	module := createModule(context, dbPath, 0, &instanceID, rootPubKeys, nodePK, dsMod, evts)
+
+	//execute the script:
+	doFileErr := context.DoFile(scriptPath)
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+
+	// retrieve the application:
+	apps, appsErr := module.Applications()
+	if appsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", appsErr.Error())
+		return
+	}
+
+	app, appErr := apps.RetrieveByBlockIndex(0)
+	if appErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", appErr.Error())
+		return
+	}
+
+	// begin the block:
+	beginBlockErr := app.BeginBlock(1)
+	if beginBlockErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", beginBlockErr.Error())
+		return
+	}
+
+	if dsMod.Get().Keys().Retrieve("last-begin-block") != "1" {
+		t.Errorf("the beginBlock hook was expected to save the height of the block")
+		return
+	}
+
+	// end the block:
+	vals, valsErr := app.EndBlock(1)
+	if valsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", valsErr.Error())
+		return
+	}
+
+	if len(vals) != 1 || vals[0].Power() != 10 {
+		t.Errorf("the endBlock hook was expected to return 1 validator update with a power of %d", 10)
+		return
+	}
+
+	// commit:
+	commitResp := app.Commit()
+	if context.GetGlobal("committedHash").String() != hex.EncodeToString(commitResp.AppHash()) {
+		t.Errorf("the onCommit hook was expected to receive the app hash of the committed block")
+		return
+	}
+}
+
+func TestModule_withUpgrade_Success(t *testing.T) {
+	// variables:
+	dbPath := "./test_files"
+	instanceID := uuid.NewV4()
+	nodePK := ed25519.GenPrivKey()
+	rootPK := crypto.SDKFunc.GenPK()
+	rootPubKeys := []crypto.PublicKey{
+		rootPK.PublicKey(),
+	}
+	scriptPath := "tests/lua/upgrades.lua"
+	defer func() {
+		os.RemoveAll(dbPath)
+	}()
+
+	//create lua state:
+	context := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// create the datastore module:
+	dsMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+		Context:   context,
+		Datastore: datastore.SDKFunc.Create(),
+	})
+
+	// create the events module:
+	evts := events_module.SDKFunc.Create(events_module.CreateParams{
+		Context: context,
+	})
+
+	// create module:
+	module := createModule(context, dbPath, 0, &instanceID, rootPubKeys, nodePK, dsMod, evts)
+
+	//execute the script:
+	doFileErr := context.DoFile(scriptPath)
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+
+	// retrieve the application:
+	apps, appsErr := module.Applications()
+	if appsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", appsErr.Error())
+		return
+	}
+
+	app, appErr := apps.RetrieveByBlockIndex(0)
+	if appErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", appErr.Error())
+		return
+	}
+
+	queryVersionFn := func() string {
+		ptr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+			From: rootPK.PublicKey(),
+			Path: "/version",
+		})
+
+		resp := app.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+			Ptr: ptr,
+			Sig: rootPK.SignBytes(routers.QueryDomain, []byte(ptr.Hash())),
+		}))
+
+		return string(resp.Value())
+	}
+
+	// begin the first block:
+	beginBlockErr := app.BeginBlock(1)
+	if beginBlockErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", beginBlockErr.Error())
+		return
+	}
+
+	saveUpgradeFn := func(height int64, code string) bool {
+		js, jsErr := json.Marshal(upgrade{
+			Version: fmt.Sprintf("17.03.%d", 8+height),
+			Height:  height,
+			Code:    code,
+		})
+
+		if jsErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", jsErr.Error())
+			return false
+		}
+
+		res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+			ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+				From: rootPK.PublicKey(),
+				Path: "/upgrades",
+			}),
+			Data: js,
+		})
+
+		trxResp := app.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+			Res: res,
+			Sig: rootPK.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+		}), []byte(res.Hash()))
+
+		if trxResp.Code() != routers.IsSuccessful {
+			t.Errorf("the transaction was expected to be successful, log: %s", trxResp.Log())
+			return false
+		}
+
+		return true
+	}
+
+	// save the upgrade:
+	isSaved := saveUpgradeFn(2, `
+		local chain = require("chain")
+		leaked = true
+		return chain.router().new({
+			key = "this-is-the-router-key",
+			routes = {
+				chain.route().new("retrieve", "/version", function(from, path, params, sig)
+					return {code = 0, log = "success", key = path, value = "2"}
+				end),
+			}
+		})
+	`)
+
+	if !isSaved {
+		return
+	}
+
+	// the upgrade is not active before its height:
+	if queryVersionFn() != "1" {
+		t.Errorf("the code of the application was expected to be the original code before the height of the upgrade")
+		return
+	}
+
+	// begin the block at the height of the upgrade:
+	app.Commit()
+	beginBlockErr = app.BeginBlock(2)
+	if beginBlockErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", beginBlockErr.Error())
+		return
+	}
+
+	if queryVersionFn() != "2" {
+		t.Errorf("the code of the application was expected to be the upgraded code at the height of the upgrade")
+		return
+	}
+
+	if context.GetGlobal("leaked") != lua.LNil {
+		t.Errorf("the globals of the upgrade were expected to stay in its own environment")
+		return
+	}
+
+	// save an upgrade that cannot be activated:
+	isSaved = saveUpgradeFn(3, `error("this upgrade cannot be activated")`)
+	if !isSaved {
+		return
+	}
+
+	app.Commit()
+	beginBlockErr = app.BeginBlock(3)
+	if beginBlockErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", beginBlockErr.Error())
+		return
+	}
+
+	if queryVersionFn() != "2" {
+		t.Errorf("the code of the application was expected to stay the same when the upgrade cannot be activated")
+		return
+	}
+
+	ptr := routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+		From: rootPK.PublicKey(),
+		Path: "/upgrades/3",
+	})
+
+	resp := app.Query(routers.SDKFunc.CreateQueryRequest(routers.CreateQueryRequestParams{
+		Ptr: ptr,
+		Sig: rootPK.SignBytes(routers.QueryDomain, []byte(ptr.Hash())),
+	}))
+
+	failed := new(upgrade)
+	jsErr := json.Unmarshal(resp.Value(), failed)
+	if jsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", jsErr.Error())
+		return
+	}
+
+	if failed.Error == "" {
+		t.Errorf("the error of the upgrade was expected to be saved with the upgrade")
+		return
+	}
+
+	// restart the node, which activates the latest activated upgrade again:
+	app.Commit()
+	restartedContext := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer restartedContext.Close()
+
+	restartedDSMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+		Context:   restartedContext,
+		Datastore: datastore.SDKFunc.Create(),
+	})
+
+	restartedEvts := events_module.SDKFunc.Create(events_module.CreateParams{
+		Context: restartedContext,
+	})
+
+	restartedModule := createModule(restartedContext, dbPath, 0, &instanceID, rootPubKeys, nodePK, restartedDSMod, restartedEvts)
+
+	doFileErr = restartedContext.DoFile(scriptPath)
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+
+	restartedApps, restartedAppsErr := restartedModule.Applications()
+	if restartedAppsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", restartedAppsErr.Error())
+		return
+	}
+
+	app, appErr = restartedApps.RetrieveByBlockIndex(3)
+	if appErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", appErr.Error())
+		return
+	}
+
+	if queryVersionFn() != "2" {
+		t.Errorf("the code of the application was expected to be the upgraded code after the node restarted")
+		return
+	}
+}
+
+func TestModule_withRandomFuncsInHandler_returnsError(t *testing.T) {
+	// variables:
+	dbPath := "./test_files"
+	instanceID := uuid.NewV4()
+	nodePK := ed25519.GenPrivKey()
+	rootPK := crypto.SDKFunc.GenPK()
+	rootPubKeys := []crypto.PublicKey{
+		rootPK.PublicKey(),
+	}
+	scriptPath := "tests/lua/random.lua"
+	defer func() {
+		os.RemoveAll(dbPath)
+	}()
+
+	//create lua state:
+	context := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// create the datastore module:
+	dsMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+		Context:   context,
+		Datastore: datastore.SDKFunc.Create(),
+	})
+
+	// create the events module:
+	evts := events_module.SDKFunc.Create(events_module.CreateParams{
+		Context: context,
+	})
+
+	// create the crypto and uuid modules:
+	crypto_module.SDKFunc.Create(crypto_module.CreateParams{
+		Context: context,
+	})
+
+	uuid_module.SDKFunc.Create(uuid_module.CreateParams{
+		Context: context,
+	})
+
+	// create module:
+	module := createModule(context, dbPath, 0, &instanceID, rootPubKeys, nodePK, dsMod, evts)
+
+	//execute the script:
+	doFileErr := context.DoFile(scriptPath)
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+
+	// retrieve the application:
+	apps, appsErr := module.Applications()
+	if appsErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", appsErr.Error())
+		return
+	}
+
+	app, appErr := apps.RetrieveByBlockIndex(0)
+	if appErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", appErr.Error())
+		return
+	}
+
+	transactFn := func(kind string, data string) routers.TransactionResponse {
+		res := routers.SDKFunc.CreateResource(routers.CreateResourceParams{
+			ResPtr: routers.SDKFunc.CreateResourcePointer(routers.CreateResourcePointerParams{
+				From: rootPK.PublicKey(),
+				Path: fmt.Sprintf("/random/%s", kind),
+			}),
+			Data: []byte(data),
+		})
+
+		return app.Transact(routers.SDKFunc.CreateTransactionRequest(routers.CreateTransactionRequestParams{
+			Res: res,
+			Sig: rootPK.SignBytes(routers.SaveTransactionDomain, []byte(res.Hash())),
+		}), []byte(res.Hash()))
+	}
+
+	// the random funcs are rejected in the handlers:
+	randomKinds := map[string]string{
+		"uuid":      "data",
+		"privkey":   "data",
+		"encryptto": rootPK.PublicKey().String(),
+		"encrypt":   "data",
+		"decrypt": crypto.SDKFunc.Encrypt(crypto.EncryptParams{
+			Pass: []byte("this is a password"),
+			Msg:  []byte("data"),
+		}),
+	}
+
+	for oneKind, oneData := range randomKinds {
+		trxResp := transactFn(oneKind, oneData)
+		if trxResp.Code() == routers.IsSuccessful {
+			t.Errorf("the transaction that calls a random func (%s) was expected to fail", oneKind)
+			return
+		}
+	}
+
+	// the deterministic funcs are allowed in the handlers:
+	trxResp := transactFn("givenuuid", "data")
+	if trxResp.Code() != routers.IsSuccessful {
+		t.Errorf("the transaction was expected to be successful, log: %s", trxResp.Log())
+		return
+	}
+}
+
+func TestModule_withBudget_Success(t *testing.T) {
+	// variables:
+	instanceID := uuid.NewV4()
+	nodePK := ed25519.GenPrivKey()
+	budgets := map[string]int{
+		"":              sandbox.DefaultBudget,
+		"budget = 500,": 500,
+	}
+
+	for oneField, oneBudget := range budgets {
+		context := lua.NewState()
+		defer context.Close()
+
+		dsMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+			Context:   context,
+			Datastore: datastore.SDKFunc.Create(),
+		})
+
+		evts := events_module.SDKFunc.Create(events_module.CreateParams{
+			Context: context,
+		})
+
+		mod := createModule(context, "./test_files", 0, &instanceID, []crypto.PublicKey{}, nodePK, dsMod, evts)
+
+		//execute:
+		doErr := context.DoString(fmt.Sprintf(`
+			local chain = require("chain")
+			chain.chain().load({namespace = "xmn", name = "budget", %s apps = {}})
+		`, oneField))
+
+		if doErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+			return
+		}
+
+		if mod.(*module).budget != oneBudget {
+			t.Errorf("the budget was expected to be %d, %d returned", oneBudget, mod.(*module).budget)
+			return
+		}
+	}
+}
+
+func TestModule_withInvalidBudget_returnsError(t *testing.T) {
+	// variables:
+	instanceID := uuid.NewV4()
+	nodePK := ed25519.GenPrivKey()
+	invalids := []string{
+		"0",
+		"-10",
+		"1.5",
+		"'1000'",
+	}
+
+	for _, oneInvalid := range invalids {
+		context := lua.NewState()
+		defer context.Close()
+
+		dsMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+			Context:   context,
+			Datastore: datastore.SDKFunc.Create(),
+		})
+
+		evts := events_module.SDKFunc.Create(events_module.CreateParams{
+			Context: context,
+		})
+
+		createModule(context, "./test_files", 0, &instanceID, []crypto.PublicKey{}, nodePK, dsMod, evts)
+
+		//execute:
+		doErr := context.DoString(fmt.Sprintf(`
+			local chain = require("chain")
+			chain.chain().load({namespace = "xmn", name = "budget", budget = %s, apps = {}})
+		`, oneInvalid))
+
+		if doErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (budget: %s)", oneInvalid)
+			return
+		}
+	}
+}
+
+func TestFromParamsToLua_isOrdered(t *testing.T) {
+	// variables:
+	params := map[string]string{
+		"zeta":  "1",
+		"alpha": "2",
+		"mu":    "3",
+		"beta":  "4",
+	}
+
+	//execute:
+	tb := fromParamsToLua(params)
+
+	keynames := []string{}
+	tb.ForEach(func(keyname lua.LValue, value lua.LValue) {
+		keynames = append(keynames, keyname.String())
+	})
+
+	expected := []string{"alpha", "beta", "mu", "zeta"}
+	if fmt.Sprintf("%v", keynames) != fmt.Sprintf("%v", expected) {
+		t.Errorf("the params were expected to be iterated in order (%v), returned: %v", expected, keynames)
+		return
+	}
+}
@@ -6,12 +6,14 @@ import (
 	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
 	crypto "github.com/xmnservices/xmnsuite/crypto"
 	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	events_module "github.com/xmnservices/xmnsuite/modules/events"
 	lua "github.com/yuin/gopher-lua"
 )
 
 // Chain represents the chain module
 type Chain interface {
 	Spawn() (applications.Node, error)
+	Applications() (applications.Applications, error)
 }
 
 // CreateParams represents the create params
@@ -23,6 +25,7 @@ type CreateParams struct {
 	RootPubKeys []crypto.PublicKey
 	NodePK      tcrypto.PrivKey
 	Datastore   datastore_module.Datastore
+	Events      events_module.Events
 }
 
 // SDKFunc represents the chain module SDK func
@@ -30,7 +33,14 @@ var SDKFunc = struct {
 	Create func(params CreateParams) Chain
 }{
 	Create: func(params CreateParams) Chain {
-		out := createModule(params.Context, params.DBPath, params.Port, params.ID, params.RootPubKeys, params.NodePK, params.Datastore)
+		// the events module is preloaded if it was not given:
+		if params.Events == nil {
+			params.Events = events_module.SDKFunc.Create(events_module.CreateParams{
+				Context: params.Context,
+			})
+		}
+
+		out := createModule(params.Context, params.DBPath, params.Port, params.ID, params.RootPubKeys, params.NodePK, params.Datastore, params.Events)
 		return out
 	},
 }
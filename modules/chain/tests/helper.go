@@ -9,6 +9,7 @@ import (
 	chain_module "github.com/xmnservices/xmnsuite/modules/chain"
 	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
 	json_module "github.com/xmnservices/xmnsuite/modules/json"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -41,7 +42,10 @@ func ExecuteForTests(context *lua.LState, scriptPath string, dbPath string, root
 	})
 
 	//execute the script:
-	doFileErr := context.DoFile(scriptPath)
+	doFileErr := sandbox.SDKFunc.DoFile(sandbox.DoFileParams{
+		Context: context,
+		Path:    scriptPath,
+	})
 	if doFileErr != nil {
 		return nil, doFileErr
 	}
@@ -0,0 +1,48 @@
+-- load the modules:
+require("datastore")
+local chain = require("chain")
+
+-- the app hash of the latest committed block:
+committedHash = nil
+
+function retrieveNothing(from, path, params, sig)
+    return {
+        code = 1,
+        log="not found",
+        key=path,
+        value=null
+    }
+end
+
+chain.chain().load({
+    namespace = "xmn",
+    name = "hooks",
+    apps = {
+        chain.app().new({
+            version = "17.03.09",
+            beginBlockIndex = 0,
+            endBlockIndex = -1,
+            router = chain.router().new({
+                key = "this-is-the-router-key",
+                routes = {
+                    chain.route().new("retrieve", "/nothing", retrieveNothing),
+                }
+            }),
+            beginBlock = function(height)
+                local x = keys.load()
+                x:save("last-begin-block", tostring(height))
+            end,
+            endBlock = function(height)
+                return {
+                    {
+                        pubKey = "5a9b2c1de3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0",
+                        power = height * 10,
+                    },
+                }
+            end,
+            onCommit = function(height, appHash)
+                committedHash = appHash
+            end,
+        })
+    }
+})
@@ -0,0 +1,53 @@
+-- load the modules:
+require("datastore")
+require("crypto")
+local uuid = require("uuid")
+local chain = require("chain")
+
+-- the funcs the handler calls, by kind:
+local calls = {
+    uuid = function(data)
+        return uuid.new():string()
+    end,
+    givenuuid = function()
+        return uuid.new("0b6d0b5e-2b63-4e36-a47c-9ad2e8c4b3a1"):string()
+    end,
+    privkey = function()
+        return privkey.new():pubKey()
+    end,
+    encryptto = function(data)
+        return pubkey.encryptTo(data, "this is a message")
+    end,
+    encrypt = function(data)
+        return cipher.encrypt("this is a password", data)
+    end,
+    decrypt = function(data)
+        return cipher.decrypt("this is a password", data)
+    end,
+}
+
+function saveRandom(from, path, params, data, sig)
+    local value = calls[params.kind](data)
+    return {
+        code = 0,
+        log = value,
+    }
+end
+
+chain.chain().load({
+    namespace = "xmn",
+    name = "random",
+    apps = {
+        chain.app().new({
+            version = "17.03.09",
+            beginBlockIndex = 0,
+            endBlockIndex = -1,
+            router = chain.router().new({
+                key = "this-is-the-router-key",
+                routes = {
+                    chain.route().new("save", "/random/<kind|[a-z]+>", saveRandom),
+                }
+            }),
+        })
+    }
+})
@@ -0,0 +1,31 @@
+-- load the modules:
+require("datastore")
+local chain = require("chain")
+
+function retrieveVersion(from, path, params, sig)
+    return {
+        code = 0,
+        log="success",
+        key=path,
+        value="1"
+    }
+end
+
+chain.chain().load({
+    namespace = "xmn",
+    name = "upgrades",
+    apps = {
+        chain.app().new({
+            version = "17.03.09",
+            beginBlockIndex = 0,
+            endBlockIndex = -1,
+            upgradable = true,
+            router = chain.router().new({
+                key = "this-is-the-router-key",
+                routes = {
+                    chain.route().new("retrieve", "/version", retrieveVersion),
+                }
+            }),
+        })
+    }
+})
@@ -0,0 +1,323 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	"github.com/xmnservices/xmnsuite/routers"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const upgradesPattern = "/upgrades"
+
+/*
+ * Upgrade
+ *
+ * The code of an upgrade is a lua chunk that returns the router of the application, for example:
+ *      local chain = require("chain")
+ *      return chain.router().new({ key = "my-router-key", routes = { ... } })
+ *
+ * Once saved, the upgrade is activated by every node at the beginning of the block at its height.  The code runs in its own
+ * environment, in the budget of the chain, on an empty store: a node that restarts activates the code again, so the
+ * router it returns cannot depend on the state of the chain.  When the upgrade cannot be activated, the current code
+ * stays active and the error is saved with the upgrade.
+ */
+
+type upgrade struct {
+	Version string `json:"version"`
+	Height  int64  `json:"height"`
+	Code    string `json:"code"`
+	Error   string `json:"error,omitempty"`
+}
+
+// upgrader routes the requests of an upgradable application to the code of its latest activated upgrade
+type upgrader struct {
+	mod           *module
+	version       string
+	routerDS      datastore.DataStore
+	routerRoleKey string
+	height        int64
+	current       routers.Router
+}
+
+func createUpgrader(
+	mod *module,
+	version string,
+	routerDS datastore.DataStore,
+	routerRoleKey string,
+	rteParams []routers.CreateRouteParams,
+	store datastore.DataStore,
+) (*upgrader, error) {
+	out := upgrader{
+		mod:           mod,
+		version:       version,
+		routerDS:      routerDS,
+		routerRoleKey: routerRoleKey,
+		height:        0,
+		current:       nil,
+	}
+
+	// a node that restarts uses the code of the latest activated upgrade:
+	if keyname, ok := store.Keys().Retrieve(out.activeKeyname()).(string); ok {
+		upg, upgErr := out.retrieve(store, keyname)
+		if upgErr != nil {
+			return nil, upgErr
+		}
+
+		activateErr := out.activate(upg)
+		if activateErr != nil {
+			return nil, activateErr
+		}
+
+		return &out, nil
+	}
+
+	rter, rterErr := out.createRouter(rteParams)
+	if rterErr != nil {
+		return nil, rterErr
+	}
+
+	out.current = rter
+	return &out, nil
+}
+
+// Route routes the request using the router of the latest activated upgrade
+func (app *upgrader) Route(from crypto.PublicKey, path string, method int) routers.PreparedHandler {
+	return app.current.Route(from, path, method)
+}
+
+func (app *upgrader) wrapBeginBlock(beginBlock applications.BeginBlock) applications.BeginBlock {
+	return func(store datastore.DataStore, height int64) error {
+		app.height = height
+
+		// activate the upgrade scheduled at this height, if any:
+		keyname := app.keyname(height)
+		if store.Keys().Exists(keyname) > 0 {
+			upg, upgErr := app.retrieve(store, keyname)
+			if upgErr != nil {
+				return upgErr
+			}
+
+			// the current code stays active when the upgrade cannot be activated, and every node saves the same error:
+			activateErr := app.activate(upg)
+			if activateErr != nil {
+				upg.Error = activateErr.Error()
+				js, jsErr := json.Marshal(upg)
+				if jsErr != nil {
+					return jsErr
+				}
+
+				store.Keys().Save(keyname, string(js))
+			} else {
+				store.Keys().Save(app.activeKeyname(), keyname)
+			}
+		}
+
+		if beginBlock == nil {
+			return nil
+		}
+
+		// the begin block func runs on its own copy of the store, so that its failure does not discard the activation:
+		copied := store.Copy()
+		beginBlockErr := beginBlock(copied, height)
+		if beginBlockErr != nil {
+			log.Printf("there was an error while beginning the block (height: %d): %s", height, beginBlockErr.Error())
+			return nil
+		}
+
+		store.Replace(copied)
+		return nil
+	}
+}
+
+func (app *upgrader) activate(upg *upgrade) error {
+	fn, fnErr := sandbox.SDKFunc.LoadString(sandbox.LoadStringParams{
+		Context: app.mod.context,
+		Code:    upg.Code,
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+
+	// the code runs in its own environment, so that its globals do not leak to the other versions:
+	env := app.mod.context.NewTable()
+	mt := app.mod.context.NewTable()
+	mt.RawSetString("__index", app.mod.context.G.Global)
+	app.mod.context.SetMetatable(env, mt)
+	fn.Env = env
+
+	// execute the code, in the budget of the chain, on an empty store:
+	app.mod.replaceDS(datastore.SDKFunc.Create())
+	value, valueErr := callLuaBlockFunc("upgrade", fn, app.mod.context, app.mod.budget)
+	if valueErr != nil {
+		return valueErr
+	}
+
+	if ud, ok := value.(*lua.LUserData); ok {
+		if rtr, ok := ud.Value.(*router); ok {
+			rter, rterErr := app.createRouter(app.mod.createRouteParams(rtr))
+			if rterErr != nil {
+				return rterErr
+			}
+
+			app.current = rter
+			return nil
+		}
+	}
+
+	str := fmt.Sprintf("the code of the upgrade (version: %s) was expected to return a router", upg.Version)
+	return errors.New(str)
+}
+
+func (app *upgrader) createRouter(rteParams []routers.CreateRouteParams) (out routers.Router, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			str := fmt.Sprintf("the router could not be created: %v", r)
+			err = errors.New(str)
+		}
+	}()
+
+	// the upgrade routes stay available in every upgrade:
+	out = routers.SDKFunc.CreateRouter(routers.CreateRouterParams{
+		DataStore:  app.routerDS,
+		RoleKey:    app.routerRoleKey,
+		RtesParams: append(rteParams, app.routes()...),
+	})
+
+	return
+}
+
+func (app *upgrader) retrieve(store datastore.DataStore, keyname string) (*upgrade, error) {
+	js, ok := store.Keys().Retrieve(keyname).(string)
+	if !ok {
+		str := fmt.Sprintf("the upgrade (keyname: %s) could not be found", keyname)
+		return nil, errors.New(str)
+	}
+
+	upg := new(upgrade)
+	jsErr := json.Unmarshal([]byte(js), upg)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	return upg, nil
+}
+
+func (app *upgrader) keyname(height int64) string {
+	return fmt.Sprintf("xmn-upgrade:%s:%d", app.version, height)
+}
+
+func (app *upgrader) activeKeyname() string {
+	return fmt.Sprintf("xmn-upgrade:%s:active", app.version)
+}
+
+func (app *upgrader) routes() []routers.CreateRouteParams {
+	return []routers.CreateRouteParams{
+		app.saveRoute(),
+		app.retrieveRoute(),
+	}
+}
+
+/*
+ * Save
+ * Expected data:
+ *      a json object that contains the version of the code, the height of the block it will be activated at, and the lua code
+ */
+func (app *upgrader) saveRoute() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: upgradesPattern,
+		SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+			upg := new(upgrade)
+			jsErr := json.Unmarshal(data, upg)
+			if jsErr != nil {
+				str := fmt.Sprintf("the upgrade is not a valid json object: %s", jsErr.Error())
+				return nil, errors.New(str)
+			}
+
+			// the error is only set when the upgrade cannot be activated:
+			upg.Error = ""
+
+			if upg.Version == "" {
+				return nil, errors.New("the version of the upgrade is mandatory")
+			}
+
+			// the upgrade can only be activated on a future block:
+			if upg.Height <= app.height {
+				str := fmt.Sprintf("the height (%d) of the upgrade must be greater than the height (%d) of the current block", upg.Height, app.height)
+				return nil, errors.New(str)
+			}
+
+			_, compileErr := sandbox.SDKFunc.LoadString(sandbox.LoadStringParams{
+				Context: app.mod.context,
+				Code:    upg.Code,
+			})
+			if compileErr != nil {
+				str := fmt.Sprintf("the code of the upgrade (version: %s) does not compile: %s", upg.Version, compileErr.Error())
+				return nil, errors.New(str)
+			}
+
+			keyname := app.keyname(upg.Height)
+			if store.Keys().Exists(keyname) > 0 {
+				str := fmt.Sprintf("an upgrade is already scheduled at the height (%d)", upg.Height)
+				return nil, errors.New(str)
+			}
+
+			js, jsErr := json.Marshal(upg)
+			if jsErr != nil {
+				return nil, jsErr
+			}
+
+			store.Keys().Save(keyname, string(js))
+
+			// return the response:
+			resp := routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+				Code:    routers.IsSuccessful,
+				Log:     "success",
+				GazUsed: int64(len(js)),
+				Tags: map[string][]byte{
+					"upgrade":         []byte("1"),
+					"upgrade.version": []byte(upg.Version),
+					"upgrade.height":  []byte(strconv.FormatInt(upg.Height, 10)),
+				},
+			})
+
+			return resp, nil
+		},
+	}
+}
+
+func (app *upgrader) retrieveRoute() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: fmt.Sprintf("%s/<height|[0-9]+>", upgradesPattern),
+		QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+			height, heightErr := strconv.ParseInt(params["height"], 10, 64)
+			if heightErr != nil {
+				str := fmt.Sprintf("the given height (%s) is invalid: %s", params["height"], heightErr.Error())
+				return nil, errors.New(str)
+			}
+
+			js, ok := store.Keys().Retrieve(app.keyname(height)).(string)
+			if !ok {
+				str := fmt.Sprintf("there is no upgrade scheduled at the height (%d)", height)
+				return nil, errors.New(str)
+			}
+
+			// return the response:
+			resp := routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+				Code:  routers.IsSuccessful,
+				Log:   "success",
+				Key:   path,
+				Value: []byte(js),
+			})
+
+			return resp, nil
+		},
+	}
+}
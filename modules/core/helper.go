@@ -0,0 +1,112 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	lua "github.com/yuin/gopher-lua"
+	luajson "layeh.com/gopher-json"
+)
+
+func execute(fn func() (interface{}, error)) (out interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = toError(r)
+		}
+	}()
+
+	return fn()
+}
+
+func toLua(l *lua.LState, ins interface{}) (lua.LValue, error) {
+	// the instances are converted to lua tables using their json representation:
+	js, jsErr := json.Marshal(ins)
+	if jsErr != nil {
+		str := fmt.Sprintf("the instance could not be converted to json: %s", jsErr.Error())
+		return nil, errors.New(str)
+	}
+
+	return luajson.Decode(l, js)
+}
+
+func fromLuaToID(l *lua.LState, index int) *uuid.UUID {
+	id, idErr := fromStringToID(l.CheckString(index))
+	if idErr != nil {
+		l.ArgError(index, idErr.Error())
+		return nil
+	}
+
+	return id
+}
+
+func fromStringToID(idAsString string) (*uuid.UUID, error) {
+	id, idErr := uuid.FromString(idAsString)
+	if idErr != nil {
+		str := fmt.Sprintf("the given id (ID: %s) is not a valid id", idAsString)
+		return nil, errors.New(str)
+	}
+
+	return &id, nil
+}
+
+func fromLuaToPubKey(l *lua.LState, index int) crypto.PublicKey {
+	pubKeyAsString := l.CheckString(index)
+	pubKey, pubKeyErr := execute(func() (interface{}, error) {
+		return crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+			PubKeyAsString: pubKeyAsString,
+		}), nil
+	})
+
+	if pubKeyErr != nil {
+		str := fmt.Sprintf("the given public key (%s) is invalid: %s", pubKeyAsString, pubKeyErr.Error())
+		l.ArgError(index, str)
+		return nil
+	}
+
+	return pubKey.(crypto.PublicKey)
+}
+
+func fromLuaToPage(l *lua.LState, index int) (int, int) {
+	idx := l.CheckInt(index)
+	amount := l.CheckInt(index + 1)
+	if idx < 0 || amount < 0 {
+		l.ArgError(index, "the index and the amount cannot be negative")
+		return 0, 0
+	}
+
+	if amount > MaxAmountOfElements {
+		amount = MaxAmountOfElements
+	}
+
+	return idx, amount
+}
+
+func fromLuaToAmount(tb *lua.LTable) (int, error) {
+	amount, ok := tb.RawGetString("amount").(lua.LNumber)
+	if !ok || int(amount) <= 0 || lua.LNumber(int(amount)) != amount {
+		return 0, errors.New("the amount was expected to be a positive integer")
+	}
+
+	return int(amount), nil
+}
+
+func fromLuaToString(tb *lua.LTable, keyname string) string {
+	if value, ok := tb.RawGetString(keyname).(lua.LString); ok {
+		return string(value)
+	}
+
+	return ""
+}
+
+func toError(r interface{}) error {
+	if casted, ok := r.(error); ok {
+		return casted
+	}
+
+	str := fmt.Sprintf("%v", r)
+	return errors.New(str)
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	lua "github.com/yuin/gopher-lua"
+)
+
+type testInstance struct {
+	ID     *uuid.UUID `json:"id"`
+	Amount int        `json:"amount"`
+}
+
+func TestToLua_Success(t *testing.T) {
+	//variables:
+	context := lua.NewState()
+	defer context.Close()
+
+	id := uuid.NewV4()
+	ins := &testInstance{
+		ID:     &id,
+		Amount: 45,
+	}
+
+	//execute:
+	value, valueErr := toLua(context, ins)
+	if valueErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", valueErr.Error())
+		return
+	}
+
+	tb, ok := value.(*lua.LTable)
+	if !ok {
+		t.Errorf("the returned value was expected to be a table")
+		return
+	}
+
+	if tb.RawGetString("id").String() != id.String() {
+		t.Errorf("the returned id was expected to be %s, %s returned", id.String(), tb.RawGetString("id").String())
+		return
+	}
+
+	if tb.RawGetString("amount") != lua.LNumber(45) {
+		t.Errorf("the returned amount was expected to be %d, %s returned", 45, tb.RawGetString("amount").String())
+		return
+	}
+}
+
+func TestFromLuaToAmount_Success(t *testing.T) {
+	//variables:
+	context := lua.NewState()
+	defer context.Close()
+
+	tb := context.NewTable()
+	tb.RawSetString("amount", lua.LNumber(12))
+
+	//execute:
+	amount, amountErr := fromLuaToAmount(tb)
+	if amountErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", amountErr.Error())
+		return
+	}
+
+	if amount != 12 {
+		t.Errorf("the returned amount was expected to be %d, %d returned", 12, amount)
+		return
+	}
+}
+
+func TestFromLuaToAmount_withInvalidAmount_returnsError(t *testing.T) {
+	//variables:
+	context := lua.NewState()
+	defer context.Close()
+
+	invalids := []lua.LValue{
+		lua.LNumber(0),
+		lua.LNumber(-3),
+		lua.LNumber(1.5),
+		lua.LString("12"),
+		lua.LNil,
+	}
+
+	//execute:
+	for _, oneInvalid := range invalids {
+		tb := context.NewTable()
+		tb.RawSetString("amount", oneInvalid)
+
+		_, amountErr := fromLuaToAmount(tb)
+		if amountErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (amount: %s)", oneInvalid.String())
+			return
+		}
+	}
+}
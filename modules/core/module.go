@@ -0,0 +1,468 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/blockchains/core/meta"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/pledge"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/transfer"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity/entities/wallet/entities/user"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request"
+	active_request "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active/vote"
+	active_vote "github.com/xmnservices/xmnsuite/blockchains/core/objects/request/active/vote/active"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/request/keyname"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/deposit"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/token/balance"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/underlying/withdrawal"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	lua "github.com/yuin/gopher-lua"
+)
+
+type module struct {
+	context                 *lua.LState
+	signer                  crypto.Signer
+	client                  applications.Client
+	met                     meta.Meta
+	walletRepository        wallet.Repository
+	userRepository          user.Repository
+	transferRepository      transfer.Repository
+	pledgeRepository        pledge.Repository
+	activeRequestRepository active_request.Repository
+	activeVoteRepository    active_vote.Repository
+	keynameRepository       keyname.Repository
+	balanceRepository       balance.Repository
+}
+
+func createModule(context *lua.LState, signer crypto.Signer, client applications.Client, met meta.Meta) *module {
+	out := module{
+		context: context,
+		signer:  signer,
+		client:  client,
+		met:     met,
+	}
+
+	// the repositories query the chain through the client:
+	if client != nil {
+		entityRepository := entity.SDKFunc.CreateSDKRepository(entity.CreateSDKRepositoryParams{
+			Signer: signer,
+			Client: client,
+		})
+
+		out.walletRepository = wallet.SDKFunc.CreateRepository(wallet.CreateRepositoryParams{
+			EntityRepository: entityRepository,
+		})
+
+		out.userRepository = user.SDKFunc.CreateRepository(user.CreateRepositoryParams{
+			EntityRepository: entityRepository,
+		})
+
+		out.transferRepository = transfer.SDKFunc.CreateRepository(transfer.CreateRepositoryParams{
+			EntityRepository: entityRepository,
+		})
+
+		out.pledgeRepository = pledge.SDKFunc.CreateRepository(pledge.CreateRepositoryParams{
+			EntityRepository: entityRepository,
+		})
+
+		out.activeRequestRepository = active_request.SDKFunc.CreateRepository(active_request.CreateRepositoryParams{
+			EntityRepository: entityRepository,
+		})
+
+		out.activeVoteRepository = active_vote.SDKFunc.CreateRepository(active_vote.CreateRepositoryParams{
+			EntityRepository: entityRepository,
+		})
+
+		out.keynameRepository = keyname.SDKFunc.CreateRepository(keyname.CreateRepositoryParams{
+			EntityRepository: entityRepository,
+		})
+
+		out.balanceRepository = balance.SDKFunc.CreateRepository(balance.CreateRepositoryParams{
+			DepositRepository: deposit.SDKFunc.CreateRepository(deposit.CreateRepositoryParams{
+				EntityRepository: entityRepository,
+			}),
+			WithdrawalRepository: withdrawal.SDKFunc.CreateRepository(withdrawal.CreateRepositoryParams{
+				EntityRepository: entityRepository,
+			}),
+		})
+	}
+
+	out.register()
+
+	return &out
+}
+
+func (app *module) register() {
+	app.context.PreloadModule("core", func(context *lua.LState) int {
+		methods := map[string]lua.LGFunction{
+			"wallet": func(context *lua.LState) int {
+				return app.registerWallet(context)
+			},
+			"user": func(context *lua.LState) int {
+				return app.registerUser(context)
+			},
+			"transfer": func(context *lua.LState) int {
+				return app.registerTransfer(context)
+			},
+			"pledge": func(context *lua.LState) int {
+				return app.registerPledge(context)
+			},
+			"request": func(context *lua.LState) int {
+				return app.registerRequest(context)
+			},
+			"vote": func(context *lua.LState) int {
+				return app.registerVote(context)
+			},
+			"balance": func(context *lua.LState) int {
+				return app.registerBalance(context)
+			},
+		}
+
+		ntable := context.NewTable()
+		context.SetFuncs(ntable, methods)
+		context.Push(ntable)
+
+		return 1
+	})
+}
+
+func (app *module) registerWallet(context *lua.LState) int {
+	// retrieves a wallet by its id:
+	retrieveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			return app.walletRepository.RetrieveByID(fromLuaToID(l, 1))
+		})
+	}
+
+	// retrieves a list of wallets:
+	listFn := func(l *lua.LState) int {
+		return app.call(l, 2, func() (interface{}, error) {
+			index, amount := fromLuaToPage(l, 1)
+			return app.walletRepository.RetrieveSet(index, amount)
+		})
+	}
+
+	return app.push(context, map[string]lua.LGFunction{
+		"retrieve": retrieveFn,
+		"list":     listFn,
+	})
+}
+
+func (app *module) registerUser(context *lua.LState) int {
+	// retrieves a user by its id:
+	retrieveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			return app.userRepository.RetrieveByID(fromLuaToID(l, 1))
+		})
+	}
+
+	// retrieves a user by its PublicKey:
+	retrieveByPubKeyFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			return app.userRepository.RetrieveByPubKey(fromLuaToPubKey(l, 1))
+		})
+	}
+
+	// retrieves a list of users:
+	listFn := func(l *lua.LState) int {
+		return app.call(l, 2, func() (interface{}, error) {
+			index, amount := fromLuaToPage(l, 1)
+			return app.userRepository.RetrieveSet(index, amount)
+		})
+	}
+
+	return app.push(context, map[string]lua.LGFunction{
+		"retrieve":         retrieveFn,
+		"retrieveByPubKey": retrieveByPubKeyFn,
+		"list":             listFn,
+	})
+}
+
+func (app *module) registerTransfer(context *lua.LState) int {
+	// retrieves a transfer by its id:
+	retrieveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			return app.transferRepository.RetrieveByID(fromLuaToID(l, 1))
+		})
+	}
+
+	// retrieves a list of transfers:
+	listFn := func(l *lua.LState) int {
+		return app.call(l, 2, func() (interface{}, error) {
+			index, amount := fromLuaToPage(l, 1)
+			return app.transferRepository.RetrieveSet(index, amount)
+		})
+	}
+
+	// requests a transfer to the shareholders of the from wallet:
+	saveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			tb := l.CheckTable(1)
+			fromWallet, toWallet, walletsErr := app.retrieveWallets(tb)
+			if walletsErr != nil {
+				return nil, walletsErr
+			}
+
+			amount, amountErr := fromLuaToAmount(tb)
+			if amountErr != nil {
+				return nil, amountErr
+			}
+
+			trsf := transfer.SDKFunc.Create(transfer.CreateParams{
+				Withdrawal: withdrawal.SDKFunc.Create(withdrawal.CreateParams{
+					From:   fromWallet,
+					Amount: amount,
+				}),
+				Deposit: deposit.SDKFunc.Create(deposit.CreateParams{
+					To:     toWallet,
+					Amount: amount,
+				}),
+			})
+
+			return app.saveRequest(transfer.SDKFunc.CreateRepresentation(), trsf, fromLuaToString(tb, "reason"))
+		})
+	}
+
+	return app.push(context, map[string]lua.LGFunction{
+		"retrieve": retrieveFn,
+		"list":     listFn,
+		"save":     saveFn,
+	})
+}
+
+func (app *module) registerPledge(context *lua.LState) int {
+	// retrieves a pledge by its id:
+	retrieveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			return app.pledgeRepository.RetrieveByID(fromLuaToID(l, 1))
+		})
+	}
+
+	// requests a pledge to the shareholders of the from wallet:
+	saveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			tb := l.CheckTable(1)
+			fromWallet, toWallet, walletsErr := app.retrieveWallets(tb)
+			if walletsErr != nil {
+				return nil, walletsErr
+			}
+
+			amount, amountErr := fromLuaToAmount(tb)
+			if amountErr != nil {
+				return nil, amountErr
+			}
+
+			pldge := pledge.SDKFunc.Create(pledge.CreateParams{
+				From: withdrawal.SDKFunc.Create(withdrawal.CreateParams{
+					From:   fromWallet,
+					Amount: amount,
+				}),
+				To: toWallet,
+			})
+
+			return app.saveRequest(pledge.SDKFunc.CreateRepresentation(), pldge, fromLuaToString(tb, "reason"))
+		})
+	}
+
+	return app.push(context, map[string]lua.LGFunction{
+		"retrieve": retrieveFn,
+		"save":     saveFn,
+	})
+}
+
+func (app *module) registerRequest(context *lua.LState) int {
+	// retrieves an active request by its id:
+	retrieveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			return app.activeRequestRepository.RetrieveByID(fromLuaToID(l, 1))
+		})
+	}
+
+	// retrieves a list of active requests:
+	listFn := func(l *lua.LState) int {
+		return app.call(l, 2, func() (interface{}, error) {
+			index, amount := fromLuaToPage(l, 1)
+			return app.activeRequestRepository.RetrieveSet(index, amount)
+		})
+	}
+
+	return app.push(context, map[string]lua.LGFunction{
+		"retrieve": retrieveFn,
+		"list":     listFn,
+	})
+}
+
+func (app *module) registerVote(context *lua.LState) int {
+	// votes on an active request, with the user of the signer:
+	saveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			tb := l.CheckTable(1)
+			requestID, requestIDErr := fromStringToID(tb.RawGetString("request").String())
+			if requestIDErr != nil {
+				return nil, requestIDErr
+			}
+
+			req, reqErr := app.activeRequestRepository.RetrieveByID(requestID)
+			if reqErr != nil {
+				str := fmt.Sprintf("there was an error while retrieving a request (ID: %s): %s", requestID.String(), reqErr.Error())
+				return nil, errors.New(str)
+			}
+
+			fromUser, fromUserErr := app.userRepository.RetrieveByPubKey(app.signer.PublicKey())
+			if fromUserErr != nil {
+				str := fmt.Sprintf("there was an error while retrieving the user (PubKey: %s): %s", app.signer.PublicKey().String(), fromUserErr.Error())
+				return nil, errors.New(str)
+			}
+
+			vot := vote.SDKFunc.Create(vote.CreateParams{
+				Request:    req.Request(),
+				Voter:      fromUser,
+				Reason:     fromLuaToString(tb, "reason"),
+				IsApproved: lua.LVAsBool(tb.RawGetString("approved")),
+				IsNeutral:  lua.LVAsBool(tb.RawGetString("neutral")),
+			})
+
+			// find the representation of the requested entity:
+			kname := req.Request().Keyname()
+			reps := app.met.WriteOnEntityRequest()[kname.Group().Name()].Map()
+
+			voteService := vote.SDKFunc.CreateSDKService(vote.CreateSDKServiceParams{
+				Signer: app.signer,
+				Client: app.client,
+			})
+
+			saveErr := voteService.Save(vot, reps[kname.Name()])
+			if saveErr != nil {
+				str := fmt.Sprintf("there was an error while saving a vote: %s", saveErr.Error())
+				return nil, errors.New(str)
+			}
+
+			return app.activeVoteRepository.RetrieveByVote(vot)
+		})
+	}
+
+	return app.push(context, map[string]lua.LGFunction{
+		"save": saveFn,
+	})
+}
+
+func (app *module) registerBalance(context *lua.LState) int {
+	// retrieves the balance of a wallet by its id:
+	retrieveFn := func(l *lua.LState) int {
+		return app.call(l, 1, func() (interface{}, error) {
+			wal, walErr := app.walletRepository.RetrieveByID(fromLuaToID(l, 1))
+			if walErr != nil {
+				return nil, walErr
+			}
+
+			return app.balanceRepository.RetrieveByWallet(wal)
+		})
+	}
+
+	return app.push(context, map[string]lua.LGFunction{
+		"retrieve": retrieveFn,
+	})
+}
+
+func (app *module) push(context *lua.LState, methods map[string]lua.LGFunction) int {
+	ntable := context.NewTable()
+	context.SetFuncs(ntable, methods)
+	context.Push(ntable)
+	return 1
+}
+
+func (app *module) call(l *lua.LState, amountOfParams int, fn func() (interface{}, error)) int {
+	if l.GetTop() != amountOfParams {
+		str := fmt.Sprintf("the func expected %d parameters", amountOfParams)
+		l.ArgError(1, str)
+		return 1
+	}
+
+	if app.client == nil {
+		l.RaiseError("the client must be set in the engine in order to use the core module")
+		return 1
+	}
+
+	// the core SDK panics on invalid data, so the panics are converted to lua errors:
+	out, outErr := execute(fn)
+	if outErr != nil {
+		l.RaiseError("%s", outErr.Error())
+		return 1
+	}
+
+	value, valueErr := toLua(l, out)
+	if valueErr != nil {
+		l.RaiseError("%s", valueErr.Error())
+		return 1
+	}
+
+	l.Push(value)
+	return 1
+}
+
+func (app *module) retrieveWallets(tb *lua.LTable) (wallet.Wallet, wallet.Wallet, error) {
+	fromWalletID, fromWalletIDErr := fromStringToID(tb.RawGetString("from").String())
+	if fromWalletIDErr != nil {
+		return nil, nil, fromWalletIDErr
+	}
+
+	toWalletID, toWalletIDErr := fromStringToID(tb.RawGetString("to").String())
+	if toWalletIDErr != nil {
+		return nil, nil, toWalletIDErr
+	}
+
+	fromWallet, fromWalletErr := app.walletRepository.RetrieveByID(fromWalletID)
+	if fromWalletErr != nil {
+		str := fmt.Sprintf("there was an error while retrieving the wallet (ID: %s): %s", fromWalletID.String(), fromWalletErr.Error())
+		return nil, nil, errors.New(str)
+	}
+
+	toWallet, toWalletErr := app.walletRepository.RetrieveByID(toWalletID)
+	if toWalletErr != nil {
+		str := fmt.Sprintf("there was an error while retrieving the wallet (ID: %s): %s", toWalletID.String(), toWalletErr.Error())
+		return nil, nil, errors.New(str)
+	}
+
+	return fromWallet, toWallet, nil
+}
+
+func (app *module) saveRequest(representation entity.Representation, ins entity.Entity, reason string) (request.Request, error) {
+	// retrieve the user of the signer:
+	fromUser, fromUserErr := app.userRepository.RetrieveByPubKey(app.signer.PublicKey())
+	if fromUserErr != nil {
+		str := fmt.Sprintf("there was an error while retrieving the user (PubKey: %s): %s", app.signer.PublicKey().String(), fromUserErr.Error())
+		return nil, errors.New(str)
+	}
+
+	// retrieve the keyname:
+	kname, knameErr := app.keynameRepository.RetrieveByName(representation.MetaData().Keyname())
+	if knameErr != nil {
+		str := fmt.Sprintf("there was an error while retrieving the keyname instance (Name: %s): %s", representation.MetaData().Keyname(), knameErr.Error())
+		return nil, errors.New(str)
+	}
+
+	// create then save the request:
+	newReq := request.SDKFunc.Create(request.CreateParams{
+		FromUser:   fromUser,
+		SaveEntity: ins,
+		Reason:     reason,
+		Keyname:    kname,
+	})
+
+	reqService := request.SDKFunc.CreateSDKService(request.CreateSDKServiceParams{
+		Signer: app.signer,
+		Client: app.client,
+	})
+
+	saveErr := reqService.Save(newReq, representation)
+	if saveErr != nil {
+		str := fmt.Sprintf("there was an error while saving the request: %s", saveErr.Error())
+		return nil, errors.New(str)
+	}
+
+	return newReq, nil
+}
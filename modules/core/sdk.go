@@ -0,0 +1,35 @@
+package core
+
+import (
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/blockchains/core/meta"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const (
+	// MaxAmountOfElements represents the maximum amount of elements a list func returns
+	MaxAmountOfElements = 100
+)
+
+// Core represents the core module
+type Core interface {
+}
+
+// CreateParams represents the create params.  The Signer signs the queries and the requests sent to the Client
+type CreateParams struct {
+	Context *lua.LState
+	Signer  crypto.Signer
+	Client  applications.Client
+	Meta    meta.Meta
+}
+
+// SDKFunc represents the core module SDK func
+var SDKFunc = struct {
+	Create func(params CreateParams) Core
+}{
+	Create: func(params CreateParams) Core {
+		out := createModule(params.Context, params.Signer, params.Client, params.Meta)
+		return out
+	},
+}
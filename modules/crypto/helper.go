@@ -0,0 +1,134 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// the reason why the random funcs cannot be called by the handlers:
+const notDeterministic = "is not deterministic"
+
+// raiseIfMetered raises an error when a random, or unmetered, func is called by a handler, since every node would write
+// another state, or could be stalled:
+func raiseIfMetered(l *lua.LState, name string, reason string) {
+	if sandbox.SDKFunc.IsMetered(sandbox.IsMeteredParams{Context: l}) {
+		l.RaiseError("the %s func %s, and therefore cannot be called by the handlers of the chain", name, reason)
+	}
+}
+
+func fromLuaToPubKey(l *lua.LState, index int) crypto.PublicKey {
+	pubKeyAsString := l.CheckString(index)
+	pubKey, pubKeyErr := createPubKey(pubKeyAsString)
+	if pubKeyErr != nil {
+		str := fmt.Sprintf("the given public key (%s) is invalid: %s", pubKeyAsString, pubKeyErr.Error())
+		l.ArgError(index, str)
+		return nil
+	}
+
+	return pubKey
+}
+
+func fromLuaToSig(l *lua.LState, index int) crypto.Signature {
+	sigAsString := l.CheckString(index)
+	sig, sigErr := createSig(sigAsString)
+	if sigErr != nil {
+		str := fmt.Sprintf("the given signature (%s) is invalid: %s", sigAsString, sigErr.Error())
+		l.ArgError(index, str)
+		return nil
+	}
+
+	return sig
+}
+
+func fromLuaToRingSig(l *lua.LState, index int) crypto.RingSignature {
+	ringSigAsString := l.CheckString(index)
+	ringSig, ringSigErr := createRingSig(ringSigAsString)
+	if ringSigErr != nil {
+		str := fmt.Sprintf("the given ring signature (%s) is invalid: %s", ringSigAsString, ringSigErr.Error())
+		l.ArgError(index, str)
+		return nil
+	}
+
+	return ringSig
+}
+
+func createPubKey(pubKeyAsString string) (out crypto.PublicKey, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+		PubKeyAsString: pubKeyAsString,
+	})
+
+	return
+}
+
+func createSig(sigAsString string) (out crypto.Signature, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreateSig(crypto.CreateSigParams{
+		SigAsString: sigAsString,
+	})
+
+	return
+}
+
+func createRingSig(ringSigAsString string) (out crypto.RingSignature, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreateRingSig(crypto.CreateRingSigParams{
+		RingSigAsString: ringSigAsString,
+	})
+
+	return
+}
+
+func decrypt(pass []byte, encrypted string) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+
+	out = crypto.SDKFunc.Decrypt(crypto.DecryptParams{
+		Pass:         pass,
+		EncryptedMsg: encrypted,
+	})
+
+	return
+}
+
+func encode(encodeFn func() string) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+
+	out = encodeFn()
+	return
+}
+
+func toError(r interface{}) error {
+	if casted, ok := r.(error); ok {
+		return casted
+	}
+
+	str := fmt.Sprintf("%v", r)
+	return errors.New(str)
+}
@@ -10,8 +10,14 @@ local anotherPubKey = anotherPrivKey:pubKey()
 
 local ringSign = anotherPrivKey:ringSign("mymessage", {pubKey, anotherPubKey})
 
+local encrypted = pubkey.encryptTo(anotherPubKey, "my private message")
+local decrypted = anotherPrivKey:decrypt(encrypted)
+
 -- verify:
 assert(type(pubKey) == "string")
 assert(type(anotherPubKey) == "string")
 assert(anotherPubKey == "aab735bdbbe6f03fd37f020738a76c4c92a5f00ec82d69f6c129f97b46112a59")
 assert(type(ringSign) == "string")
+assert(type(encrypted) == "string")
+assert(decrypted == "my private message")
+assert(not pcall(function() return x:decrypt(encrypted) end))
@@ -0,0 +1,44 @@
+-- load the modules:
+require("crypto")
+
+-- variables:
+local pk = privkey.new()
+local pubKey = pk:pubKey()
+local anotherPubKey = privkey.new():pubKey()
+local msg = "mymessage"
+
+-- execute:
+local sig = pk:sign(msg)
+local sigBytes = pk:signBytes("mydomain", msg)
+local ringSig = pk:ringSign(msg, {pubKey, anotherPubKey})
+local encodedPubKey = pubkey.encode(pubKey, "base58")
+local address = pubkey.address(pubKey)
+local encrypted = cipher.encrypt("mypass", "my secret message")
+
+-- verify the public keys:
+assert(pubkey.new(encodedPubKey) == pubKey)
+assert(pubkey.equals(encodedPubKey, pubKey))
+assert(not pubkey.equals(pubKey, anotherPubKey))
+assert(pubkey.isValid(pubKey))
+assert(not pubkey.isValid("not a public key"))
+assert(not pcall(function() return pubkey.new("not a public key") end))
+assert(pubkey.verifyAddress(pubKey, address))
+assert(not pubkey.verifyAddress(anotherPubKey, address))
+
+-- verify the signatures:
+assert(signature.new(sig) == sig)
+assert(signature.new(signature.encode(sig, "base58")) == sig)
+assert(signature.pubKey(sig, msg) == pubKey)
+assert(signature.verify(sig, msg, pubKey))
+assert(not signature.verify(sig, msg, anotherPubKey))
+assert(not signature.verify(sig, "another message", pubKey))
+assert(signature.verifyBytes(sigBytes, "mydomain", msg, pubKey))
+assert(not signature.verifyBytes(sigBytes, "anotherdomain", msg, pubKey))
+
+-- verify the ring signatures:
+assert(ringsig.verify(ringSig, msg))
+assert(not ringsig.verify(ringSig, "another message"))
+
+-- verify the encryption:
+assert(cipher.decrypt("mypass", encrypted) == "my secret message")
+assert(not pcall(function() return cipher.decrypt("another pass", encrypted) end))
@@ -1,18 +1,32 @@
 package crypto
 
 import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
 
 	edwards25519 "github.com/dedis/kyber/group/edwards25519"
 	crypto "github.com/xmnservices/xmnsuite/crypto"
+	json_module "github.com/xmnservices/xmnsuite/modules/json"
 	lua "github.com/yuin/gopher-lua"
-	luajson "layeh.com/gopher-json"
+	blake2b "golang.org/x/crypto/blake2b"
 )
 
 var curve = edwards25519.NewBlakeSHA256Ed25519()
 
 const luaPrivKey = "privkey"
 
+const luaPubKey = "pubkey"
+
+const luaSig = "signature"
+
+const luaRingSig = "ringsig"
+
+const luaCipher = "cipher"
+
+const luaHash = "hash"
+
 type module struct {
 	context *lua.LState
 }
@@ -29,11 +43,18 @@ func createModule(context *lua.LState) Crypto {
 
 func (app *module) register() {
 	// preload JSON:
-	luajson.Preload(app.context)
+	json_module.SDKFunc.Create(json_module.CreateParams{
+		Context: app.context,
+	})
 
 	// preload XMN:
 	app.context.PreloadModule("crypto", func(context *lua.LState) int {
 		app.registerPrivKey(context)
+		app.registerPubKey(context)
+		app.registerSignature(context)
+		app.registerRingSignature(context)
+		app.registerCipher(context)
+		app.registerHash(context)
 		return 1
 	})
 }
@@ -68,6 +89,7 @@ func (app *module) registerPrivKey(context *lua.LState) {
 
 		}
 
+		raiseIfMetered(l, "privkey.new", notDeterministic)
 		ud := l.NewUserData()
 		ud.Value = crypto.SDKFunc.GenPK()
 
@@ -102,6 +124,21 @@ func (app *module) registerPrivKey(context *lua.LState) {
 		return 1
 	}
 
+	//execute the signBytesFn command on the privkey instance:
+	signBytesFn := func(l *lua.LState) int {
+		p := checkFn(l)
+		if l.GetTop() == 3 {
+			domain := l.CheckString(2)
+			msg := l.CheckString(3)
+			sig := p.SignBytes(domain, []byte(msg))
+			l.Push(lua.LString(sig.String()))
+			return 1
+		}
+
+		l.ArgError(1, "the signBytes func expected 2 parameters")
+		return 1
+	}
+
 	//execute the ringSignFn command on the privkey instance:
 	ringSignFn := func(l *lua.LState) int {
 		p := checkFn(l)
@@ -136,11 +173,33 @@ func (app *module) registerPrivKey(context *lua.LState) {
 		return 1
 	}
 
+	//execute the decrypt command on the privkey instance:
+	decryptFn := func(l *lua.LState) int {
+		p := checkFn(l)
+		if l.GetTop() == 2 {
+			encrypted := l.CheckString(2)
+			decrypted, decryptedErr := p.Decrypt(encrypted)
+			if decryptedErr != nil {
+				str := fmt.Sprintf("there was an error while decrypting a message: %s", decryptedErr.Error())
+				l.RaiseError(str)
+				return 1
+			}
+
+			l.Push(lua.LString(decrypted))
+			return 1
+		}
+
+		l.ArgError(1, "the decrypt func expected 1 parameter")
+		return 1
+	}
+
 	// the users methods:
 	var methods = map[string]lua.LGFunction{
-		"pubKey":   pubKeyFn,
-		"sign":     signFn,
-		"ringSign": ringSignFn,
+		"pubKey":    pubKeyFn,
+		"sign":      signFn,
+		"signBytes": signBytesFn,
+		"ringSign":  ringSignFn,
+		"decrypt":   decryptFn,
 	}
 
 	mt := context.NewTypeMetatable(luaPrivKey)
@@ -152,3 +211,345 @@ func (app *module) registerPrivKey(context *lua.LState) {
 	// methods
 	context.SetField(mt, "__index", context.SetFuncs(context.NewTable(), methods))
 }
+
+func (app *module) registerPubKey(context *lua.LState) {
+	// parse a public key, in any supported encoding, and return its hex representation:
+	newPubKey := func(l *lua.LState) int {
+		if l.GetTop() == 1 {
+			pubKey := fromLuaToPubKey(l, 1)
+			l.Push(lua.LString(pubKey.String()))
+			return 1
+		}
+
+		l.ArgError(1, "the new func expected 1 parameter")
+		return 1
+	}
+
+	// returns true if the string is a valid public key, false otherwise:
+	isValidFn := func(l *lua.LState) int {
+		if l.GetTop() == 1 {
+			_, pubKeyErr := createPubKey(l.CheckString(1))
+			l.Push(lua.LBool(pubKeyErr == nil))
+			return 1
+		}
+
+		l.ArgError(1, "the isValid func expected 1 parameter")
+		return 1
+	}
+
+	// returns true if the public keys are the same, even if they are encoded differently:
+	equalsFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			first := fromLuaToPubKey(l, 1)
+			second := fromLuaToPubKey(l, 2)
+			l.Push(lua.LBool(first.Equals(second)))
+			return 1
+		}
+
+		l.ArgError(1, "the equals func expected 2 parameters")
+		return 1
+	}
+
+	// encode a public key:
+	encodeFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			pubKey := fromLuaToPubKey(l, 1)
+			encoding := l.CheckString(2)
+			encoded, encodedErr := encode(func() string {
+				return crypto.SDKFunc.EncodePubKey(crypto.EncodePubKeyParams{
+					PubKey:   pubKey,
+					Encoding: encoding,
+				})
+			})
+
+			if encodedErr != nil {
+				str := fmt.Sprintf("there was an error while encoding a public key: %s", encodedErr.Error())
+				l.RaiseError(str)
+				return 1
+			}
+
+			l.Push(lua.LString(encoded))
+			return 1
+		}
+
+		l.ArgError(1, "the encode func expected 2 parameters")
+		return 1
+	}
+
+	// create the address of a public key:
+	addressFn := func(l *lua.LState) int {
+		if l.GetTop() == 1 {
+			pubKey := fromLuaToPubKey(l, 1)
+			address := crypto.SDKFunc.CreateAddress(crypto.CreateAddressParams{
+				PubKey: pubKey,
+			})
+
+			l.Push(lua.LString(address))
+			return 1
+		}
+
+		l.ArgError(1, "the address func expected 1 parameter")
+		return 1
+	}
+
+	// verify that an address belongs to a public key:
+	verifyAddressFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			pubKey := fromLuaToPubKey(l, 1)
+			isValid := crypto.SDKFunc.VerifyAddress(crypto.VerifyAddressParams{
+				PubKey:  pubKey,
+				Address: l.CheckString(2),
+			})
+
+			l.Push(lua.LBool(isValid))
+			return 1
+		}
+
+		l.ArgError(1, "the verifyAddress func expected 2 parameters")
+		return 1
+	}
+
+	// encrypt a message to a pubkey:
+	encryptToFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			pubKey := fromLuaToPubKey(l, 1)
+			raiseIfMetered(l, "pubkey.encryptTo", notDeterministic)
+			encrypted := crypto.SDKFunc.EncryptTo(crypto.EncryptToParams{
+				PubKey: pubKey,
+				Msg:    []byte(l.CheckString(2)),
+			})
+
+			l.Push(lua.LString(encrypted))
+			return 1
+		}
+
+		l.ArgError(1, "the encryptTo func expected 2 parameters")
+		return 1
+	}
+
+	mt := context.NewTypeMetatable(luaPubKey)
+	context.SetGlobal(luaPubKey, mt)
+
+	// static attributes
+	context.SetField(mt, "new", context.NewFunction(newPubKey))
+	context.SetField(mt, "isValid", context.NewFunction(isValidFn))
+	context.SetField(mt, "equals", context.NewFunction(equalsFn))
+	context.SetField(mt, "encode", context.NewFunction(encodeFn))
+	context.SetField(mt, "address", context.NewFunction(addressFn))
+	context.SetField(mt, "verifyAddress", context.NewFunction(verifyAddressFn))
+	context.SetField(mt, "encryptTo", context.NewFunction(encryptToFn))
+}
+
+func (app *module) registerSignature(context *lua.LState) {
+	// parse a signature, in any supported encoding, and return its hex representation:
+	newSig := func(l *lua.LState) int {
+		if l.GetTop() == 1 {
+			sig := fromLuaToSig(l, 1)
+			l.Push(lua.LString(sig.String()))
+			return 1
+		}
+
+		l.ArgError(1, "the new func expected 1 parameter")
+		return 1
+	}
+
+	// returns the public key that signed the message:
+	pubKeyFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			sig := fromLuaToSig(l, 1)
+			msg := l.CheckString(2)
+			l.Push(lua.LString(sig.PublicKey(msg).String()))
+			return 1
+		}
+
+		l.ArgError(1, "the pubKey func expected 2 parameters")
+		return 1
+	}
+
+	// verify that the message has been signed by the public key:
+	verifyFn := func(l *lua.LState) int {
+		if l.GetTop() == 3 {
+			sig := fromLuaToSig(l, 1)
+			msg := l.CheckString(2)
+			pubKey := fromLuaToPubKey(l, 3)
+			isValid := sig.Verify(msg) && sig.PublicKey(msg).Equals(pubKey)
+			l.Push(lua.LBool(isValid))
+			return 1
+		}
+
+		l.ArgError(1, "the verify func expected 3 parameters")
+		return 1
+	}
+
+	// verify that the message bytes have been signed by the public key, in the given domain:
+	verifyBytesFn := func(l *lua.LState) int {
+		if l.GetTop() == 4 {
+			sig := fromLuaToSig(l, 1)
+			domain := l.CheckString(2)
+			msg := l.CheckString(3)
+			pubKey := fromLuaToPubKey(l, 4)
+			l.Push(lua.LBool(sig.VerifyBytes(domain, []byte(msg), pubKey)))
+			return 1
+		}
+
+		l.ArgError(1, "the verifyBytes func expected 4 parameters")
+		return 1
+	}
+
+	// encode a signature:
+	encodeFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			sig := fromLuaToSig(l, 1)
+			encoding := l.CheckString(2)
+			encoded, encodedErr := encode(func() string {
+				return crypto.SDKFunc.EncodeSig(crypto.EncodeSigParams{
+					Sig:      sig,
+					Encoding: encoding,
+				})
+			})
+
+			if encodedErr != nil {
+				str := fmt.Sprintf("there was an error while encoding a signature: %s", encodedErr.Error())
+				l.RaiseError(str)
+				return 1
+			}
+
+			l.Push(lua.LString(encoded))
+			return 1
+		}
+
+		l.ArgError(1, "the encode func expected 2 parameters")
+		return 1
+	}
+
+	mt := context.NewTypeMetatable(luaSig)
+	context.SetGlobal(luaSig, mt)
+
+	// static attributes
+	context.SetField(mt, "new", context.NewFunction(newSig))
+	context.SetField(mt, "pubKey", context.NewFunction(pubKeyFn))
+	context.SetField(mt, "verify", context.NewFunction(verifyFn))
+	context.SetField(mt, "verifyBytes", context.NewFunction(verifyBytesFn))
+	context.SetField(mt, "encode", context.NewFunction(encodeFn))
+}
+
+func (app *module) registerRingSignature(context *lua.LState) {
+	// verify that the message has been signed by one of the public keys of the ring:
+	verifyFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			ringSig := fromLuaToRingSig(l, 1)
+			msg := l.CheckString(2)
+			l.Push(lua.LBool(ringSig.Verify(msg)))
+			return 1
+		}
+
+		l.ArgError(1, "the verify func expected 2 parameters")
+		return 1
+	}
+
+	// verify that the message bytes have been signed by one of the public keys of the ring, in the given domain:
+	verifyBytesFn := func(l *lua.LState) int {
+		if l.GetTop() == 3 {
+			ringSig := fromLuaToRingSig(l, 1)
+			domain := l.CheckString(2)
+			msg := l.CheckString(3)
+			l.Push(lua.LBool(ringSig.VerifyBytes(domain, []byte(msg))))
+			return 1
+		}
+
+		l.ArgError(1, "the verifyBytes func expected 3 parameters")
+		return 1
+	}
+
+	mt := context.NewTypeMetatable(luaRingSig)
+	context.SetGlobal(luaRingSig, mt)
+
+	// static attributes
+	context.SetField(mt, "verify", context.NewFunction(verifyFn))
+	context.SetField(mt, "verifyBytes", context.NewFunction(verifyBytesFn))
+}
+
+func (app *module) registerCipher(context *lua.LState) {
+	// encrypt a message with a password:
+	encryptFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			raiseIfMetered(l, "cipher.encrypt", notDeterministic)
+			pass := l.CheckString(1)
+			msg := l.CheckString(2)
+			encrypted := crypto.SDKFunc.Encrypt(crypto.EncryptParams{
+				Pass: []byte(pass),
+				Msg:  []byte(msg),
+			})
+
+			l.Push(lua.LString(encrypted))
+			return 1
+		}
+
+		l.ArgError(1, "the encrypt func expected 2 parameters")
+		return 1
+	}
+
+	// decrypt a message with a password:
+	decryptFn := func(l *lua.LState) int {
+		if l.GetTop() == 2 {
+			raiseIfMetered(l, "cipher.decrypt", "derives its key without being charged to the budget")
+			pass := l.CheckString(1)
+			encrypted := l.CheckString(2)
+			decrypted, decryptedErr := decrypt([]byte(pass), encrypted)
+			if decryptedErr != nil {
+				str := fmt.Sprintf("there was an error while decrypting a message: %s", decryptedErr.Error())
+				l.RaiseError(str)
+				return 1
+			}
+
+			l.Push(lua.LString(decrypted))
+			return 1
+		}
+
+		l.ArgError(1, "the decrypt func expected 2 parameters")
+		return 1
+	}
+
+	mt := context.NewTypeMetatable(luaCipher)
+	context.SetGlobal(luaCipher, mt)
+
+	// static attributes
+	context.SetField(mt, "encrypt", context.NewFunction(encryptFn))
+	context.SetField(mt, "decrypt", context.NewFunction(decryptFn))
+}
+
+func (app *module) registerHash(context *lua.LState) {
+	// create a func that hashes its string parameter and returns the hex encoded digest:
+	createHashFn := func(name string, hashFn func(data []byte) []byte) lua.LGFunction {
+		return func(l *lua.LState) int {
+			if l.GetTop() == 1 {
+				digest := hashFn([]byte(l.CheckString(1)))
+				l.Push(lua.LString(hex.EncodeToString(digest)))
+				return 1
+			}
+
+			str := fmt.Sprintf("the %s func expected 1 parameter", name)
+			l.ArgError(1, str)
+			return 1
+		}
+	}
+
+	mt := context.NewTypeMetatable(luaHash)
+	context.SetGlobal(luaHash, mt)
+
+	// static attributes
+	context.SetField(mt, "sha256", context.NewFunction(createHashFn("sha256", func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		return digest[:]
+	})))
+
+	context.SetField(mt, "sha512", context.NewFunction(createHashFn("sha512", func(data []byte) []byte {
+		digest := sha512.Sum512(data)
+		return digest[:]
+	})))
+
+	context.SetField(mt, "blake2b", context.NewFunction(createHashFn("blake2b", func(data []byte) []byte {
+		digest := blake2b.Sum256(data)
+		return digest[:]
+	})))
+}
@@ -28,3 +28,49 @@ func TestPrivKey_Success(t *testing.T) {
 		return
 	}
 }
+
+func TestSignature_Success(t *testing.T) {
+
+	// variables:
+	scriptPath := "lua/signature_test.lua"
+
+	//create lua state:
+	context := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// create module:
+	createModule(context)
+
+	//execute the script:
+	doFileErr := context.DoFile(scriptPath)
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+}
+
+func TestHash_Success(t *testing.T) {
+
+	// variables:
+	scriptPath := "lua/hash_test.lua"
+
+	//create lua state:
+	context := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// create module:
+	createModule(context)
+
+	//execute the script:
+	doFileErr := context.DoFile(scriptPath)
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+}
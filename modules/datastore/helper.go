@@ -1,6 +1,8 @@
 package datastore
 
 import (
+	"sort"
+
 	"github.com/xmnservices/xmnsuite/datastore/keys"
 	lua "github.com/yuin/gopher-lua"
 )
@@ -10,8 +12,17 @@ func convertHashMapToLTable(hashmap map[string]interface{}) *lua.LTable {
 		return nil
 	}
 
+	// the keynames are inserted in order, so that pairs iterates them in the same order on every node:
+	keynames := []string{}
+	for keyname := range hashmap {
+		keynames = append(keynames, keyname)
+	}
+
+	sort.Strings(keynames)
+
 	out := lua.LTable{}
-	for keyname, value := range hashmap {
+	for _, keyname := range keynames {
+		value := hashmap[keyname]
 		if subHashMap, ok := value.(map[string]interface{}); ok {
 			subLTable := convertHashMapToLTable(subHashMap)
 			out.RawSet(lua.LString(keyname), subLTable)
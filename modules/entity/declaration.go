@@ -0,0 +1,308 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	"github.com/xmnservices/xmnsuite/routers"
+	lua "github.com/yuin/gopher-lua"
+)
+
+var namePattern = regexp.MustCompile("^[a-z][a-z-]{2,}$")
+
+type declaration struct {
+	context   *lua.LState
+	ds        datastore_module.Datastore
+	budget    int
+	name      string
+	fields    map[string]string
+	keynames  *lua.LFunction
+	onSave    *lua.LFunction
+	onDelete  *lua.LFunction
+	met       entity.MetaData
+	rep       entity.Representation
+	deleteRep entity.Representation
+}
+
+func createDeclaration(
+	context *lua.LState,
+	ds datastore_module.Datastore,
+	budget int,
+	name string,
+	fields map[string]string,
+	keynames *lua.LFunction,
+	onSave *lua.LFunction,
+	onDelete *lua.LFunction,
+) (*declaration, error) {
+
+	if !namePattern.MatchString(name) {
+		str := fmt.Sprintf("the entity name (%s) must contain at least 3 lowercase letters or dashes, and start with a letter", name)
+		return nil, errors.New(str)
+	}
+
+	if _, ok := fields[idField]; ok {
+		str := fmt.Sprintf("the %s field is reserved and cannot be declared on the entity (name: %s)", idField, name)
+		return nil, errors.New(str)
+	}
+
+	for keyname, typ := range fields {
+		if typ != StringField && typ != NumberField && typ != BooleanField {
+			str := fmt.Sprintf("the field (%s) of the entity (name: %s) has an invalid type (%s), expected: %s, %s or %s", keyname, name, typ, StringField, NumberField, BooleanField)
+			return nil, errors.New(str)
+		}
+	}
+
+	out := declaration{
+		context:  context,
+		ds:       ds,
+		budget:   budget,
+		name:     name,
+		fields:   fields,
+		keynames: keynames,
+		onSave:   onSave,
+		onDelete: onDelete,
+	}
+
+	out.met = entity.SDKFunc.CreateMetaData(entity.CreateMetaDataParams{
+		Name:            name,
+		ToEntity:        out.toEntity,
+		Normalize:       out.normalize,
+		Denormalize:     out.denormalize,
+		EmptyStorable:   new(storableInstance),
+		EmptyNormalized: new(storableInstance),
+	})
+
+	var onSaveFn entity.OnSave
+	if onSave != nil {
+		onSaveFn = out.onSaveHook
+	}
+
+	var onDeleteFn entity.OnDelete
+	if onDelete != nil {
+		onDeleteFn = out.onDeleteHook
+	}
+
+	out.rep = entity.SDKFunc.CreateRepresentation(entity.CreateRepresentationParams{
+		Met:        out.met,
+		ToStorable: out.normalize,
+		Keynames:   out.retrieveKeynames,
+		OnSave:     onSaveFn,
+		OnDelete:   onDeleteFn,
+	})
+
+	// the service deletes the whole sets of the keynames it is given, so the IDs are removed from the sets by the delete route instead:
+	out.deleteRep = entity.SDKFunc.CreateRepresentation(entity.CreateRepresentationParams{
+		Met:        out.met,
+		ToStorable: out.normalize,
+	})
+
+	return &out, nil
+}
+
+// Name returns the name
+func (app *declaration) Name() string {
+	return app.name
+}
+
+// Representation returns the entity representation
+func (app *declaration) Representation() entity.Representation {
+	return app.rep
+}
+
+// Routes returns the save, retrieve, delete and list routes of the entity
+func (app *declaration) Routes() []routers.CreateRouteParams {
+	return []routers.CreateRouteParams{
+		app.saveRoute(),
+		app.retrieveRoute(),
+		app.deleteRoute(),
+		app.listRoute(),
+		app.listByKeynameRoute(),
+	}
+}
+
+func (app *declaration) toEntity(repository entity.Repository, data interface{}) (entity.Entity, error) {
+	switch casted := data.(type) {
+	case []byte:
+		ins, insErr := createInstanceFromJSON(casted)
+		if insErr != nil {
+			return nil, insErr
+		}
+
+		validateErr := app.validate(ins)
+		if validateErr != nil {
+			return nil, validateErr
+		}
+
+		return ins, nil
+	case *storableInstance:
+		return createInstanceFromStorable(casted)
+	}
+
+	str := fmt.Sprintf("the given data cannot be converted to a %s instance", app.name)
+	return nil, errors.New(str)
+}
+
+func (app *declaration) normalize(ins entity.Entity) (interface{}, error) {
+	if casted, ok := ins.(*instance); ok {
+		return casted.storable()
+	}
+
+	str := fmt.Sprintf("the given entity is not a valid %s instance", app.name)
+	return nil, errors.New(str)
+}
+
+func (app *declaration) denormalize(ins interface{}) (entity.Entity, error) {
+	if casted, ok := ins.(*storableInstance); ok {
+		return createInstanceFromStorable(casted)
+	}
+
+	str := fmt.Sprintf("the given data is not a normalized %s instance", app.name)
+	return nil, errors.New(str)
+}
+
+func (app *declaration) validate(ins *instance) error {
+	// the keynames are sorted so that the same invalid instance always produces the same error, on every node:
+	keynames := []string{}
+	for keyname := range ins.Fields {
+		keynames = append(keynames, keyname)
+	}
+
+	sort.Strings(keynames)
+	for _, keyname := range keynames {
+		typ, ok := app.fields[keyname]
+		if !ok {
+			str := fmt.Sprintf("the field (%s) is not declared on the entity (name: %s)", keyname, app.name)
+			return errors.New(str)
+		}
+
+		isValid := false
+		switch ins.Fields[keyname].(type) {
+		case string:
+			isValid = typ == StringField
+		case float64:
+			isValid = typ == NumberField
+		case bool:
+			isValid = typ == BooleanField
+		}
+
+		if !isValid {
+			str := fmt.Sprintf("the field (%s) of the entity (name: %s) was expected to be a %s", keyname, app.name, typ)
+			return errors.New(str)
+		}
+	}
+
+	declared := []string{}
+	for keyname := range app.fields {
+		declared = append(declared, keyname)
+	}
+
+	sort.Strings(declared)
+	for _, keyname := range declared {
+		if _, ok := ins.Fields[keyname]; !ok {
+			str := fmt.Sprintf("the field (%s) of the entity (name: %s) is mandatory", keyname, app.name)
+			return errors.New(str)
+		}
+	}
+
+	return nil
+}
+
+func (app *declaration) retrieveKeynames(ins entity.Entity) ([]string, error) {
+	// every instance is in the set of its entity, so that they can all be listed:
+	out := []string{
+		app.met.Keyname(),
+	}
+
+	if app.keynames == nil {
+		return out, nil
+	}
+
+	casted, ok := ins.(*instance)
+	if !ok {
+		str := fmt.Sprintf("the given entity is not a valid %s instance", app.name)
+		return nil, errors.New(str)
+	}
+
+	value, valueErr := app.call("keynames", app.keynames, casted.toLua(app.context))
+	if valueErr != nil {
+		return nil, valueErr
+	}
+
+	if luaKeynames, ok := value.(*lua.LTable); ok {
+		luaKeynames.ForEach(func(key lua.LValue, oneKeyname lua.LValue) {
+			out = append(out, oneKeyname.String())
+		})
+
+		return out, nil
+	}
+
+	str := fmt.Sprintf("the keynames hook of the entity (name: %s) was expected to return a table", app.name)
+	return nil, errors.New(str)
+}
+
+func (app *declaration) onSaveHook(ds datastore.DataStore, ins entity.Entity) error {
+	return app.hook("onSave", app.onSave, ds, ins)
+}
+
+func (app *declaration) onDeleteHook(ds datastore.DataStore, ins entity.Entity) error {
+	return app.hook("onDelete", app.onDelete, ds, ins)
+}
+
+func (app *declaration) hook(name string, fn *lua.LFunction, ds datastore.DataStore, ins entity.Entity) error {
+	casted, ok := ins.(*instance)
+	if !ok {
+		str := fmt.Sprintf("the given entity is not a valid %s instance", app.name)
+		return errors.New(str)
+	}
+
+	// the hook works on the datastore of the transaction:
+	app.ds.Replace(ds)
+
+	// a hook aborts the transaction by returning an error message:
+	value, valueErr := app.call(name, fn, casted.toLua(app.context))
+	if valueErr != nil {
+		return valueErr
+	}
+
+	if value != lua.LNil {
+		str := fmt.Sprintf("the %s hook of the entity (name: %s) aborted the transaction: %s", name, app.name, value.String())
+		return errors.New(str)
+	}
+
+	return nil
+}
+
+func (app *declaration) call(name string, fn *lua.LFunction, args ...lua.LValue) (lua.LValue, error) {
+	luaP := lua.P{
+		Fn:      fn,
+		NRet:    1,
+		Protect: true,
+	}
+
+	// call the func, in its budget:
+	meter := sandbox.SDKFunc.CreateMeter(sandbox.CreateMeterParams{
+		Context: app.context,
+		Budget:  app.budget,
+	})
+
+	callErr := meter.Call(luaP, args...)
+	if meter.IsExhausted() {
+		str := fmt.Sprintf("the %s hook of the entity (name: %s) has been aborted since it exhausted its budget of %d lua instructions", name, app.name, app.budget)
+		return nil, errors.New(str)
+	}
+
+	if callErr != nil {
+		return nil, callErr
+	}
+
+	// retrieve the returned value:
+	value := app.context.Get(-1)
+	app.context.Pop(1)
+	return value, nil
+}
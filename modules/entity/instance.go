@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const idField = "id"
+
+type instance struct {
+	UUID   *uuid.UUID
+	Fields map[string]interface{}
+}
+
+type storableInstance struct {
+	ID     string `json:"id"`
+	Fields []byte `json:"fields"`
+}
+
+func createInstance(id *uuid.UUID, fields map[string]interface{}) *instance {
+	out := instance{
+		UUID:   id,
+		Fields: fields,
+	}
+
+	return &out
+}
+
+func createInstanceFromJSON(data []byte) (*instance, error) {
+	fields := map[string]interface{}{}
+	jsErr := json.Unmarshal(data, &fields)
+	if jsErr != nil {
+		str := fmt.Sprintf("the given data is not a valid json object: %s", jsErr.Error())
+		return nil, errors.New(str)
+	}
+
+	idAsString, ok := fields[idField].(string)
+	if !ok {
+		str := fmt.Sprintf("the given data does not contain the %s field", idField)
+		return nil, errors.New(str)
+	}
+
+	id, idErr := uuid.FromString(idAsString)
+	if idErr != nil {
+		str := fmt.Sprintf("the given ID (%s) is invalid: %s", idAsString, idErr.Error())
+		return nil, errors.New(str)
+	}
+
+	delete(fields, idField)
+	return createInstance(&id, fields), nil
+}
+
+func createInstanceFromStorable(storable *storableInstance) (*instance, error) {
+	id, idErr := uuid.FromString(storable.ID)
+	if idErr != nil {
+		str := fmt.Sprintf("the stored ID (%s) is invalid: %s", storable.ID, idErr.Error())
+		return nil, errors.New(str)
+	}
+
+	fields := map[string]interface{}{}
+	jsErr := json.Unmarshal(storable.Fields, &fields)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	return createInstance(&id, fields), nil
+}
+
+// ID returns the ID
+func (obj *instance) ID() *uuid.UUID {
+	return obj.UUID
+}
+
+// MarshalJSON converts the instance to a flat json object
+func (obj *instance) MarshalJSON() ([]byte, error) {
+	out := map[string]interface{}{}
+	for keyname, value := range obj.Fields {
+		out[keyname] = value
+	}
+
+	out[idField] = obj.UUID.String()
+	return json.Marshal(out)
+}
+
+func (obj *instance) storable() (*storableInstance, error) {
+	js, jsErr := json.Marshal(obj.Fields)
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	out := storableInstance{
+		ID:     obj.UUID.String(),
+		Fields: js,
+	}
+
+	return &out, nil
+}
+
+func (obj *instance) toLua(l *lua.LState) *lua.LTable {
+	// the keynames are inserted in order, so that pairs iterates them in the same order on every node:
+	keynames := []string{}
+	for keyname := range obj.Fields {
+		keynames = append(keynames, keyname)
+	}
+
+	sort.Strings(keynames)
+
+	out := l.NewTable()
+	for _, keyname := range keynames {
+		switch casted := obj.Fields[keyname].(type) {
+		case string:
+			out.RawSetString(keyname, lua.LString(casted))
+		case float64:
+			out.RawSetString(keyname, lua.LNumber(casted))
+		case bool:
+			out.RawSetString(keyname, lua.LBool(casted))
+		}
+	}
+
+	out.RawSetString(idField, lua.LString(obj.UUID.String()))
+	return out
+}
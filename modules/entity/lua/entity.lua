@@ -0,0 +1,26 @@
+-- load the modules:
+local entity = require("entity")
+
+-- the latest deleted message:
+deleted = nil
+
+messages = entity.new({
+    name = "message",
+    fields = {
+        title = "string",
+        votes = "number",
+    },
+    keynames = function(ins)
+        return {
+            "message:by_title:" .. ins.title,
+        }
+    end,
+    onSave = function(ins)
+        if ins.title == "forbidden" then
+            return "the title is forbidden"
+        end
+    end,
+    onDelete = function(ins)
+        deleted = messages:retrieve(ins.id)
+    end,
+})
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
+	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const luaEntity = "entity"
+
+type module struct {
+	context *lua.LState
+	ds      datastore_module.Datastore
+	budget  int
+	decls   []*declaration
+}
+
+func createModule(context *lua.LState, ds datastore_module.Datastore, budget int) Entity {
+	out := module{
+		context: context,
+		ds:      ds,
+		budget:  budget,
+		decls:   []*declaration{},
+	}
+
+	out.register()
+
+	return &out
+}
+
+// Declarations returns the entities declared in lua
+func (app *module) Declarations() []Declaration {
+	out := []Declaration{}
+	for _, oneDecl := range app.decls {
+		out = append(out, oneDecl)
+	}
+
+	return out
+}
+
+func (app *module) register() {
+	// preload entity:
+	app.context.PreloadModule("entity", func(context *lua.LState) int {
+		methods := map[string]lua.LGFunction{
+			"new": func(context *lua.LState) int {
+				return app.newEntity(context)
+			},
+		}
+
+		ntable := context.NewTable()
+		context.SetFuncs(ntable, methods)
+		context.Push(ntable)
+
+		app.registerEntity(context)
+
+		return 1
+	})
+}
+
+func (app *module) newEntity(context *lua.LState) int {
+	amount := context.GetTop()
+	if amount != 1 {
+		context.ArgError(1, "the new function was expected to have 1 parameter")
+		return 1
+	}
+
+	// convert the table argument to a declaration:
+	tb := context.CheckTable(1)
+	name := tb.RawGetString("name").String()
+
+	fields := map[string]string{}
+	if rawFields, ok := tb.RawGetString("fields").(*lua.LTable); ok {
+		rawFields.ForEach(func(keyname lua.LValue, typ lua.LValue) {
+			fields[keyname.String()] = typ.String()
+		})
+	}
+
+	fetchFn := func(keyname string) *lua.LFunction {
+		if fn, ok := tb.RawGetString(keyname).(*lua.LFunction); ok {
+			return fn
+		}
+
+		return nil
+	}
+
+	decl, declErr := createDeclaration(
+		app.context,
+		app.ds,
+		app.budget,
+		name,
+		fields,
+		fetchFn("keynames"),
+		fetchFn("onSave"),
+		fetchFn("onDelete"),
+	)
+
+	if declErr != nil {
+		str := fmt.Sprintf("the passed table argument is invalid: %s", declErr.Error())
+		context.ArgError(1, str)
+		return 1
+	}
+
+	for _, oneDecl := range app.decls {
+		if oneDecl.name == decl.name {
+			str := fmt.Sprintf("the entity (name: %s) has already been declared", decl.name)
+			context.ArgError(1, str)
+			return 1
+		}
+	}
+
+	// add the declaration:
+	app.decls = append(app.decls, decl)
+
+	// set the value:
+	ud := context.NewUserData()
+	ud.Value = decl
+
+	context.SetMetatable(ud, context.GetTypeMetatable(luaEntity))
+	context.Push(ud)
+	return 1
+}
+
+func (app *module) registerEntity(context *lua.LState) {
+	//verifies that the given type is an entity declaration:
+	checkFn := func(l *lua.LState) *declaration {
+		ud := l.CheckUserData(1)
+		if v, ok := ud.Value.(*declaration); ok {
+			return v
+		}
+
+		l.ArgError(1, "entity expected")
+		return nil
+	}
+
+	//returns the name of the entity:
+	nameFn := func(l *lua.LState) int {
+		decl := checkFn(l)
+		if decl == nil {
+			return 1
+		}
+
+		l.Push(lua.LString(decl.name))
+		return 1
+	}
+
+	//retrieves an instance of the entity by its ID, from the current datastore:
+	retrieveFn := func(l *lua.LState) int {
+		decl := checkFn(l)
+		if l.GetTop() != 2 {
+			l.ArgError(1, "the retrieve func expected 1 parameter")
+			return 1
+		}
+
+		id, idErr := uuid.FromString(l.CheckString(2))
+		if idErr != nil {
+			l.ArgError(2, "the given id is not a valid uuid")
+			return 1
+		}
+
+		repository := entity.SDKFunc.CreateRepository(app.ds.Get())
+		ins, insErr := repository.RetrieveByID(decl.met, &id)
+		if insErr != nil {
+			l.Push(lua.LNil)
+			return 1
+		}
+
+		l.Push(ins.(*instance).toLua(l))
+		return 1
+	}
+
+	// the entity methods:
+	var methods = map[string]lua.LGFunction{
+		"name":     nameFn,
+		"retrieve": retrieveFn,
+	}
+
+	mt := context.NewTypeMetatable(luaEntity)
+	context.SetField(mt, "__index", context.SetFuncs(context.NewTable(), methods))
+}
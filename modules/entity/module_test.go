@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	"github.com/xmnservices/xmnsuite/routers"
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestModule_Success(t *testing.T) {
+	// variables:
+	scriptPath := "lua/entity.lua"
+	store := datastore.SDKFunc.Create()
+	from := crypto.SDKFunc.GenPK().PublicKey()
+	id := uuid.NewV4()
+	path := fmt.Sprintf("/message/%s", id.String())
+	params := map[string]string{
+		"id": id.String(),
+	}
+
+	//create lua state:
+	context := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// create the datastore module:
+	dsMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+		Context:   context,
+		Datastore: datastore.SDKFunc.Create(),
+	})
+
+	// create module:
+	module := createModule(context, dsMod, sandbox.DefaultBudget)
+
+	//execute the script:
+	doFileErr := context.DoFile(scriptPath)
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+
+	decls := module.Declarations()
+	if len(decls) != 1 {
+		t.Errorf("%d declarations were expected, %d returned", 1, len(decls))
+		return
+	}
+
+	rtes := decls[0].Routes()
+	save, retrieve, del, list, listByKeyname := rtes[0].SaveTrx, rtes[1].QueryTrx, rtes[2].DelTrx, rtes[3].QueryTrx, rtes[4].QueryTrx
+
+	// save:
+	saveResp, saveErr := save(store, from, "/message", map[string]string{}, []byte(fmt.Sprintf(`{"id":"%s","title":"hello","votes":3}`, id.String())), nil)
+	if saveErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", saveErr.Error())
+		return
+	}
+
+	if saveResp.Code() != routers.IsSuccessful {
+		t.Errorf("the save was expected to be successful: %s", saveResp.Log())
+		return
+	}
+
+	// saving the same instance again, an instance with an invalid field, or an instance rejected by the onSave hook, fails:
+	invalids := []string{
+		fmt.Sprintf(`{"id":"%s","title":"hello","votes":3}`, id.String()),
+		fmt.Sprintf(`{"id":"%s","title":"hello","votes":"3"}`, uuid.NewV4().String()),
+		fmt.Sprintf(`{"id":"%s","title":"hello"}`, uuid.NewV4().String()),
+		fmt.Sprintf(`{"id":"%s","title":"forbidden","votes":3}`, uuid.NewV4().String()),
+	}
+
+	for _, oneInvalid := range invalids {
+		_, invalidErr := save(store, from, "/message", map[string]string{}, []byte(oneInvalid), nil)
+		if invalidErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (data: %s)", oneInvalid)
+			return
+		}
+	}
+
+	// retrieve:
+	retResp, retErr := retrieve(store, from, path, params, nil)
+	if retErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", retErr.Error())
+		return
+	}
+
+	retIns := map[string]interface{}{}
+	json.Unmarshal(retResp.Value(), &retIns)
+	if retIns["id"] != id.String() || retIns["title"] != "hello" || retIns["votes"] != float64(3) {
+		t.Errorf("the retrieved instance is invalid: %s", retResp.Value())
+		return
+	}
+
+	// list by keyname:
+	listResp, listErr := listByKeyname(store, from, "/message/message:by_title:hello/0/10", map[string]string{
+		"keyname": "message:by_title:hello",
+		"index":   "0",
+		"amount":  "10",
+	}, nil)
+
+	if listErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", listErr.Error())
+		return
+	}
+
+	ps := partialSet{}
+	json.Unmarshal(listResp.Value(), &ps)
+	if ps.TotalAmount != 1 {
+		t.Errorf("the total amount was expected to be %d, %d returned", 1, ps.TotalAmount)
+		return
+	}
+
+	// delete:
+	delResp, delErr := del(store, from, path, params, nil)
+	if delErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", delErr.Error())
+		return
+	}
+
+	if delResp.Code() != routers.IsSuccessful {
+		t.Errorf("the delete was expected to be successful: %s", delResp.Log())
+		return
+	}
+
+	// the onDelete hook was executed before the instance was deleted:
+	deleted, ok := context.GetGlobal("deleted").(*lua.LTable)
+	if !ok || deleted.RawGetString("id").String() != id.String() {
+		t.Errorf("the onDelete hook was expected to retrieve the deleted instance")
+		return
+	}
+
+	// the instance is no longer retrievable, nor listed:
+	_, retAfterDelErr := retrieve(store, from, path, params, nil)
+	if retAfterDelErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	listAllResp, listAllErr := list(store, from, "/message/0/10", map[string]string{
+		"index":  "0",
+		"amount": "10",
+	}, nil)
+
+	if listAllErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", listAllErr.Error())
+		return
+	}
+
+	psAll := partialSet{}
+	json.Unmarshal(listAllResp.Value(), &psAll)
+	if psAll.TotalAmount != 0 {
+		t.Errorf("the total amount was expected to be %d, %d returned", 0, psAll.TotalAmount)
+		return
+	}
+}
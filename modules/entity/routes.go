@@ -0,0 +1,259 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	"github.com/xmnservices/xmnsuite/routers"
+)
+
+const idPattern = "<id|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}>"
+
+const pagePattern = "<index|[0-9]+>/<amount|[0-9]+>"
+
+type partialSet struct {
+	Instances   []*instance `json:"entities"`
+	Index       int         `json:"index"`
+	TotalAmount int         `json:"total_amount"`
+}
+
+/*
+ * Save
+ * Expected data:
+ *      a json object that contains the id of the instance and its declared fields
+ */
+func (app *declaration) saveRoute() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: fmt.Sprintf("/%s", app.name),
+		SaveTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, data []byte, sig crypto.Signature) (routers.TransactionResponse, error) {
+			//replace the datastore:
+			app.ds.Replace(store)
+
+			// create the repository and service:
+			repository := entity.SDKFunc.CreateRepository(store)
+			service := entity.SDKFunc.CreateService(store)
+
+			// convert the data to an entity:
+			ins, insErr := app.met.ToEntity()(repository, data)
+			if insErr != nil {
+				return nil, insErr
+			}
+
+			// make sure the entity does not already exists:
+			_, alreadyExistsErr := repository.RetrieveByID(app.met, ins.ID())
+			if alreadyExistsErr == nil {
+				str := fmt.Sprintf("the entity (Name: %s, ID: %s) already exists", app.name, ins.ID().String())
+				return nil, errors.New(str)
+			}
+
+			// save the entity:
+			saveErr := service.Save(ins, app.rep)
+			if saveErr != nil {
+				return nil, saveErr
+			}
+
+			// convert to json:
+			js, jsErr := json.Marshal(ins)
+			if jsErr != nil {
+				return nil, jsErr
+			}
+
+			// return the response:
+			resp := routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+				Code:    routers.IsSuccessful,
+				Log:     "success",
+				GazUsed: int64(len(js)),
+				Tags: map[string][]byte{
+					fmt.Sprintf("%s/%s", path, ins.ID().String()): js,
+				},
+			})
+
+			return resp, nil
+		},
+	}
+}
+
+func (app *declaration) retrieveRoute() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: fmt.Sprintf("/%s/%s", app.name, idPattern),
+		QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+			// retrieve the entity:
+			ins, insErr := app.retrieve(store, params)
+			if insErr != nil {
+				return nil, insErr
+			}
+
+			// convert to json:
+			js, jsErr := json.Marshal(ins)
+			if jsErr != nil {
+				return nil, jsErr
+			}
+
+			// return the response:
+			resp := routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+				Code:  routers.IsSuccessful,
+				Log:   "success",
+				Key:   path,
+				Value: js,
+			})
+
+			return resp, nil
+		},
+	}
+}
+
+func (app *declaration) deleteRoute() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: fmt.Sprintf("/%s/%s", app.name, idPattern),
+		DelTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.TransactionResponse, error) {
+			//replace the datastore:
+			app.ds.Replace(store)
+
+			// retrieve the entity:
+			ins, insErr := app.retrieve(store, params)
+			if insErr != nil {
+				return nil, insErr
+			}
+
+			// execute the onDelete hook:
+			if onDelete := app.rep.OnDelete(); onDelete != nil {
+				onDeleteErr := onDelete(store, ins)
+				if onDeleteErr != nil {
+					return nil, onDeleteErr
+				}
+			}
+
+			// retrieve the keynames before the entity is deleted:
+			keynames, keynamesErr := app.rep.Keynames()(ins)
+			if keynamesErr != nil {
+				return nil, keynamesErr
+			}
+
+			// delete the entity:
+			service := entity.SDKFunc.CreateService(store)
+			delErr := service.Delete(ins, app.deleteRep)
+			if delErr != nil {
+				return nil, delErr
+			}
+
+			// remove the entity from its sets:
+			store.Sets().DelMul(keynames, ins.ID().String())
+
+			// convert to json:
+			js, jsErr := json.Marshal(ins)
+			if jsErr != nil {
+				return nil, jsErr
+			}
+
+			// return the response:
+			resp := routers.SDKFunc.CreateTransactionResponse(routers.CreateTransactionResponseParams{
+				Code:    routers.IsSuccessful,
+				Log:     "success",
+				GazUsed: int64(len(js)),
+				Tags: map[string][]byte{
+					path: js,
+				},
+			})
+
+			return resp, nil
+		},
+	}
+}
+
+func (app *declaration) listRoute() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: fmt.Sprintf("/%s/%s", app.name, pagePattern),
+		QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+			return app.list(store, path, app.met.Keyname(), params)
+		},
+	}
+}
+
+/*
+ * ListByKeyname
+ * Expected params:
+ *      <keyname|[^/]+>: one of the keynames returned by the keynames hook of the entity
+ */
+func (app *declaration) listByKeynameRoute() routers.CreateRouteParams {
+	return routers.CreateRouteParams{
+		Pattern: fmt.Sprintf("/%s/<keyname|[^/]+>/%s", app.name, pagePattern),
+		QueryTrx: func(store datastore.DataStore, from crypto.PublicKey, path string, params map[string]string, sig crypto.Signature) (routers.QueryResponse, error) {
+			return app.list(store, path, params["keyname"], params)
+		},
+	}
+}
+
+func (app *declaration) retrieve(store datastore.DataStore, params map[string]string) (*instance, error) {
+	// parse the id:
+	id, idErr := uuid.FromString(params["id"])
+	if idErr != nil {
+		str := fmt.Sprintf("the given ID (%s) is invalid: %s", params["id"], idErr.Error())
+		return nil, errors.New(str)
+	}
+
+	// retrieve the entity instance:
+	repository := entity.SDKFunc.CreateRepository(store)
+	ins, insErr := repository.RetrieveByID(app.met, &id)
+	if insErr != nil {
+		return nil, insErr
+	}
+
+	return ins.(*instance), nil
+}
+
+func (app *declaration) list(store datastore.DataStore, path string, keyname string, params map[string]string) (routers.QueryResponse, error) {
+	index, indexErr := strconv.Atoi(params["index"])
+	if indexErr != nil {
+		str := fmt.Sprintf("the given index (%s) is invalid: %s", params["index"], indexErr.Error())
+		return nil, errors.New(str)
+	}
+
+	amount, amountErr := strconv.Atoi(params["amount"])
+	if amountErr != nil {
+		str := fmt.Sprintf("the given amount (%s) is invalid: %s", params["amount"], amountErr.Error())
+		return nil, errors.New(str)
+	}
+
+	if amount > MaxAmountOfElements {
+		amount = MaxAmountOfElements
+	}
+
+	// retrieve the entity partial set:
+	repository := entity.SDKFunc.CreateRepository(store)
+	ps, psErr := repository.RetrieveSetByKeyname(app.met, keyname, index, amount)
+	if psErr != nil {
+		return nil, psErr
+	}
+
+	instances := []*instance{}
+	for _, oneIns := range ps.Instances() {
+		instances = append(instances, oneIns.(*instance))
+	}
+
+	// convert to json:
+	js, jsErr := json.Marshal(&partialSet{
+		Instances:   instances,
+		Index:       ps.Index(),
+		TotalAmount: ps.TotalAmount(),
+	})
+
+	if jsErr != nil {
+		return nil, jsErr
+	}
+
+	// return the response:
+	resp := routers.SDKFunc.CreateQueryResponse(routers.CreateQueryResponseParams{
+		Code:  routers.IsSuccessful,
+		Log:   "success",
+		Key:   path,
+		Value: js,
+	})
+
+	return resp, nil
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"github.com/xmnservices/xmnsuite/blockchains/core/objects/entity"
+	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	"github.com/xmnservices/xmnsuite/routers"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const (
+	// StringField represents a string field type
+	StringField = "string"
+
+	// NumberField represents a number field type
+	NumberField = "number"
+
+	// BooleanField represents a boolean field type
+	BooleanField = "boolean"
+)
+
+const (
+	// MaxAmountOfElements represents the maximum amount of entities returned by the list routes
+	MaxAmountOfElements = 100
+)
+
+// Entity represents the entity module
+type Entity interface {
+	Declarations() []Declaration
+}
+
+// Declaration represents an entity declared in lua
+type Declaration interface {
+	Name() string
+	Representation() entity.Representation
+	Routes() []routers.CreateRouteParams
+}
+
+// CreateParams represents the create params
+type CreateParams struct {
+	Context   *lua.LState
+	Datastore datastore_module.Datastore
+	Budget    int
+}
+
+// SDKFunc represents the entity module SDK func
+var SDKFunc = struct {
+	Create func(params CreateParams) Entity
+}{
+	Create: func(params CreateParams) Entity {
+		if params.Budget == 0 {
+			params.Budget = sandbox.DefaultBudget
+		}
+
+		out := createModule(params.Context, params.Datastore, params.Budget)
+		return out
+	},
+}
@@ -0,0 +1,21 @@
+-- load the modules:
+local events = require("events")
+
+-- emit the events:
+events.emit("transfer", {
+    from = "alice",
+    to = "bob",
+    amount = 45,
+})
+
+events.emit("transfer", {
+    from = "bob",
+    to = "carol",
+    amount = 12,
+})
+
+events.emit("audited", {
+    approved = true,
+})
+
+events.emit("pinged")
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+var keyPattern = regexp.MustCompile("^[a-z][a-z0-9_-]*$")
+
+type event struct {
+	name       string
+	attributes map[string]string
+}
+
+type module struct {
+	context *lua.LState
+	evts    []*event
+}
+
+func createModule(context *lua.LState) Events {
+	out := module{
+		context: context,
+		evts:    []*event{},
+	}
+
+	out.register()
+
+	return &out
+}
+
+// Flush returns the tags of the events emitted since the last flush, then forgets them
+func (app *module) Flush() map[string][]byte {
+	out := map[string][]byte{}
+	amounts := map[string]int{}
+	for _, oneEvent := range app.evts {
+		prefix := oneEvent.name
+		if amount := amounts[oneEvent.name]; amount > 0 {
+			prefix = fmt.Sprintf("%s.%d", oneEvent.name, amount)
+		}
+
+		for attribute, value := range oneEvent.attributes {
+			out[fmt.Sprintf("%s.%s", prefix, attribute)] = []byte(value)
+		}
+
+		amounts[oneEvent.name]++
+	}
+
+	for name, amount := range amounts {
+		out[name] = []byte(strconv.Itoa(amount))
+	}
+
+	app.evts = []*event{}
+	return out
+}
+
+func (app *module) register() {
+	// preload events:
+	app.context.PreloadModule("events", func(context *lua.LState) int {
+		methods := map[string]lua.LGFunction{
+			"emit": app.emit,
+		}
+
+		ntable := context.NewTable()
+		context.SetFuncs(ntable, methods)
+		context.Push(ntable)
+
+		return 1
+	})
+}
+
+func (app *module) emit(context *lua.LState) int {
+	amount := context.GetTop()
+	if amount != 1 && amount != 2 {
+		context.ArgError(1, "the emit func expected 1 or 2 parameters")
+		return 0
+	}
+
+	name := context.CheckString(1)
+	if !keyPattern.MatchString(name) {
+		str := fmt.Sprintf("the event name (%s) must match %s", name, keyPattern.String())
+		context.ArgError(1, str)
+		return 0
+	}
+
+	attributes := map[string]string{}
+	if amount == 2 {
+		// the attributes are sorted so that the same invalid event always produces the same error, on every node:
+		tb := context.CheckTable(2)
+		keys := []string{}
+		tb.ForEach(func(key lua.LValue, value lua.LValue) {
+			keys = append(keys, key.String())
+		})
+
+		sort.Strings(keys)
+		for _, oneKey := range keys {
+			if !keyPattern.MatchString(oneKey) {
+				str := fmt.Sprintf("the attribute (%s) of the event (name: %s) must match %s", oneKey, name, keyPattern.String())
+				context.ArgError(2, str)
+				return 0
+			}
+
+			switch value := tb.RawGetString(oneKey).(type) {
+			case lua.LString, lua.LNumber, lua.LBool:
+				attributes[oneKey] = value.String()
+			default:
+				str := fmt.Sprintf("the attribute (%s) of the event (name: %s) must be a string, a number or a boolean, %s given", oneKey, name, value.Type().String())
+				context.ArgError(2, str)
+				return 0
+			}
+		}
+	}
+
+	app.evts = append(app.evts, &event{
+		name:       name,
+		attributes: attributes,
+	})
+
+	return 0
+}
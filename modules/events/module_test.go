@@ -0,0 +1,83 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestEvents_Success(t *testing.T) {
+	//create lua state:
+	context := lua.NewState(lua.Options{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// create module:
+	evts := createModule(context)
+
+	//execute:
+	doFileErr := context.DoFile("lua/events.lua")
+	if doFileErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doFileErr.Error())
+		return
+	}
+
+	expected := map[string][]byte{
+		"transfer":          []byte("2"),
+		"transfer.from":     []byte("alice"),
+		"transfer.to":       []byte("bob"),
+		"transfer.amount":   []byte("45"),
+		"transfer.1.from":   []byte("bob"),
+		"transfer.1.to":     []byte("carol"),
+		"transfer.1.amount": []byte("12"),
+		"audited":           []byte("1"),
+		"audited.approved":  []byte("true"),
+		"pinged":            []byte("1"),
+	}
+
+	tags := evts.Flush()
+	if !reflect.DeepEqual(expected, tags) {
+		t.Errorf("the returned tags are invalid.  Expected: %v, Returned: %v", expected, tags)
+		return
+	}
+
+	// the events are forgotten once flushed:
+	if len(evts.Flush()) != 0 {
+		t.Errorf("the events were expected to be forgotten once flushed")
+		return
+	}
+}
+
+func TestEvents_withInvalidEvent_returnsError(t *testing.T) {
+	//variables:
+	invalids := []string{
+		`require("events").emit("Transfer")`,
+		`require("events").emit("transfer", {["To"] = "bob"})`,
+		`require("events").emit("transfer", {to = {}})`,
+		`require("events").emit()`,
+	}
+
+	for _, oneInvalid := range invalids {
+		//create lua state:
+		context := lua.NewState()
+
+		// create module:
+		evts := createModule(context)
+
+		//execute:
+		doErr := context.DoString(oneInvalid)
+		context.Close()
+		if doErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (script: %s)", oneInvalid)
+			return
+		}
+
+		if len(evts.Flush()) != 0 {
+			t.Errorf("the invalid event was not expected to be emitted (script: %s)", oneInvalid)
+			return
+		}
+	}
+}
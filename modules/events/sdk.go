@@ -0,0 +1,39 @@
+package events
+
+import (
+	lua "github.com/yuin/gopher-lua"
+)
+
+/*
+ * Events
+ *
+ * The lua handlers emit events using events.emit(name, attributes).  Once the handler returns,
+ * the events are added to the tags of its TransactionResponse, so that they are indexed by the
+ * blockchain and can be filtered by the client subscriptions:
+ *
+ *      <name>:                 the amount of times the event has been emitted by the transaction
+ *      <name>.<attribute>:     the value of the attribute of the first emitted event
+ *      <name>.<n>.<attribute>: the value of the attribute of the (n+1)th emitted event
+ *
+ * The names and the attributes must match ^[a-z][a-z0-9_-]*$.  The values are strings, numbers or booleans.
+ */
+
+// Events represents the events module
+type Events interface {
+	Flush() map[string][]byte
+}
+
+// CreateParams represents the create params
+type CreateParams struct {
+	Context *lua.LState
+}
+
+// SDKFunc represents the events module SDK func
+var SDKFunc = struct {
+	Create func(params CreateParams) Events
+}{
+	Create: func(params CreateParams) Events {
+		out := createModule(params.Context)
+		return out
+	},
+}
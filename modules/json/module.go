@@ -0,0 +1,98 @@
+package json
+
+import (
+	"encoding/json"
+	"sort"
+
+	lua "github.com/yuin/gopher-lua"
+	luajson "layeh.com/gopher-json"
+)
+
+type module struct {
+}
+
+func createModule(context *lua.LState) JSON {
+	out := module{}
+	out.register(context)
+	return &out
+}
+
+func (app *module) register(context *lua.LState) {
+	// preload json:
+	context.PreloadModule("json", func(l *lua.LState) int {
+		methods := map[string]lua.LGFunction{
+			"encode": app.encode,
+			"decode": app.decode,
+		}
+
+		ntable := l.NewTable()
+		l.SetFuncs(ntable, methods)
+		l.Push(ntable)
+
+		return 1
+	})
+}
+
+func (app *module) encode(l *lua.LState) int {
+	value := l.CheckAny(1)
+	data, dataErr := luajson.Encode(value)
+	if dataErr != nil {
+		l.Push(lua.LNil)
+		l.Push(lua.LString(dataErr.Error()))
+		return 2
+	}
+
+	l.Push(lua.LString(string(data)))
+	return 1
+}
+
+func (app *module) decode(l *lua.LState) int {
+	str := l.CheckString(1)
+
+	var value interface{}
+	jsErr := json.Unmarshal([]byte(str), &value)
+	if jsErr != nil {
+		l.Push(lua.LNil)
+		l.Push(lua.LString(jsErr.Error()))
+		return 2
+	}
+
+	l.Push(fromValueToLua(l, value))
+	return 1
+}
+
+// fromValueToLua converts a decoded json value to lua, the keys of the objects are inserted in order so that pairs
+// iterates them in the same order on every node
+func fromValueToLua(l *lua.LState, value interface{}) lua.LValue {
+	switch casted := value.(type) {
+	case bool:
+		return lua.LBool(casted)
+	case float64:
+		return lua.LNumber(casted)
+	case string:
+		return lua.LString(casted)
+	case []interface{}:
+		out := l.CreateTable(len(casted), 0)
+		for index, oneElement := range casted {
+			out.RawSetInt(index+1, fromValueToLua(l, oneElement))
+		}
+
+		return out
+	case map[string]interface{}:
+		keynames := []string{}
+		for keyname := range casted {
+			keynames = append(keynames, keyname)
+		}
+
+		sort.Strings(keynames)
+
+		out := l.CreateTable(0, len(casted))
+		for _, oneKeyname := range keynames {
+			out.RawSetString(oneKeyname, fromValueToLua(l, casted[oneKeyname]))
+		}
+
+		return out
+	}
+
+	return lua.LNil
+}
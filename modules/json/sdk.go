@@ -2,12 +2,8 @@ package json
 
 import (
 	lua "github.com/yuin/gopher-lua"
-	luajson "layeh.com/gopher-json"
 )
 
-type module struct {
-}
-
 // JSON represents the json module
 type JSON interface {
 }
@@ -22,7 +18,7 @@ var SDKFunc = struct {
 	Create func(params CreateParams) JSON
 }{
 	Create: func(params CreateParams) JSON {
-		luajson.Preload(params.Context)
-		return &module{}
+		out := createModule(params.Context)
+		return out
 	},
 }
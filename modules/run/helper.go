@@ -0,0 +1,53 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// the instance ID identifies the chain in its database directory, so it is kept between runs:
+func retrieveInstanceID(dbPath string, id *uuid.UUID) (*uuid.UUID, error) {
+	filePath := filepath.Join(dbPath, InstanceIDFileName)
+	data, dataErr := ioutil.ReadFile(filePath)
+	if dataErr != nil && !os.IsNotExist(dataErr) {
+		return nil, dataErr
+	}
+
+	if dataErr == nil {
+		savedID, savedIDErr := uuid.FromString(strings.TrimSpace(string(data)))
+		if savedIDErr != nil {
+			str := fmt.Sprintf("the instance ID file (%s) is invalid: %s", filePath, savedIDErr.Error())
+			return nil, errors.New(str)
+		}
+
+		if id != nil && !uuid.Equal(*id, savedID) {
+			str := fmt.Sprintf("the given instance ID (%s) does not match the instance ID (%s) of the chain in the database directory (%s)", id.String(), savedID.String(), dbPath)
+			return nil, errors.New(str)
+		}
+
+		return &savedID, nil
+	}
+
+	if id == nil {
+		newID := uuid.NewV4()
+		id = &newID
+	}
+
+	mkdirErr := os.MkdirAll(dbPath, 0755)
+	if mkdirErr != nil {
+		return nil, mkdirErr
+	}
+
+	writeErr := ioutil.WriteFile(filePath, []byte(id.String()), 0644)
+	if writeErr != nil {
+		return nil, writeErr
+	}
+
+	return id, nil
+}
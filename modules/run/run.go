@@ -0,0 +1,162 @@
+package run
+
+import (
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+	tcrypto "github.com/tendermint/tendermint/crypto"
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/blockchains/core/meta"
+	tendermint "github.com/xmnservices/xmnsuite/blockchains/tendermint"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	datastore "github.com/xmnservices/xmnsuite/datastore"
+	chain_module "github.com/xmnservices/xmnsuite/modules/chain"
+	core_module "github.com/xmnservices/xmnsuite/modules/core"
+	crypto_module "github.com/xmnservices/xmnsuite/modules/crypto"
+	datastore_module "github.com/xmnservices/xmnsuite/modules/datastore"
+	entity_module "github.com/xmnservices/xmnsuite/modules/entity"
+	events_module "github.com/xmnservices/xmnsuite/modules/events"
+	json_module "github.com/xmnservices/xmnsuite/modules/json"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	sdk_module "github.com/xmnservices/xmnsuite/modules/sdk"
+	uuid_module "github.com/xmnservices/xmnsuite/modules/uuid"
+	lua "github.com/yuin/gopher-lua"
+)
+
+func execute(
+	context *lua.LState,
+	scriptPath string,
+	dbPath string,
+	port int,
+	id *uuid.UUID,
+	rootPubKeys []crypto.PublicKey,
+	nodePK tcrypto.PrivKey,
+	client applications.Client,
+) (applications.Node, error) {
+	module, moduleErr := load(context, scriptPath, dbPath, port, id, rootPubKeys, nodePK, client)
+	if moduleErr != nil {
+		return nil, moduleErr
+	}
+
+	// spawn:
+	return module.Spawn()
+}
+
+func load(
+	context *lua.LState,
+	scriptPath string,
+	dbPath string,
+	port int,
+	id *uuid.UUID,
+	rootPubKeys []crypto.PublicKey,
+	nodePK tcrypto.PrivKey,
+	client applications.Client,
+) (chain_module.Chain, error) {
+	// the sdk module talks to the chain that is spawned, by default:
+	if client == nil {
+		client = tendermint.SDKFunc.CreateClient(tendermint.CreateClientParams{
+			IPAsString: fmt.Sprintf("tcp://127.0.0.1:%d", port),
+		})
+	}
+
+	// preload JSON:
+	json_module.SDKFunc.Create(json_module.CreateParams{
+		Context: context,
+	})
+
+	// preload datastore:
+	dsMod := datastore_module.SDKFunc.Create(datastore_module.CreateParams{
+		Context:   context,
+		Datastore: datastore.SDKFunc.Create(),
+	})
+
+	// preload entity:
+	entity_module.SDKFunc.Create(entity_module.CreateParams{
+		Context:   context,
+		Datastore: dsMod,
+	})
+
+	// preload crypto:
+	crypto_module.SDKFunc.Create(crypto_module.CreateParams{
+		Context: context,
+	})
+
+	// preload uuid:
+	uuid_module.SDKFunc.Create(uuid_module.CreateParams{
+		Context: context,
+	})
+
+	// preload sdk:
+	sdk_module.SDKFunc.Create(sdk_module.CreateParams{
+		Context: context,
+		Client:  client,
+	})
+
+	// preload events:
+	evts := events_module.SDKFunc.Create(events_module.CreateParams{
+		Context: context,
+	})
+
+	// create the chain module:
+	module := chain_module.SDKFunc.Create(chain_module.CreateParams{
+		Context:     context,
+		DBPath:      dbPath,
+		Port:        port,
+		ID:          id,
+		RootPubKeys: rootPubKeys,
+		NodePK:      nodePK,
+		Datastore:   dsMod,
+		Events:      evts,
+	})
+
+	// execute the script, with its concatenations limited:
+	doFileErr := sandbox.SDKFunc.DoFile(sandbox.DoFileParams{
+		Context: context,
+		Path:    scriptPath,
+	})
+	if doFileErr != nil {
+		return nil, doFileErr
+	}
+
+	return module, nil
+}
+
+func script(
+	context *lua.LState,
+	scriptPath string,
+	signer crypto.Signer,
+	client applications.Client,
+	met meta.Meta,
+) error {
+	// preload JSON:
+	json_module.SDKFunc.Create(json_module.CreateParams{
+		Context: context,
+	})
+
+	// preload crypto:
+	crypto_module.SDKFunc.Create(crypto_module.CreateParams{
+		Context: context,
+	})
+
+	// preload uuid:
+	uuid_module.SDKFunc.Create(uuid_module.CreateParams{
+		Context: context,
+	})
+
+	// preload sdk:
+	sdk_module.SDKFunc.Create(sdk_module.CreateParams{
+		Context: context,
+		Client:  client,
+	})
+
+	// preload core:
+	core_module.SDKFunc.Create(core_module.CreateParams{
+		Context: context,
+		Signer:  signer,
+		Client:  client,
+		Meta:    met,
+	})
+
+	// execute the script:
+	return context.DoFile(scriptPath)
+}
@@ -0,0 +1,94 @@
+package run
+
+import (
+	uuid "github.com/satori/go.uuid"
+	tcrypto "github.com/tendermint/tendermint/crypto"
+	ed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	"github.com/xmnservices/xmnsuite/blockchains/core/meta"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	chain_module "github.com/xmnservices/xmnsuite/modules/chain"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const (
+	// InstanceIDFileName represents the name of the file, in the database directory, that contains the instance ID of the chain
+	InstanceIDFileName = "instance_id"
+)
+
+// ExecuteParams represents the Execute params
+type ExecuteParams struct {
+	Context     *lua.LState
+	ScriptPath  string
+	DBPath      string
+	Port        int
+	ID          *uuid.UUID
+	RootPubKeys []crypto.PublicKey
+	NodePK      tcrypto.PrivKey
+	Client      applications.Client
+}
+
+// LoadParams represents the Load params
+type LoadParams struct {
+	Context     *lua.LState
+	ScriptPath  string
+	DBPath      string
+	Port        int
+	ID          *uuid.UUID
+	RootPubKeys []crypto.PublicKey
+	NodePK      tcrypto.PrivKey
+	Client      applications.Client
+}
+
+// ScriptParams represents the Script params.  The Signer signs the queries and the requests the script sends to the Client
+type ScriptParams struct {
+	Context    *lua.LState
+	ScriptPath string
+	Signer     crypto.Signer
+	Client     applications.Client
+	Meta       meta.Meta
+}
+
+// SDKFunc represents the run SDK func
+var SDKFunc = struct {
+	Execute func(params ExecuteParams) applications.Node
+	Load    func(params LoadParams) chain_module.Chain
+	Script  func(params ScriptParams)
+}{
+	Execute: func(params ExecuteParams) applications.Node {
+		id, idErr := retrieveInstanceID(params.DBPath, params.ID)
+		if idErr != nil {
+			panic(idErr)
+		}
+
+		out, outErr := execute(params.Context, params.ScriptPath, params.DBPath, params.Port, id, params.RootPubKeys, params.NodePK, params.Client)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	Load: func(params LoadParams) chain_module.Chain {
+		if params.ID == nil {
+			id := uuid.NewV4()
+			params.ID = &id
+		}
+
+		if params.NodePK == nil {
+			params.NodePK = ed25519.GenPrivKey()
+		}
+
+		out, outErr := load(params.Context, params.ScriptPath, params.DBPath, params.Port, params.ID, params.RootPubKeys, params.NodePK, params.Client)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	Script: func(params ScriptParams) {
+		scriptErr := script(params.Context, params.ScriptPath, params.Signer, params.Client, params.Meta)
+		if scriptErr != nil {
+			panic(scriptErr)
+		}
+	},
+}
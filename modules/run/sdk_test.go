@@ -0,0 +1,66 @@
+package run
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	ed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+)
+
+func TestExecute_Success(t *testing.T) {
+	// variables:
+	port := rand.Int()%9000 + 1000
+	dbPath := "./test_files"
+	scriptPath := "lua/chain.lua"
+	defer func() {
+		os.RemoveAll(dbPath)
+	}()
+
+	//create lua state:
+	context := sandbox.SDKFunc.Create(sandbox.CreateParams{
+		CallStackSize: 120,
+		RegistrySize:  120 * 20,
+	})
+	defer context.Close()
+
+	// execute:
+	node := SDKFunc.Execute(ExecuteParams{
+		Context:     context,
+		ScriptPath:  scriptPath,
+		DBPath:      dbPath,
+		Port:        port,
+		RootPubKeys: []crypto.PublicKey{},
+		NodePK:      ed25519.GenPrivKey(),
+	})
+	defer node.Stop()
+
+	// the instance ID is kept in the database directory:
+	id, idErr := retrieveInstanceID(dbPath, nil)
+	if idErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", idErr.Error())
+		return
+	}
+
+	sameID, sameIDErr := retrieveInstanceID(dbPath, nil)
+	if sameIDErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", sameIDErr.Error())
+		return
+	}
+
+	if !uuid.Equal(*id, *sameID) {
+		t.Errorf("the instance ID was expected to be the same between runs")
+		return
+	}
+
+	// another instance ID cannot be used on the same database directory:
+	otherID := uuid.NewV4()
+	_, otherIDErr := retrieveInstanceID(dbPath, &otherID)
+	if otherIDErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -0,0 +1,396 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+const (
+	// the amount of bytes a formatted number can use, without its width and precision:
+	maxFormattedNumberLength = 400
+
+	// the cost of a pattern saturates, it already exceeds any budget:
+	maxPatternCost = math.MaxInt32
+)
+
+// the functions of the opened libraries that are replaced by deterministic, or size limited, functions:
+var limitedFuncs = []struct {
+	lib  string
+	name string
+	fn   func(original lua.LValue) lua.LGFunction
+}{
+	{"", "tostring", limitToString},
+	{"", "loadstring", limitLoadString},
+	{lua.StringLibName, "rep", limitRep},
+	{lua.StringLibName, "format", limitFormat},
+	{lua.StringLibName, "find", limitFind},
+	{lua.StringLibName, "match", limitMatch},
+	{lua.StringLibName, "gmatch", limitMatch},
+	{lua.StringLibName, "gsub", limitGsub},
+	{lua.TabLibName, "concat", limitConcat},
+}
+
+func limitFuncs(context *lua.LState) error {
+	for _, oneLimited := range limitedFuncs {
+		var original lua.LValue
+		if oneLimited.lib == "" {
+			original = context.GetGlobal(oneLimited.name)
+		} else {
+			lib, ok := context.GetGlobal(oneLimited.lib).(*lua.LTable)
+			if !ok {
+				str := fmt.Sprintf("the %s library could not be opened in the sandbox", oneLimited.lib)
+				return errors.New(str)
+			}
+
+			original = lib.RawGetString(oneLimited.name)
+		}
+
+		if _, ok := original.(*lua.LFunction); !ok {
+			str := fmt.Sprintf("the function (%s) could not be found in the sandbox", oneLimited.name)
+			return errors.New(str)
+		}
+
+		fn := context.NewFunction(oneLimited.fn(original))
+		if oneLimited.lib == "" {
+			context.SetGlobal(oneLimited.name, fn)
+			continue
+		}
+
+		context.GetGlobal(oneLimited.lib).(*lua.LTable).RawSetString(oneLimited.name, fn)
+	}
+
+	return nil
+}
+
+// delegate calls the original function with the arguments of the current call, and returns its values:
+func delegate(l *lua.LState, original lua.LValue) int {
+	top := l.GetTop()
+	l.Insert(original, 1)
+	l.Call(top, lua.MultRet)
+	return l.GetTop()
+}
+
+func raiseIfTooLong(l *lua.LState, length int) {
+	if length > MaxStringLength {
+		l.RaiseError("the string would be longer than the maximum of %d bytes", MaxStringLength)
+	}
+}
+
+// toDeterministicString converts the value to a string that does not contain its address in memory:
+func toDeterministicString(l *lua.LState, value lua.LValue) lua.LString {
+	if l.GetMetaField(value, "__tostring") != lua.LNil {
+		return lua.LString(l.ToStringMeta(value).String())
+	}
+
+	return lua.LString(value.Type().String())
+}
+
+func isReference(value lua.LValue) bool {
+	switch value.Type() {
+	case lua.LTTable, lua.LTFunction, lua.LTUserData, lua.LTThread, lua.LTChannel:
+		return true
+	}
+
+	return false
+}
+
+func limitToString(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		value := l.CheckAny(1)
+		if isReference(value) {
+			l.Push(toDeterministicString(l, value))
+			return 1
+		}
+
+		return delegate(l, original)
+	}
+}
+
+func limitRep(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		str := l.CheckString(1)
+		amount := l.CheckInt(2)
+		if amount > 0 && len(str) > 0 && len(str) > MaxStringLength/amount {
+			raiseIfTooLong(l, MaxStringLength+1)
+		}
+
+		return delegate(l, original)
+	}
+}
+
+func limitFormat(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		format := l.CheckString(1)
+
+		// the references are formatted without their address:
+		length := len(format)
+		for i := 2; i <= l.GetTop(); i++ {
+			value := l.Get(i)
+			if isReference(value) {
+				value = toDeterministicString(l, value)
+				l.Replace(i, value)
+			}
+
+			if str, ok := value.(lua.LString); ok {
+				length += len(str)
+			}
+		}
+
+		// add the widths and precisions of the verbs:
+		for index := strings.Index(format, "%"); index >= 0 && index < len(format)-1; {
+			verb := format[index+1:]
+			if strings.HasPrefix(verb, "%") {
+				index = nextVerb(format, index+2)
+				continue
+			}
+
+			verb = strings.TrimLeft(verb, "-+# 0")
+			width, rest := readDigits(verb)
+			precision := 0
+			if strings.HasPrefix(rest, ".") {
+				precision, _ = readDigits(rest[1:])
+			}
+
+			length += width + precision + maxFormattedNumberLength
+			raiseIfTooLong(l, length)
+			index = nextVerb(format, index+1)
+		}
+
+		raiseIfTooLong(l, length)
+		return delegate(l, original)
+	}
+}
+
+func nextVerb(format string, from int) int {
+	if from >= len(format) {
+		return -1
+	}
+
+	next := strings.Index(format[from:], "%")
+	if next < 0 {
+		return -1
+	}
+
+	return from + next
+}
+
+func readDigits(str string) (int, string) {
+	value := 0
+	for index, oneChar := range str {
+		if oneChar < '0' || oneChar > '9' {
+			return value, str[index:]
+		}
+
+		// the width cannot overflow, it is already too long:
+		if value > MaxStringLength {
+			continue
+		}
+
+		value = value*10 + int(oneChar-'0')
+	}
+
+	return value, ""
+}
+
+// patternCost returns the amount of instructions charged for matching the pattern on the string.  A matcher that
+// backtracks tries, for each position of the string, each item of the pattern, and retries the following items for
+// each length matched by a quantifier, so each quantifier that is not the last item multiplies the cost by the length
+// of the string:
+func patternCost(str string, pattern string, plain bool) int {
+	if plain || !strings.ContainsAny(pattern, "^$*+?.([%-") {
+		return len(str) + len(pattern)
+	}
+
+	cost := len(str) + 1
+	multiply := func(factor int) {
+		if factor > maxPatternCost/cost {
+			cost = maxPatternCost
+			return
+		}
+
+		cost *= factor
+	}
+
+	multiply(len(pattern) + 1)
+	for index := strings.TrimPrefix(pattern, "^"); len(index) > 0; {
+		item, quantifier, rest := nextPatternItem(index)
+		isLast := strings.Trim(rest, ")$") == ""
+		switch {
+		case strings.HasPrefix(item, "%b"), len(item) == 2 && item[0] == '%' && item[1] >= '1' && item[1] <= '9':
+			// the balanced matches and the back references compare up to the length of the string:
+			multiply(len(str) + 1)
+		case quantifier == '?':
+			multiply(2)
+		case quantifier != 0 && !isLast:
+			multiply(len(str) + 1)
+		}
+
+		index = rest
+	}
+
+	return cost
+}
+
+// nextPatternItem returns the next item of the pattern, its quantifier, and the rest of the pattern:
+func nextPatternItem(pattern string) (string, byte, string) {
+	end := 1
+	switch pattern[0] {
+	case '(', ')':
+		return pattern[:1], 0, pattern[1:]
+	case '%':
+		switch {
+		case len(pattern) < 2:
+		case pattern[1] == 'b':
+			end = 4
+		case pattern[1] == 'f':
+			end = 2 + setLength(pattern[2:])
+		default:
+			end = 2
+		}
+	case '[':
+		end = setLength(pattern)
+	}
+
+	if end > len(pattern) {
+		end = len(pattern)
+	}
+
+	if end < len(pattern) && strings.IndexByte("*+-?", pattern[end]) >= 0 {
+		return pattern[:end], pattern[end], pattern[end+1:]
+	}
+
+	return pattern[:end], 0, pattern[end:]
+}
+
+// setLength returns the length of the set that begins the pattern:
+func setLength(pattern string) int {
+	if !strings.HasPrefix(pattern, "[") {
+		return 0
+	}
+
+	index := 1
+	if index < len(pattern) && pattern[index] == '^' {
+		index++
+	}
+
+	// a closing bracket at the beginning of the set is part of it:
+	if index < len(pattern) && pattern[index] == ']' {
+		index++
+	}
+
+	for index < len(pattern) && pattern[index] != ']' {
+		if pattern[index] == '%' {
+			index++
+		}
+
+		index++
+	}
+
+	return index + 1
+}
+
+func limitFind(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		str := l.CheckString(1)
+		pattern := l.CheckString(2)
+		charge(l, patternCost(str, pattern, lua.LVAsBool(l.Get(4))))
+		return delegate(l, original)
+	}
+}
+
+// limitMatch limits the match and gmatch funcs, the iterator of gmatch resumes each match where the previous one
+// ended, so all its matches cost at most as much as matching the whole string:
+func limitMatch(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		str := l.CheckString(1)
+		pattern := l.CheckString(2)
+		charge(l, patternCost(str, pattern, false))
+		return delegate(l, original)
+	}
+}
+
+func limitGsub(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		str := l.CheckString(1)
+		pattern := l.CheckString(2)
+		repl := l.CheckAny(3)
+		charge(l, patternCost(str, pattern, false))
+
+		// the amount of replacements:
+		amount := len(str) + 1
+		if limit := l.OptInt(4, -1); limit >= 0 && limit < amount {
+			amount = limit
+		}
+
+		switch casted := repl.(type) {
+		case lua.LString:
+			// each replacement contains, at most, its captures in full:
+			length := len(casted) + strings.Count(string(casted), "%")*len(str)
+			if length > 0 && amount > 0 && length > MaxStringLength/amount {
+				raiseIfTooLong(l, MaxStringLength+1)
+			}
+
+			raiseIfTooLong(l, len(str)+amount*length)
+		case *lua.LTable, *lua.LFunction:
+			// the replacements are measured as they are returned:
+			total := len(str)
+			l.Replace(3, l.NewFunction(func(sub *lua.LState) int {
+				var value lua.LValue
+				if tb, ok := casted.(*lua.LTable); ok {
+					value = sub.GetTable(tb, sub.Get(1))
+				} else {
+					top := sub.GetTop()
+					sub.Insert(casted, 1)
+					sub.Call(top, 1)
+					value = sub.Get(-1)
+				}
+
+				if replaced, ok := value.(lua.LString); ok {
+					total += len(replaced)
+				} else if number, ok := value.(lua.LNumber); ok {
+					total += len(number.String())
+				}
+
+				raiseIfTooLong(sub, total)
+				sub.Push(value)
+				return 1
+			}))
+		}
+
+		return delegate(l, original)
+	}
+}
+
+func limitConcat(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		tb := l.CheckTable(1)
+		sep := l.OptString(2, "")
+		from := l.OptInt(3, 1)
+		to := l.OptInt(4, tb.Len())
+
+		// the original func raises the error of an invalid element:
+		length := 0
+		for index := from; index <= to; index++ {
+			value := tb.RawGetInt(index)
+			if str, ok := value.(lua.LString); ok {
+				length += len(str)
+			} else if number, ok := value.(lua.LNumber); ok {
+				length += len(number.String())
+			} else {
+				break
+			}
+
+			if index < to {
+				length += len(sep)
+			}
+
+			raiseIfTooLong(l, length)
+		}
+
+		return delegate(l, original)
+	}
+}
@@ -0,0 +1,244 @@
+package sandbox
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/ast"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// the name of the global func the concatenations are compiled to:
+const concatFuncName = "__sandbox_concat"
+
+// the virtual machine concatenates the strings without calling any func, so the loaded code is compiled with its
+// concatenations replaced by calls to a func that verifies the length of the string it creates:
+func load(context *lua.LState, reader io.Reader, name string) (*lua.LFunction, error) {
+	chunk, chunkErr := parse.Parse(reader, name)
+	if chunkErr != nil {
+		return nil, chunkErr
+	}
+
+	limitStmts(chunk)
+	proto, protoErr := lua.Compile(chunk, name)
+	if protoErr != nil {
+		return nil, protoErr
+	}
+
+	context.SetGlobal(concatFuncName, context.NewFunction(concat))
+	return context.NewFunctionFromProto(proto), nil
+}
+
+func loadString(context *lua.LState, code string) (*lua.LFunction, error) {
+	return load(context, strings.NewReader(code), "<string>")
+}
+
+func doString(context *lua.LState, code string) error {
+	fn, fnErr := loadString(context, code)
+	if fnErr != nil {
+		return fnErr
+	}
+
+	context.Push(fn)
+	return context.PCall(0, lua.MultRet, nil)
+}
+
+func doFile(context *lua.LState, path string) error {
+	file, fileErr := os.Open(path)
+	if fileErr != nil {
+		return fileErr
+	}
+	defer file.Close()
+
+	fn, fnErr := load(context, file, path)
+	if fnErr != nil {
+		return fnErr
+	}
+
+	context.Push(fn)
+	return context.PCall(0, lua.MultRet, nil)
+}
+
+// limitLoadString replaces the loadstring func, so that the code it compiles is limited too:
+func limitLoadString(original lua.LValue) lua.LGFunction {
+	return func(l *lua.LState) int {
+		code := l.CheckString(1)
+		name := l.OptString(2, "<string>")
+		fn, fnErr := load(l, strings.NewReader(code), name)
+		if fnErr != nil {
+			l.Push(lua.LNil)
+			l.Push(lua.LString(fnErr.Error()))
+			return 2
+		}
+
+		l.Push(fn)
+		return 1
+	}
+}
+
+// concat concatenates the amount of operands that follows its first argument from the right, like the virtual machine
+// does, and raises an error before creating a string that is too long:
+func concat(l *lua.LState) int {
+	top := l.CheckInt(1) + 1
+	rhs := l.Get(top)
+	for index := top - 1; index >= 2; {
+		lhs := l.Get(index)
+		if !lua.LVCanConvToString(lhs) || !lua.LVCanConvToString(rhs) {
+			op := l.GetMetaField(lhs, "__concat")
+			if op == lua.LNil {
+				op = l.GetMetaField(rhs, "__concat")
+			}
+
+			if op.Type() != lua.LTFunction {
+				l.RaiseError("cannot perform concat operation between %v and %v", lhs.Type().String(), rhs.Type().String())
+				return 0
+			}
+
+			l.Push(op)
+			l.Push(lhs)
+			l.Push(rhs)
+			l.Call(2, 1)
+			rhs = l.Get(-1)
+			l.Pop(1)
+			index--
+			continue
+		}
+
+		// the following strings, and numbers, are joined at once:
+		length := len(lua.LVAsString(rhs))
+		from := index
+		for ; from >= 2 && lua.LVCanConvToString(l.Get(from)); from-- {
+			length += len(lua.LVAsString(l.Get(from)))
+			raiseIfTooLong(l, length)
+		}
+
+		elements := []string{}
+		for i := from + 1; i <= index; i++ {
+			elements = append(elements, lua.LVAsString(l.Get(i)))
+		}
+
+		elements = append(elements, lua.LVAsString(rhs))
+		rhs = lua.LString(strings.Join(elements, ""))
+		index = from
+	}
+
+	l.Push(rhs)
+	return 1
+}
+
+func limitStmts(stmts []ast.Stmt) {
+	for _, oneStmt := range stmts {
+		limitStmt(oneStmt)
+	}
+}
+
+func limitExprs(exprs []ast.Expr) {
+	for index, oneExpr := range exprs {
+		exprs[index] = limitExpr(oneExpr)
+	}
+}
+
+func limitStmt(stmt ast.Stmt) {
+	switch casted := stmt.(type) {
+	case *ast.AssignStmt:
+		limitExprs(casted.Lhs)
+		limitExprs(casted.Rhs)
+	case *ast.LocalAssignStmt:
+		limitExprs(casted.Exprs)
+	case *ast.FuncCallStmt:
+		casted.Expr = limitExpr(casted.Expr)
+	case *ast.DoBlockStmt:
+		limitStmts(casted.Stmts)
+	case *ast.WhileStmt:
+		casted.Condition = limitExpr(casted.Condition)
+		limitStmts(casted.Stmts)
+	case *ast.RepeatStmt:
+		casted.Condition = limitExpr(casted.Condition)
+		limitStmts(casted.Stmts)
+	case *ast.IfStmt:
+		casted.Condition = limitExpr(casted.Condition)
+		limitStmts(casted.Then)
+		limitStmts(casted.Else)
+	case *ast.NumberForStmt:
+		casted.Init = limitExpr(casted.Init)
+		casted.Limit = limitExpr(casted.Limit)
+		casted.Step = limitExpr(casted.Step)
+		limitStmts(casted.Stmts)
+	case *ast.GenericForStmt:
+		limitExprs(casted.Exprs)
+		limitStmts(casted.Stmts)
+	case *ast.FuncDefStmt:
+		casted.Name.Func = limitExpr(casted.Name.Func)
+		casted.Name.Receiver = limitExpr(casted.Name.Receiver)
+		limitStmts(casted.Func.Stmts)
+	case *ast.ReturnStmt:
+		limitExprs(casted.Exprs)
+	}
+}
+
+func limitExpr(expr ast.Expr) ast.Expr {
+	switch casted := expr.(type) {
+	case *ast.StringConcatOpExpr:
+		// the concatenations are right associative, so the chain is concatenated by a single call:
+		args := []ast.Expr{limitExpr(casted.Lhs)}
+		rhs := casted.Rhs
+		for {
+			next, ok := rhs.(*ast.StringConcatOpExpr)
+			if !ok {
+				break
+			}
+
+			args = append(args, limitExpr(next.Lhs))
+			rhs = next.Rhs
+		}
+
+		// the last operand is expanded when it returns many values, so the amount of operands is passed first:
+		args = append(args, limitExpr(rhs))
+		amount := &ast.NumberExpr{Value: strconv.Itoa(len(args))}
+		amount.SetLine(casted.Line())
+		amount.SetLastLine(casted.LastLine())
+
+		fn := &ast.IdentExpr{Value: concatFuncName}
+		fn.SetLine(casted.Line())
+		fn.SetLastLine(casted.LastLine())
+
+		out := &ast.FuncCallExpr{Func: fn, Args: append([]ast.Expr{amount}, args...), AdjustRet: true}
+		out.SetLine(casted.Line())
+		out.SetLastLine(casted.LastLine())
+		return out
+	case *ast.AttrGetExpr:
+		casted.Object = limitExpr(casted.Object)
+		casted.Key = limitExpr(casted.Key)
+	case *ast.TableExpr:
+		for _, oneField := range casted.Fields {
+			oneField.Key = limitExpr(oneField.Key)
+			oneField.Value = limitExpr(oneField.Value)
+		}
+	case *ast.FuncCallExpr:
+		casted.Func = limitExpr(casted.Func)
+		casted.Receiver = limitExpr(casted.Receiver)
+		limitExprs(casted.Args)
+	case *ast.LogicalOpExpr:
+		casted.Lhs = limitExpr(casted.Lhs)
+		casted.Rhs = limitExpr(casted.Rhs)
+	case *ast.RelationalOpExpr:
+		casted.Lhs = limitExpr(casted.Lhs)
+		casted.Rhs = limitExpr(casted.Rhs)
+	case *ast.ArithmeticOpExpr:
+		casted.Lhs = limitExpr(casted.Lhs)
+		casted.Rhs = limitExpr(casted.Rhs)
+	case *ast.UnaryMinusOpExpr:
+		casted.Expr = limitExpr(casted.Expr)
+	case *ast.UnaryNotOpExpr:
+		casted.Expr = limitExpr(casted.Expr)
+	case *ast.UnaryLenOpExpr:
+		casted.Expr = limitExpr(casted.Expr)
+	case *ast.FunctionExpr:
+		limitStmts(casted.Stmts)
+	}
+
+	return expr
+}
@@ -0,0 +1,121 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// the lua virtual machine checks the Done channel of its context before executing each instruction, so a context
+// that counts these checks meters the executed instructions deterministically:
+type meter struct {
+	context   *lua.LState
+	budget    int
+	used      int
+	exhausted chan struct{}
+}
+
+func createMeter(context *lua.LState, budget int) (Meter, error) {
+	// a call without a budget would never be aborted:
+	if budget <= 0 {
+		str := fmt.Sprintf("the budget (%d) must be a positive amount of instructions", budget)
+		return nil, errors.New(str)
+	}
+
+	exhausted := make(chan struct{})
+	close(exhausted)
+
+	out := meter{
+		context:   context,
+		budget:    budget,
+		used:      0,
+		exhausted: exhausted,
+	}
+
+	return &out, nil
+}
+
+// the funcs that are not deterministic use it to refuse to run in the calls of the handlers, which must write the same
+// state on every node:
+func isMetered(context *lua.LState) bool {
+	_, ok := context.Context().(*meter)
+	return ok
+}
+
+// charge adds the work of a go func to the instructions of the metered call, and raises an error once the budget is
+// exhausted, since the virtual machine only checks the budget between its instructions:
+func charge(context *lua.LState, amount int) {
+	app, ok := context.Context().(*meter)
+	if !ok {
+		return
+	}
+
+	if amount > app.budget-app.used {
+		app.used = app.budget + 1
+		context.RaiseError(app.Err().Error())
+		return
+	}
+
+	app.used += amount
+}
+
+// Call calls the lua function, and aborts it once it executed more instructions than the budget
+func (app *meter) Call(p lua.P, args ...lua.LValue) error {
+	previous := app.context.Context()
+	app.context.SetContext(app)
+	defer func() {
+		if previous != nil {
+			app.context.SetContext(previous)
+			return
+		}
+
+		app.context.RemoveContext()
+	}()
+
+	return app.context.CallByParam(p, args...)
+}
+
+// Used returns the amount of instructions executed by the call
+func (app *meter) Used() int {
+	if app.used > app.budget {
+		return app.budget
+	}
+
+	return app.used
+}
+
+// IsExhausted returns true if the call has been aborted because it exhausted its budget, false otherwise
+func (app *meter) IsExhausted() bool {
+	return app.used > app.budget
+}
+
+// Deadline returns no deadline, the meter does not depend on the clock
+func (app *meter) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done counts an executed instruction, and returns a closed channel once the budget is exhausted
+func (app *meter) Done() <-chan struct{} {
+	app.used++
+	if app.IsExhausted() {
+		return app.exhausted
+	}
+
+	return nil
+}
+
+// Err returns an error once the budget is exhausted
+func (app *meter) Err() error {
+	if app.IsExhausted() {
+		return fmt.Errorf("the lua call exhausted its budget of %d instructions", app.budget)
+	}
+
+	return nil
+}
+
+// Value returns no value
+func (app *meter) Value(key interface{}) interface{} {
+	return nil
+}
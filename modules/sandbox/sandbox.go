@@ -0,0 +1,90 @@
+package sandbox
+
+import (
+	"errors"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// the libraries that do not touch the clock, the filesystem or the randomness.  The coroutine library is not opened
+// since the threads it creates do not share the context of their parent, and would escape its meter:
+var libs = []struct {
+	name string
+	fn   lua.LGFunction
+}{
+	{lua.LoadLibName, lua.OpenPackage},
+	{lua.BaseLibName, lua.OpenBase},
+	{lua.TabLibName, lua.OpenTable},
+	{lua.StringLibName, lua.OpenString},
+	{lua.MathLibName, lua.OpenMath},
+}
+
+// the functions of the opened libraries that read files, that are not deterministic, or that compile code without
+// limiting its concatenations:
+var removedGlobals = []string{
+	"load",
+	"dofile",
+	"loadfile",
+	"collectgarbage",
+	"_printregs",
+}
+
+var removedMathFuncs = []string{
+	"random",
+	"randomseed",
+}
+
+func createSandbox(callStackSize int, registrySize int) (*lua.LState, error) {
+	context := lua.NewState(lua.Options{
+		CallStackSize: callStackSize,
+		RegistrySize:  registrySize,
+		SkipOpenLibs:  true,
+	})
+
+	for _, oneLib := range libs {
+		context.Push(context.NewFunction(oneLib.fn))
+		context.Push(lua.LString(oneLib.name))
+		context.Call(1, 0)
+	}
+
+	for _, oneName := range removedGlobals {
+		context.SetGlobal(oneName, lua.LNil)
+	}
+
+	limitErr := limitFuncs(context)
+	if limitErr != nil {
+		context.Close()
+		return nil, limitErr
+	}
+
+	math, ok := context.GetGlobal(lua.MathLibName).(*lua.LTable)
+	if !ok {
+		context.Close()
+		return nil, errors.New("the math library could not be opened in the sandbox")
+	}
+
+	for _, oneName := range removedMathFuncs {
+		math.RawSetString(oneName, lua.LNil)
+	}
+
+	// only the preloaded modules can be required, the loader that reads lua files is removed:
+	pkg, ok := context.GetGlobal(lua.LoadLibName).(*lua.LTable)
+	if !ok {
+		context.Close()
+		return nil, errors.New("the package library could not be opened in the sandbox")
+	}
+
+	loaders, ok := pkg.RawGetString("loaders").(*lua.LTable)
+	if !ok || loaders.Len() <= 0 {
+		context.Close()
+		return nil, errors.New("the package loaders could not be found in the sandbox")
+	}
+
+	for loaders.Len() > 1 {
+		loaders.Remove(loaders.Len())
+	}
+
+	pkg.RawSetString("path", lua.LString(""))
+	pkg.RawSetString("cpath", lua.LString(""))
+	return context, nil
+}
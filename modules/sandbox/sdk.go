@@ -0,0 +1,94 @@
+package sandbox
+
+import (
+	lua "github.com/yuin/gopher-lua"
+)
+
+const (
+	// DefaultBudget represents the default amount of lua instructions a metered call can execute
+	DefaultBudget = 1000000
+
+	// MaxStringLength represents the maximum length, in bytes, of the strings a sandboxed lua call can create
+	MaxStringLength = 1024 * 1024
+)
+
+// Meter represents a meter that limits the amount of lua instructions a call can execute
+type Meter interface {
+	Call(p lua.P, args ...lua.LValue) error
+	Used() int
+	IsExhausted() bool
+}
+
+// CreateParams represents the Create params
+type CreateParams struct {
+	CallStackSize int
+	RegistrySize  int
+}
+
+// CreateMeterParams represents the CreateMeter params
+type CreateMeterParams struct {
+	Context *lua.LState
+	Budget  int
+}
+
+// IsMeteredParams represents the IsMetered params
+type IsMeteredParams struct {
+	Context *lua.LState
+}
+
+// LoadStringParams represents the LoadString params
+type LoadStringParams struct {
+	Context *lua.LState
+	Code    string
+}
+
+// DoStringParams represents the DoString params
+type DoStringParams struct {
+	Context *lua.LState
+	Code    string
+}
+
+// DoFileParams represents the DoFile params
+type DoFileParams struct {
+	Context *lua.LState
+	Path    string
+}
+
+// SDKFunc represents the sandbox SDK func
+var SDKFunc = struct {
+	Create      func(params CreateParams) *lua.LState
+	CreateMeter func(params CreateMeterParams) Meter
+	IsMetered   func(params IsMeteredParams) bool
+	LoadString  func(params LoadStringParams) (*lua.LFunction, error)
+	DoString    func(params DoStringParams) error
+	DoFile      func(params DoFileParams) error
+}{
+	Create: func(params CreateParams) *lua.LState {
+		out, outErr := createSandbox(params.CallStackSize, params.RegistrySize)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateMeter: func(params CreateMeterParams) Meter {
+		out, outErr := createMeter(params.Context, params.Budget)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	IsMetered: func(params IsMeteredParams) bool {
+		return isMetered(params.Context)
+	},
+	LoadString: func(params LoadStringParams) (*lua.LFunction, error) {
+		return loadString(params.Context, params.Code)
+	},
+	DoString: func(params DoStringParams) error {
+		return doString(params.Context, params.Code)
+	},
+	DoFile: func(params DoFileParams) error {
+		return doFile(params.Context, params.Path)
+	},
+}
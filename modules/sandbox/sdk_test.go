@@ -0,0 +1,402 @@
+package sandbox
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestCreate_withoutNonDeterministicFuncs_Success(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	// the removed libraries and functions are not reachable:
+	doErr := context.DoString(`
+		removed = os == nil and io == nil and debug == nil and coroutine == nil and load == nil and dofile == nil and loadfile == nil and math.random == nil and math.randomseed == nil
+		available = string ~= nil and table ~= nil and math.floor ~= nil
+	`)
+
+	if doErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+		return
+	}
+
+	if context.GetGlobal("removed") != lua.LTrue || context.GetGlobal("available") != lua.LTrue {
+		t.Errorf("the sandbox was expected to only contain the deterministic libraries")
+		return
+	}
+
+	// the lua files cannot be required:
+	requireErr := context.DoString(`require("os")`)
+	if requireErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestMeter_exhaustsBudget_Success(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	doErr := context.DoString(`
+		function loop()
+			while true do end
+		end
+
+		function count(amount)
+			local total = 0
+			for i = 1, amount do
+				total = total + i
+			end
+
+			return total
+		end
+	`)
+
+	if doErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+		return
+	}
+
+	// the infinite loop is aborted:
+	loopMeter := SDKFunc.CreateMeter(CreateMeterParams{
+		Context: context,
+		Budget:  1000,
+	})
+
+	loopErr := loopMeter.Call(lua.P{
+		Fn:      context.GetGlobal("loop"),
+		NRet:    0,
+		Protect: true,
+	})
+
+	if loopErr == nil || !loopMeter.IsExhausted() {
+		t.Errorf("the infinite loop was expected to exhaust its budget")
+		return
+	}
+
+	// the state can still be used, and the same call always uses the same amount of instructions:
+	used := []int{}
+	for i := 0; i < 2; i++ {
+		meter := SDKFunc.CreateMeter(CreateMeterParams{
+			Context: context,
+			Budget:  DefaultBudget,
+		})
+
+		callErr := meter.Call(lua.P{
+			Fn:      context.GetGlobal("count"),
+			NRet:    1,
+			Protect: true,
+		}, lua.LNumber(100))
+
+		if callErr != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", callErr.Error())
+			return
+		}
+
+		ret := context.Get(-1)
+		context.Pop(1)
+		if ret != lua.LNumber(5050) {
+			t.Errorf("the returned value was expected to be %d, %s returned", 5050, ret.String())
+			return
+		}
+
+		used = append(used, meter.Used())
+	}
+
+	if used[0] <= 0 || used[0] != used[1] {
+		t.Errorf("the amount of instructions was expected to be the same for the same call: %v", used)
+		return
+	}
+}
+
+func TestMeter_withCoroutine_returnsError(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	doErr := context.DoString(`
+		function escape()
+			local co = coroutine.create(function()
+				while true do end
+			end)
+
+			coroutine.resume(co)
+		end
+	`)
+
+	if doErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+		return
+	}
+
+	// the loop cannot be executed in a thread that escapes the meter:
+	meter := SDKFunc.CreateMeter(CreateMeterParams{
+		Context: context,
+		Budget:  10000,
+	})
+
+	callErr := meter.Call(lua.P{
+		Fn:      context.GetGlobal("escape"),
+		NRet:    0,
+		Protect: true,
+	})
+
+	if callErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestMeter_withoutBudget_returnsError(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	//execute:
+	for _, oneBudget := range []int{0, -1} {
+		_, meterErr := createMeter(context, oneBudget)
+		if meterErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (budget: %d)", oneBudget)
+			return
+		}
+	}
+}
+
+func TestCreate_withReferences_isDeterministic(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	// the references are converted to strings without their address:
+	doErr := context.DoString(`
+		local named = setmetatable({}, {__tostring = function() return "named" end})
+		values = {
+			tostring({}),
+			tostring(print),
+			string.format("%s-%s", {}, named),
+			tostring(named),
+			tostring(12),
+		}
+	`)
+
+	if doErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+		return
+	}
+
+	expected := []string{"table", "function", "table-named", "named", "12"}
+	values := context.GetGlobal("values").(*lua.LTable)
+	for index, oneExpected := range expected {
+		value := values.RawGetInt(index + 1).String()
+		if value != oneExpected {
+			t.Errorf("the value at index %d was expected to be %s, %s returned", index, oneExpected, value)
+			return
+		}
+	}
+}
+
+func TestMeter_withTooLongString_returnsError(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	doErr := SDKFunc.DoString(DoStringParams{
+		Context: context,
+		Code: `
+		function rep()
+			return string.rep("x", 1e8)
+		end
+
+		function concat()
+			local str = string.rep("x", 1024 * 1024)
+			str = str .. str
+			return "done"
+		end
+
+		function chainConcat()
+			local str = string.rep("x", 512 * 1024)
+			return "a" .. str .. 1 .. str
+		end
+
+		function loadedConcat()
+			return loadstring("local str = string.rep('x', 1024 * 1024) return str .. str")()
+		end
+
+		function tableConcat()
+			local elements = {}
+			local element = string.rep("x", 1024)
+			for i = 1, 2000 do
+				elements[i] = element
+			end
+
+			return table.concat(elements)
+		end
+
+		function gsub()
+			return string.gsub(string.rep("x", 1024), "x", string.rep("y", 2048))
+		end
+
+		function format()
+			return string.format("%099999999d", 1)
+		end
+
+		function allowed()
+			local str = string.rep("x", 1024) .. string.format("%5d", 1)
+			local concatenated = setmetatable({}, {__concat = function(lhs, rhs) return "meta" end})
+			return string.gsub(str, "x", "yy") .. table.concat({"a", "b"}, ",") .. 1 .. (concatenated .. "x")
+		end
+	`,
+	})
+
+	if doErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+		return
+	}
+
+	callFn := func(name string) error {
+		meter := SDKFunc.CreateMeter(CreateMeterParams{
+			Context: context,
+			Budget:  DefaultBudget,
+		})
+
+		return meter.Call(lua.P{
+			Fn:      context.GetGlobal(name),
+			NRet:    1,
+			Protect: true,
+		})
+	}
+
+	for _, oneName := range []string{"rep", "concat", "chainConcat", "loadedConcat", "tableConcat", "gsub", "format"} {
+		callErr := callFn(oneName)
+		if callErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (func: %s)", oneName)
+			return
+		}
+	}
+
+	allowedErr := callFn("allowed")
+	if allowedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", allowedErr.Error())
+		return
+	}
+}
+
+func TestMeter_withBacktrackingPattern_exhaustsBudget(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	doErr := context.DoString(`
+		str = string.rep("a", 10000)
+		function find()
+			return string.find(str, "a*a*a*b")
+		end
+
+		function match()
+			return string.match(str, "(a-)%1b")
+		end
+
+		function gsub()
+			return string.gsub(str, "%b()a*b", "")
+		end
+
+		function plain()
+			return string.find(str, "a*a*a*b", 1, true)
+		end
+	`)
+
+	if doErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+		return
+	}
+
+	callFn := func(name string) (Meter, error) {
+		meter := SDKFunc.CreateMeter(CreateMeterParams{
+			Context: context,
+			Budget:  DefaultBudget,
+		})
+
+		return meter, meter.Call(lua.P{
+			Fn:      context.GetGlobal(name),
+			NRet:    1,
+			Protect: true,
+		})
+	}
+
+	for _, oneName := range []string{"find", "match", "gsub"} {
+		meter, callErr := callFn(oneName)
+		if callErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (func: %s)", oneName)
+			return
+		}
+
+		if !meter.IsExhausted() {
+			t.Errorf("the budget was expected to be exhausted (func: %s)", oneName)
+			return
+		}
+	}
+
+	meter, plainErr := callFn("plain")
+	if plainErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", plainErr.Error())
+		return
+	}
+
+	if meter.Used() < 10000 {
+		t.Errorf("the plain find was expected to be charged for the length of the string, %d instructions used", meter.Used())
+		return
+	}
+}
+
+func TestIsMetered_onlyInMeteredCalls_Success(t *testing.T) {
+	// create the sandbox:
+	context := SDKFunc.Create(CreateParams{})
+	defer context.Close()
+
+	// a go func that records whether it is called in a metered call:
+	context.SetGlobal("isMetered", context.NewFunction(func(l *lua.LState) int {
+		l.Push(lua.LBool(SDKFunc.IsMetered(IsMeteredParams{Context: l})))
+		return 1
+	}))
+
+	doErr := context.DoString(`unmetered = isMetered()`)
+	if doErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", doErr.Error())
+		return
+	}
+
+	if context.GetGlobal("unmetered") != lua.LFalse {
+		t.Errorf("the call was expected to NOT be metered")
+		return
+	}
+
+	meter := SDKFunc.CreateMeter(CreateMeterParams{
+		Context: context,
+		Budget:  DefaultBudget,
+	})
+
+	callErr := meter.Call(lua.P{
+		Fn:      context.GetGlobal("isMetered"),
+		NRet:    1,
+		Protect: true,
+	})
+
+	if callErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", callErr.Error())
+		return
+	}
+
+	if context.Get(-1) != lua.LTrue {
+		t.Errorf("the call was expected to be metered")
+		return
+	}
+
+	context.Pop(1)
+	if SDKFunc.IsMetered(IsMeteredParams{Context: context}) {
+		t.Errorf("the context was expected to NOT be metered once the call is over")
+		return
+	}
+}
@@ -17,7 +17,7 @@ function insert(pk, from, path, data)
     })
 
     -- sign the resource:
-    sig = pk:sign(res:hash())
+    sig = pk:signBytes(sdk.domains.save, res:hash())
 
     -- execte the transaction:
     resp = sdk.service().transact({
@@ -36,7 +36,7 @@ function delete(pk, from, path)
     })
 
     -- sign the resource pointer:
-    sig = pk:sign(ptr:hash())
+    sig = pk:signBytes(sdk.domains.delete, ptr:hash())
 
     -- execute the transaction:
     resp = sdk.service().transact({
@@ -55,7 +55,7 @@ function retrieve(pk, from, path)
     })
 
     -- sign the resource pointer:
-    sig = pk:sign(resPointer:hash())
+    sig = pk:signBytes(sdk.domains.query, resPointer:hash())
 
     -- execute the query:
     resp = sdk.service().query({
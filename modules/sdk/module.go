@@ -5,6 +5,7 @@ import (
 
 	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
 	crypto "github.com/xmnservices/xmnsuite/crypto"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
 	"github.com/xmnservices/xmnsuite/routers"
 	lua "github.com/yuin/gopher-lua"
 )
@@ -39,8 +40,15 @@ func (app *module) register() {
 			},
 		}
 
+		// the domains the router requests are signed with:
+		domains := context.NewTable()
+		context.SetField(domains, "save", lua.LString(routers.SaveTransactionDomain))
+		context.SetField(domains, "delete", lua.LString(routers.DeleteTransactionDomain))
+		context.SetField(domains, "query", lua.LString(routers.QueryDomain))
+
 		ntable := context.NewTable()
 		context.SetFuncs(ntable, methods)
+		context.SetField(ntable, "domains", domains)
 		context.Push(ntable)
 
 		app.registerResourcePointer(context)
@@ -190,6 +198,7 @@ func (app *module) registerResource(context *lua.LState) {
 func (app *module) registerService(context *lua.LState) int {
 	// transactFn executes a transaction on the service
 	transactFn := func(l *lua.LState) int {
+		raiseIfMetered(l, "transact")
 		amount := l.GetTop()
 		if amount != 1 {
 			l.ArgError(1, "the transact func expected 1 parameter")
@@ -243,6 +252,7 @@ func (app *module) registerService(context *lua.LState) int {
 
 	// queryFn executes a query on the service
 	queryFn := func(l *lua.LState) int {
+		raiseIfMetered(l, "query")
 		amount := l.GetTop()
 		if amount != 1 {
 			l.ArgError(1, "the transact func expected 1 parameter")
@@ -425,3 +435,10 @@ func (app *module) registerClientTrxResponse(context *lua.LState) int {
 	// return:
 	return 1
 }
+
+// the client talks to the network, so a handler of the chain that uses it would not write the same state on every node:
+func raiseIfMetered(l *lua.LState, name string) {
+	if sandbox.SDKFunc.IsMetered(sandbox.IsMeteredParams{Context: l}) {
+		l.RaiseError("the %s func of the service cannot be called by the handlers of the chain", name)
+	}
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"fmt"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func registerAssert(context *lua.LState) {
+	context.PreloadModule("assert", func(context *lua.LState) int {
+		methods := map[string]lua.LGFunction{
+			"equal": func(l *lua.LState) int {
+				expected, actual := l.CheckAny(1), l.CheckAny(2)
+				if !isEqual(l, expected, actual) {
+					fail(l, 3, fmt.Sprintf("expected: %s, actual: %s", expected.String(), actual.String()))
+				}
+
+				return 0
+			},
+			"notEqual": func(l *lua.LState) int {
+				expected, actual := l.CheckAny(1), l.CheckAny(2)
+				if isEqual(l, expected, actual) {
+					fail(l, 3, fmt.Sprintf("the values were not expected to be equal: %s", actual.String()))
+				}
+
+				return 0
+			},
+			"isTrue": func(l *lua.LState) int {
+				if !lua.LVAsBool(l.Get(1)) {
+					fail(l, 2, "the value was expected to be true")
+				}
+
+				return 0
+			},
+			"isFalse": func(l *lua.LState) int {
+				if lua.LVAsBool(l.Get(1)) {
+					fail(l, 2, "the value was expected to be false")
+				}
+
+				return 0
+			},
+			"isNil": func(l *lua.LState) int {
+				if l.Get(1) != lua.LNil {
+					fail(l, 2, fmt.Sprintf("the value was expected to be nil: %s", l.Get(1).String()))
+				}
+
+				return 0
+			},
+			"notNil": func(l *lua.LState) int {
+				if l.Get(1) == lua.LNil {
+					fail(l, 2, "the value was not expected to be nil")
+				}
+
+				return 0
+			},
+			"fails": func(l *lua.LState) int {
+				fn := l.CheckFunction(1)
+				callErr := l.CallByParam(lua.P{
+					Fn:      fn,
+					NRet:    0,
+					Protect: true,
+				})
+
+				if callErr == nil {
+					fail(l, 2, "the function was expected to fail")
+				}
+
+				return 0
+			},
+		}
+
+		ntable := context.NewTable()
+		context.SetFuncs(ntable, methods)
+
+		// the module can still be called like the assert function of lua:
+		meta := context.NewTable()
+		context.SetField(meta, "__call", context.NewFunction(func(l *lua.LState) int {
+			if !lua.LVAsBool(l.Get(2)) {
+				l.RaiseError("%s", l.OptString(3, "assertion failed!"))
+			}
+
+			return 0
+		}))
+
+		context.SetMetatable(ntable, meta)
+		context.Push(ntable)
+		return 1
+	})
+}
+
+// fail raises an error with the assertion message, prefixed by the optional message at the given index:
+func fail(l *lua.LState, msgIndex int, assertion string) {
+	if msg := l.OptString(msgIndex, ""); msg != "" {
+		l.RaiseError("%s: %s", msg, assertion)
+		return
+	}
+
+	l.RaiseError("%s", assertion)
+}
+
+// isEqual compares the tables by their content, and the other values like the == operator:
+func isEqual(l *lua.LState, first lua.LValue, second lua.LValue) bool {
+	firstTable, isFirstTable := first.(*lua.LTable)
+	secondTable, isSecondTable := second.(*lua.LTable)
+	if !isFirstTable || !isSecondTable {
+		return l.Equal(first, second)
+	}
+
+	isSame := true
+	amount := 0
+	firstTable.ForEach(func(key lua.LValue, value lua.LValue) {
+		amount++
+		if !isEqual(l, value, secondTable.RawGet(key)) {
+			isSame = false
+		}
+	})
+
+	secondTable.ForEach(func(key lua.LValue, value lua.LValue) {
+		amount--
+	})
+
+	return isSame && amount == 0
+}
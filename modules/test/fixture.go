@@ -0,0 +1,112 @@
+package test
+
+import (
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// the helpers of the fixture that sign and submit the resources of the tests, using the sdk and crypto modules:
+const fixtureScript = `
+local test, root = ...
+require("crypto")
+local sdk = require("sdk")
+
+function test.root()
+    return privkey.new(root)
+end
+
+function test.save(pk, path, data)
+    local res = resource.new({
+        pointer = rpointer.new({
+            from = pk:pubKey(),
+            path = path
+        }),
+        data = data
+    })
+
+    return sdk.service().transact({
+        resource = res,
+        sig = pk:signBytes(sdk.domains.save, res:hash())
+    })
+end
+
+function test.delete(pk, path)
+    local ptr = rpointer.new({
+        from = pk:pubKey(),
+        path = path
+    })
+
+    return sdk.service().transact({
+        rpointer = ptr,
+        sig = pk:signBytes(sdk.domains.delete, ptr:hash())
+    })
+end
+
+function test.query(pk, path)
+    local ptr = rpointer.new({
+        from = pk:pubKey(),
+        path = path
+    })
+
+    return sdk.service().query({
+        rpointer = ptr,
+        sig = pk:signBytes(sdk.domains.query, ptr:hash())
+    })
+end
+
+return test
+`
+
+type testCase struct {
+	name string
+	fn   *lua.LFunction
+}
+
+type fixture struct {
+	rootPK crypto.PrivateKey
+	cases  []*testCase
+}
+
+func createFixture(context *lua.LState, rootPK crypto.PrivateKey) *fixture {
+	out := fixture{
+		rootPK: rootPK,
+		cases:  []*testCase{},
+	}
+
+	out.register(context)
+	return &out
+}
+
+func (app *fixture) register(context *lua.LState) {
+	context.PreloadModule("test", func(context *lua.LState) int {
+		methods := map[string]lua.LGFunction{
+			"case": func(l *lua.LState) int {
+				app.cases = append(app.cases, &testCase{
+					name: l.CheckString(1),
+					fn:   l.CheckFunction(2),
+				})
+
+				return 0
+			},
+		}
+
+		ntable := context.NewTable()
+		context.SetFuncs(ntable, methods)
+
+		fn, fnErr := sandbox.SDKFunc.LoadString(sandbox.LoadStringParams{
+			Context: context,
+			Code:    fixtureScript,
+		})
+		if fnErr != nil {
+			context.RaiseError("the test fixture could not be loaded: %s", fnErr.Error())
+			return 0
+		}
+
+		context.Push(fn)
+		context.Push(ntable)
+		context.Push(lua.LString(app.rootPK.String()))
+		context.Call(2, 1)
+		return 1
+	})
+}
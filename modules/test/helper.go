@@ -0,0 +1,155 @@
+package test
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+func discover(dir string) ([]string, error) {
+	out := []string{}
+	walkErr := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && strings.HasSuffix(info.Name(), TestFileSuffix) {
+			out = append(out, path)
+		}
+
+		return nil
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	sort.Strings(out)
+	return out, nil
+}
+
+func format(name string, results []Result) (string, error) {
+	switch name {
+	case TAPFormat:
+		return toTAP(results), nil
+	case JUnitFormat:
+		return toJUnit(results)
+	}
+
+	str := fmt.Sprintf("the report format (%s) must either be %s or %s", name, TAPFormat, JUnitFormat)
+	return "", errors.New(str)
+}
+
+func toTAP(results []Result) string {
+	lines := []string{
+		"TAP version 13",
+		fmt.Sprintf("1..%d", len(results)),
+	}
+
+	for index, oneResult := range results {
+		status := "ok"
+		if !oneResult.IsSuccessful() {
+			status = "not ok"
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %d - %s: %s", status, index+1, oneResult.File(), oneResult.Name()))
+		if !oneResult.IsSuccessful() {
+			lines = append(lines, "  ---")
+			lines = append(lines, fmt.Sprintf("  message: %q", oneResult.Message()))
+			lines = append(lines, "  ...")
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func toJUnit(results []Result) (string, error) {
+	// one suite per test file, in the order of the results:
+	suites := []junitTestSuite{}
+	indexes := map[string]int{}
+	failures := 0
+	for _, oneResult := range results {
+		index, ok := indexes[oneResult.File()]
+		if !ok {
+			index = len(suites)
+			indexes[oneResult.File()] = index
+			suites = append(suites, junitTestSuite{
+				Name:  oneResult.File(),
+				Cases: []junitTestCase{},
+			})
+		}
+
+		testCase := junitTestCase{
+			Name:      oneResult.Name(),
+			ClassName: oneResult.File(),
+			Time:      fmt.Sprintf("%.3f", oneResult.Duration().Seconds()),
+		}
+
+		if !oneResult.IsSuccessful() {
+			testCase.Failure = &junitFailure{
+				Message: oneResult.Message(),
+				Content: oneResult.Message(),
+			}
+
+			suites[index].Failures++
+			failures++
+		}
+
+		suites[index].Tests++
+		suites[index].Cases = append(suites[index].Cases, testCase)
+	}
+
+	for index, oneSuite := range suites {
+		total := 0.0
+		for _, oneResult := range results {
+			if oneResult.File() == oneSuite.Name {
+				total += oneResult.Duration().Seconds()
+			}
+		}
+
+		suites[index].Time = fmt.Sprintf("%.3f", total)
+	}
+
+	js, jsErr := xml.MarshalIndent(junitTestSuites{
+		Tests:    len(results),
+		Failures: failures,
+		Suites:   suites,
+	}, "", "    ")
+
+	if jsErr != nil {
+		return "", jsErr
+	}
+
+	return xml.Header + string(js) + "\n", nil
+}
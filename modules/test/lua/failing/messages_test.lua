@@ -0,0 +1,16 @@
+-- load the modules:
+local assert = require("assert")
+local test = require("test")
+
+test.case("this case passes", function()
+    assert.isTrue(true)
+end)
+
+test.case("this case fails", function()
+    local resp = test.query(test.root(), "/messages/c52e8cdb-3fb2-4c0a-b4e7-90c677870774")
+    assert.equal(0, resp:code(), "the message was expected to be found")
+end)
+
+test.case("this case never ends", function()
+    while true do end
+end)
@@ -0,0 +1,34 @@
+-- load the modules:
+local json = require("json")
+local assert = require("assert")
+local test = require("test")
+
+local pk = test.root()
+local path = "/messages"
+local id = "c52e8cdb-3fb2-4c0a-b4e7-90c677870774"
+local message = {
+    id = id,
+    title = "This is my message title",
+    description = "this is a message description.  Oh yes!"
+}
+
+test.case("the message is not found before being saved", function()
+    local resp = test.query(pk, path .. "/" .. id)
+    assert.equal(1, resp:code())
+    assert.equal("not found", resp:log())
+end)
+
+test.case("the message is saved, then retrieved", function()
+    local saveResp = test.save(pk, path, json.encode(message))
+    assert.equal(0, saveResp:code(), "the message could not be saved")
+
+    local resp = test.query(pk, path .. "/" .. id)
+    assert.equal(0, resp:code())
+    assert.equal(message, json.decode(resp:value()))
+end)
+
+test.case("the message is deleted", function()
+    local resp = test.delete(pk, path .. "/" .. id)
+    assert.equal(0, resp:code())
+    assert(test.query(pk, path .. "/" .. id):code() == 1)
+end)
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"time"
+)
+
+type result struct {
+	file         string
+	name         string
+	isSuccessful bool
+	message      string
+	duration     time.Duration
+}
+
+func createResult(file string, name string, isSuccessful bool, message string, duration time.Duration) Result {
+	out := result{
+		file:         file,
+		name:         name,
+		isSuccessful: isSuccessful,
+		message:      message,
+		duration:     duration,
+	}
+
+	return &out
+}
+
+// File returns the path of the test file
+func (obj *result) File() string {
+	return obj.file
+}
+
+// Name returns the name of the test
+func (obj *result) Name() string {
+	return obj.name
+}
+
+// IsSuccessful returns true if the test passed, false otherwise
+func (obj *result) IsSuccessful() bool {
+	return obj.isSuccessful
+}
+
+// Message returns the failure message, if any
+func (obj *result) Message() string {
+	return obj.message
+}
+
+// Duration returns the duration of the test
+func (obj *result) Duration() time.Duration {
+	return obj.duration
+}
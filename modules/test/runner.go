@@ -0,0 +1,168 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	applications "github.com/xmnservices/xmnsuite/blockchains/applications"
+	crypto "github.com/xmnservices/xmnsuite/crypto"
+	crypto_module "github.com/xmnservices/xmnsuite/modules/crypto"
+	json_module "github.com/xmnservices/xmnsuite/modules/json"
+	"github.com/xmnservices/xmnsuite/modules/run"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+	sdk_module "github.com/xmnservices/xmnsuite/modules/sdk"
+	uuid_module "github.com/xmnservices/xmnsuite/modules/uuid"
+	lua "github.com/yuin/gopher-lua"
+)
+
+type runner struct {
+	scriptPath string
+	budget     int
+}
+
+func createRunner(scriptPath string, budget int) (Runner, error) {
+	if scriptPath == "" {
+		return nil, errors.New("the chain script is mandatory in order to create a test runner")
+	}
+
+	out := runner{
+		scriptPath: scriptPath,
+		budget:     budget,
+	}
+
+	return &out, nil
+}
+
+// Run runs the test files, each against its own in-memory chain
+func (app *runner) Run(testFilePaths []string) []Result {
+	out := []Result{}
+	for _, oneTestFilePath := range testFilePaths {
+		out = append(out, app.runFile(oneTestFilePath)...)
+	}
+
+	return out
+}
+
+func (app *runner) runFile(testFilePath string) []Result {
+	started := time.Now()
+	failFile := func(err error) []Result {
+		return []Result{
+			createResult(testFilePath, testFilePath, false, err.Error(), time.Since(started)),
+		}
+	}
+
+	// the database of the chain only lives for the duration of the test file:
+	dbPath, dbPathErr := ioutil.TempDir("", "xmn_test")
+	if dbPathErr != nil {
+		return failFile(dbPathErr)
+	}
+	defer os.RemoveAll(dbPath)
+
+	// the root key has write access to every route of the chain:
+	rootPK := crypto.SDKFunc.GenPK()
+
+	// load the chain:
+	chainContext := sandbox.SDKFunc.Create(sandbox.CreateParams{})
+	defer chainContext.Close()
+
+	client, clientErr := app.loadChain(chainContext, dbPath, rootPK)
+	if clientErr != nil {
+		str := fmt.Sprintf("the chain script (%s) could not be loaded: %s", app.scriptPath, clientErr.Error())
+		return failFile(errors.New(str))
+	}
+
+	// create the test context:
+	context := sandbox.SDKFunc.Create(sandbox.CreateParams{})
+	defer context.Close()
+
+	json_module.SDKFunc.Create(json_module.CreateParams{
+		Context: context,
+	})
+
+	crypto_module.SDKFunc.Create(crypto_module.CreateParams{
+		Context: context,
+	})
+
+	uuid_module.SDKFunc.Create(uuid_module.CreateParams{
+		Context: context,
+	})
+
+	sdk_module.SDKFunc.Create(sdk_module.CreateParams{
+		Context: context,
+		Client:  client,
+	})
+
+	registerAssert(context)
+	fix := createFixture(context, rootPK)
+
+	// execute the test file:
+	doFileErr := sandbox.SDKFunc.DoFile(sandbox.DoFileParams{
+		Context: context,
+		Path:    testFilePath,
+	})
+	if doFileErr != nil {
+		return failFile(doFileErr)
+	}
+
+	// a test file without cases is a single test:
+	if len(fix.cases) <= 0 {
+		return []Result{
+			createResult(testFilePath, testFilePath, true, "", time.Since(started)),
+		}
+	}
+
+	// the cases share the chain of their file, in the order they were declared:
+	out := []Result{}
+	for _, oneCase := range fix.cases {
+		caseStarted := time.Now()
+		meter := sandbox.SDKFunc.CreateMeter(sandbox.CreateMeterParams{
+			Context: context,
+			Budget:  app.budget,
+		})
+
+		callErr := meter.Call(lua.P{
+			Fn:      oneCase.fn,
+			NRet:    0,
+			Protect: true,
+		})
+
+		if callErr != nil {
+			out = append(out, createResult(testFilePath, oneCase.name, false, callErr.Error(), time.Since(caseStarted)))
+			continue
+		}
+
+		out = append(out, createResult(testFilePath, oneCase.name, true, "", time.Since(caseStarted)))
+	}
+
+	return out
+}
+
+func (app *runner) loadChain(context *lua.LState, dbPath string, rootPK crypto.PrivateKey) (out applications.Client, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	module := run.SDKFunc.Load(run.LoadParams{
+		Context:     context,
+		ScriptPath:  app.scriptPath,
+		DBPath:      dbPath,
+		RootPubKeys: []crypto.PublicKey{rootPK.PublicKey()},
+	})
+
+	apps, appsErr := module.Applications()
+	if appsErr != nil {
+		return nil, appsErr
+	}
+
+	out = applications.SDKFunc.CreateMemoryClient(applications.CreateMemoryClientParams{
+		Apps: apps,
+	})
+
+	return out, nil
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"time"
+
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
+)
+
+const (
+	// TestFileSuffix represents the suffix of the lua test files
+	TestFileSuffix = "_test.lua"
+
+	// TAPFormat represents the TAP report format
+	TAPFormat = "tap"
+
+	// JUnitFormat represents the JUnit XML report format
+	JUnitFormat = "junit"
+)
+
+// Result represents the result of a test
+type Result interface {
+	File() string
+	Name() string
+	IsSuccessful() bool
+	Message() string
+	Duration() time.Duration
+}
+
+// Runner represents a runner that executes lua test files against an in-memory chain
+type Runner interface {
+	Run(testFilePaths []string) []Result
+}
+
+// CreateRunnerParams represents the CreateRunner params
+type CreateRunnerParams struct {
+	ScriptPath string
+	Budget     int
+}
+
+// DiscoverParams represents the Discover params
+type DiscoverParams struct {
+	Dir string
+}
+
+// FormatParams represents the Format params
+type FormatParams struct {
+	Format  string
+	Results []Result
+}
+
+// SDKFunc represents the test SDK func
+var SDKFunc = struct {
+	CreateRunner func(params CreateRunnerParams) Runner
+	Discover     func(params DiscoverParams) []string
+	Format       func(params FormatParams) string
+}{
+	CreateRunner: func(params CreateRunnerParams) Runner {
+		if params.Budget == 0 {
+			params.Budget = sandbox.DefaultBudget
+		}
+
+		out, outErr := createRunner(params.ScriptPath, params.Budget)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	Discover: func(params DiscoverParams) []string {
+		if params.Dir == "" {
+			params.Dir = "."
+		}
+
+		out, outErr := discover(params.Dir)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	Format: func(params FormatParams) string {
+		if params.Format == "" {
+			params.Format = TAPFormat
+		}
+
+		out, outErr := format(params.Format, params.Results)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+}
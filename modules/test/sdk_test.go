@@ -0,0 +1,85 @@
+package test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRun_Success(t *testing.T) {
+	// discover the test files:
+	testFilePaths := SDKFunc.Discover(DiscoverParams{
+		Dir: "lua/passing",
+	})
+
+	if len(testFilePaths) != 1 {
+		t.Errorf("%d test files were expected, %d returned", 1, len(testFilePaths))
+		return
+	}
+
+	// run:
+	runner := SDKFunc.CreateRunner(CreateRunnerParams{
+		ScriptPath: "lua/chain.lua",
+		Budget:     10000,
+	})
+
+	results := runner.Run(testFilePaths)
+	if len(results) != 3 {
+		t.Errorf("%d results were expected, %d returned", 3, len(results))
+		return
+	}
+
+	for _, oneResult := range results {
+		if !oneResult.IsSuccessful() {
+			t.Errorf("the test (%s) was expected to pass: %s", oneResult.Name(), oneResult.Message())
+			return
+		}
+	}
+}
+
+func TestRun_withFailures_Success(t *testing.T) {
+	// run:
+	runner := SDKFunc.CreateRunner(CreateRunnerParams{
+		ScriptPath: "lua/chain.lua",
+		Budget:     10000,
+	})
+
+	results := runner.Run(SDKFunc.Discover(DiscoverParams{
+		Dir: "lua/failing",
+	}))
+
+	if len(results) != 3 {
+		t.Errorf("%d results were expected, %d returned", 3, len(results))
+		return
+	}
+
+	if !results[0].IsSuccessful() || results[1].IsSuccessful() || results[2].IsSuccessful() {
+		t.Errorf("only the first test was expected to pass")
+		return
+	}
+
+	if !strings.Contains(results[1].Message(), "the message was expected to be found") {
+		t.Errorf("the failure message was expected to contain the assertion message: %s", results[1].Message())
+		return
+	}
+
+	// report:
+	tap := SDKFunc.Format(FormatParams{
+		Format:  TAPFormat,
+		Results: results,
+	})
+
+	if !strings.Contains(tap, "1..3") || !strings.Contains(tap, "not ok 2") {
+		t.Errorf("the TAP report is invalid: %s", tap)
+		return
+	}
+
+	junit := SDKFunc.Format(FormatParams{
+		Format:  JUnitFormat,
+		Results: results,
+	})
+
+	if !strings.Contains(junit, `failures="2"`) {
+		t.Errorf("the JUnit report is invalid: %s", junit)
+		return
+	}
+}
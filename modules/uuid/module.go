@@ -2,6 +2,7 @@ package uuid
 
 import (
 	uuid "github.com/satori/go.uuid"
+	sandbox "github.com/xmnservices/xmnsuite/modules/sandbox"
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -54,6 +55,12 @@ func (app *module) registerNew(context *lua.LState) int {
 			return &id
 		}
 
+		// a random uuid would be different on every node, so the handlers of the chain must receive their uuids:
+		if sandbox.SDKFunc.IsMetered(sandbox.IsMeteredParams{Context: context}) {
+			context.RaiseError("the uuid.new func cannot generate a random uuid in the handlers of the chain, a uuid v4 string must be given")
+			return nil
+		}
+
 		id := uuid.NewV4()
 		return &id
 	}
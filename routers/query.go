@@ -17,16 +17,16 @@ type queryRequest struct {
 }
 
 func createQueryRequest(ptr ResourcePointer, sig crypto.Signature) (QueryRequest, error) {
-	if !ptr.From().Equals(sig.PublicKey(ptr.Hash())) {
-		str := fmt.Sprintf("the signature and resource pointer's hash could not be validated by the resource pointer's public key")
-		return nil, errors.New(str)
-	}
-
 	out := queryRequest{
 		Ptr: ptr,
 		Sig: sig,
 	}
 
+	if !out.IsAuthenticated() && !out.IsLegacyAuthenticated() {
+		str := fmt.Sprintf("the signature and resource pointer's hash could not be validated by the resource pointer's public key")
+		return nil, errors.New(str)
+	}
+
 	return &out, nil
 }
 
@@ -40,6 +40,24 @@ func (obj *queryRequest) Signature() crypto.Signature {
 	return obj.Sig
 }
 
+// IsAuthenticated returns true if the signature has been made by the resource pointer's public key, false otherwise
+func (obj *queryRequest) IsAuthenticated() bool {
+	if obj.Sig == nil || obj.Ptr == nil {
+		return false
+	}
+
+	return obj.Sig.VerifyBytes(QueryDomain, []byte(obj.Ptr.Hash()), obj.Ptr.From())
+}
+
+// IsLegacyAuthenticated returns true if the signature has been made by the resource pointer's public key, without domain, false otherwise
+func (obj *queryRequest) IsLegacyAuthenticated() bool {
+	if obj.Sig == nil || obj.Ptr == nil {
+		return false
+	}
+
+	return obj.Ptr.From().Equals(obj.Sig.PublicKey(obj.Ptr.Hash()))
+}
+
 /*
  * QueryResponse
  */
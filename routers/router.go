@@ -17,6 +17,7 @@ type handler struct {
 	saveTrx SaveTransactionFn
 	delTrx  DeleteTransactionFn
 	query   QueryFn
+	schema  Schema
 }
 
 func createHandler(saveTrx SaveTransactionFn, delTrx DeleteTransactionFn, query QueryFn) (Handler, int, error) {
@@ -65,6 +66,21 @@ func createHandlerWithQueryFn(query QueryFn) Handler {
 	return &out
 }
 
+func createHandlerWithSchema(handl Handler, schema Schema) (Handler, error) {
+	if handl.SaveTransaction() == nil {
+		return nil, errors.New("the schema can only be added to an Handler instance with a save transaction func")
+	}
+
+	out := handler{
+		saveTrx: handl.SaveTransaction(),
+		delTrx:  nil,
+		query:   nil,
+		schema:  schema,
+	}
+
+	return &out, nil
+}
+
 // SaveTransaction returns the save transaction func, if any
 func (obj *handler) SaveTransaction() SaveTransactionFn {
 	return obj.saveTrx
@@ -85,36 +101,54 @@ func (obj *handler) IsWrite() bool {
 	return obj.delTrx != nil || obj.saveTrx != nil
 }
 
+// HasSchema returns true if the handler has a schema, false otherwise
+func (obj *handler) HasSchema() bool {
+	return obj.schema != nil
+}
+
+// Schema returns the schema, if any
+func (obj *handler) Schema() Schema {
+	return obj.schema
+}
+
 /*
  * PreparedHandler
  */
 
 type preparedHandler struct {
-	path   string
-	params map[string]string
-	handl  Handler
+	pattern string
+	path    string
+	params  map[string]string
+	handl   Handler
 }
 
-func createPreparedHandler(path string, handl Handler) PreparedHandler {
+func createPreparedHandler(pattern string, path string, handl Handler) PreparedHandler {
 	out := preparedHandler{
-		path:   path,
-		params: map[string]string{},
-		handl:  handl,
+		pattern: pattern,
+		path:    path,
+		params:  map[string]string{},
+		handl:   handl,
 	}
 
 	return &out
 }
 
-func createPreparedHandlerWithParams(path string, params map[string]string, handl Handler) PreparedHandler {
+func createPreparedHandlerWithParams(pattern string, path string, params map[string]string, handl Handler) PreparedHandler {
 	out := preparedHandler{
-		path:   path,
-		params: params,
-		handl:  handl,
+		pattern: pattern,
+		path:    path,
+		params:  params,
+		handl:   handl,
 	}
 
 	return &out
 }
 
+// Pattern returns the pattern of the route that matched the path
+func (obj *preparedHandler) Pattern() string {
+	return obj.pattern
+}
+
 // Path returns the path
 func (obj *preparedHandler) Path() string {
 	return obj.path
@@ -137,6 +171,7 @@ func (obj *preparedHandler) Handler() Handler {
 type route struct {
 	rols          roles.Roles
 	usrs          users.Users
+	urlPattern    string
 	pattern       *regexp.Regexp
 	variableNames []string
 	handl         Handler
@@ -154,6 +189,7 @@ func createRoute(roleKey string, rols roles.Roles, usrs users.Users, patternAsSt
 	out := route{
 		rols:          rols,
 		usrs:          usrs,
+		urlPattern:    patternAsString,
 		pattern:       pattern,
 		variableNames: variableNames,
 		handl:         handl,
@@ -205,7 +241,7 @@ func (obj *route) Handler(from crypto.PublicKey, path string) PreparedHandler {
 		params[oneVariableName] = values[index]
 	}
 
-	out := createPreparedHandlerWithParams(path, params, obj.handl)
+	out := createPreparedHandlerWithParams(obj.urlPattern, path, params, obj.handl)
 	return out
 }
 
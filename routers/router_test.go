@@ -123,7 +123,7 @@ func TestCreatePreparedHandler_Success(t *testing.T) {
 	handler := createHandlerWithQueryFn(queryFn)
 
 	//execute:
-	preparedHandler := createPreparedHandler(path, handler)
+	preparedHandler := createPreparedHandler(path, path, handler)
 	retPath := preparedHandler.Path()
 	retHandler := preparedHandler.Handler()
 	retParams := preparedHandler.Params()
@@ -150,6 +150,7 @@ func TestCreatePreparedHandler_withParams_Success(t *testing.T) {
 		return nil, nil
 	}
 
+	pattern := "/this/is/a/<some|[a-z]+>"
 	path := "/this/is/a/path"
 	handler := createHandlerWithQueryFn(queryFn)
 	params := map[string]string{
@@ -157,7 +158,8 @@ func TestCreatePreparedHandler_withParams_Success(t *testing.T) {
 	}
 
 	//execute:
-	preparedHandler := createPreparedHandlerWithParams(path, params, handler)
+	preparedHandler := createPreparedHandlerWithParams(pattern, path, params, handler)
+	retPattern := preparedHandler.Pattern()
 	retPath := preparedHandler.Path()
 	retHandler := preparedHandler.Handler()
 	retParams := preparedHandler.Params()
@@ -176,6 +178,11 @@ func TestCreatePreparedHandler_withParams_Success(t *testing.T) {
 		t.Errorf("the returned params are invalid.")
 		return
 	}
+
+	if pattern != retPattern {
+		t.Errorf("the returned pattern is invalid.  Expected: %s, Returned: %s", pattern, retPattern)
+		return
+	}
 }
 
 func TestCreateRoute_withReadRoute_matches_Success(t *testing.T) {
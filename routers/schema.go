@@ -0,0 +1,289 @@
+package routers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	objectType  = "object"
+	arrayType   = "array"
+	stringType  = "string"
+	numberType  = "number"
+	integerType = "integer"
+	booleanType = "boolean"
+	nullType    = "null"
+)
+
+/*
+ * Schema
+ *
+ * A subset of JSON schema: type, properties, required, additionalProperties, items,
+ * enum, minLength, maxLength, pattern, minimum, maximum, minItems and maxItems.
+ * The other keywords are ignored.
+ */
+
+type schema struct {
+	RawType              json.RawMessage    `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []interface{}      `json:"enum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Pattern              string             `json:"pattern"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	types                []string
+	pattern              *regexp.Regexp
+}
+
+func createSchema(js []byte) (Schema, error) {
+	out := new(schema)
+	jsErr := json.Unmarshal(js, out)
+	if jsErr != nil {
+		str := fmt.Sprintf("the given schema is not a valid json object: %s", jsErr.Error())
+		return nil, errors.New(str)
+	}
+
+	compileErr := out.compile("$")
+	if compileErr != nil {
+		return nil, compileErr
+	}
+
+	return out, nil
+}
+
+// Validate validates the json data.  The returned error contains the path of the first invalid value
+func (obj *schema) Validate(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	decodeErr := decoder.Decode(&value)
+	if decodeErr != nil {
+		str := fmt.Sprintf("$: the data is not valid json: %s", decodeErr.Error())
+		return errors.New(str)
+	}
+
+	// the handlers parse the whole data, so nothing can follow the validated value:
+	if _, tokenErr := decoder.Token(); tokenErr != io.EOF {
+		return errors.New("$: the data must contain a single json value")
+	}
+
+	return obj.validate("$", value)
+}
+
+func (obj *schema) compile(path string) error {
+	// the type is either a string or a list of strings:
+	if len(obj.RawType) > 0 {
+		var oneType string
+		if json.Unmarshal(obj.RawType, &oneType) == nil {
+			obj.types = []string{oneType}
+		} else if json.Unmarshal(obj.RawType, &obj.types) != nil {
+			str := fmt.Sprintf("%s: the type of the schema must be a string or a list of strings", path)
+			return errors.New(str)
+		}
+
+		for _, oneType := range obj.types {
+			switch oneType {
+			case objectType, arrayType, stringType, numberType, integerType, booleanType, nullType:
+				continue
+			}
+
+			str := fmt.Sprintf("%s: the type (%s) of the schema is not supported", path, oneType)
+			return errors.New(str)
+		}
+	}
+
+	if obj.Pattern != "" {
+		pattern, patternErr := regexp.Compile(obj.Pattern)
+		if patternErr != nil {
+			str := fmt.Sprintf("%s: the pattern (%s) of the schema is invalid: %s", path, obj.Pattern, patternErr.Error())
+			return errors.New(str)
+		}
+
+		obj.pattern = pattern
+	}
+
+	for name, oneProperty := range obj.Properties {
+		compileErr := oneProperty.compile(fmt.Sprintf("%s.%s", path, name))
+		if compileErr != nil {
+			return compileErr
+		}
+	}
+
+	if obj.Items != nil {
+		return obj.Items.compile(fmt.Sprintf("%s[]", path))
+	}
+
+	return nil
+}
+
+func (obj *schema) validate(path string, value interface{}) error {
+	outputErrorFn := func(format string, args ...interface{}) error {
+		str := fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...))
+		return errors.New(str)
+	}
+
+	// type:
+	valueType := typeOf(value)
+	if len(obj.types) > 0 && !obj.hasType(valueType, value) {
+		return outputErrorFn("expected a value of type %s, %s given", strings.Join(obj.types, " or "), valueType)
+	}
+
+	// enum:
+	if len(obj.Enum) > 0 && !isInEnum(value, obj.Enum) {
+		return outputErrorFn("the value is not one of the values of the enum")
+	}
+
+	switch casted := value.(type) {
+	case map[string]interface{}:
+		for _, oneRequired := range obj.Required {
+			if _, ok := casted[oneRequired]; !ok {
+				return outputErrorFn("the property (%s) is required", oneRequired)
+			}
+		}
+
+		// the keys are sorted so that the same invalid data always produces the same error, on every node:
+		keys := []string{}
+		for oneKey := range casted {
+			keys = append(keys, oneKey)
+		}
+
+		sort.Strings(keys)
+		for _, oneKey := range keys {
+			childPath := fmt.Sprintf("%s.%s", path, oneKey)
+			if property, ok := obj.Properties[oneKey]; ok {
+				propErr := property.validate(childPath, casted[oneKey])
+				if propErr != nil {
+					return propErr
+				}
+
+				continue
+			}
+
+			if obj.AdditionalProperties != nil && !*obj.AdditionalProperties {
+				str := fmt.Sprintf("%s: the property is not declared in the schema", childPath)
+				return errors.New(str)
+			}
+		}
+
+	case []interface{}:
+		if obj.MinItems != nil && len(casted) < *obj.MinItems {
+			return outputErrorFn("expected at least %d items, %d given", *obj.MinItems, len(casted))
+		}
+
+		if obj.MaxItems != nil && len(casted) > *obj.MaxItems {
+			return outputErrorFn("expected at most %d items, %d given", *obj.MaxItems, len(casted))
+		}
+
+		if obj.Items != nil {
+			for index, oneItem := range casted {
+				itemErr := obj.Items.validate(fmt.Sprintf("%s[%d]", path, index), oneItem)
+				if itemErr != nil {
+					return itemErr
+				}
+			}
+		}
+
+	case string:
+		length := utf8.RuneCountInString(casted)
+		if obj.MinLength != nil && length < *obj.MinLength {
+			return outputErrorFn("expected at least %d characters, %d given", *obj.MinLength, length)
+		}
+
+		if obj.MaxLength != nil && length > *obj.MaxLength {
+			return outputErrorFn("expected at most %d characters, %d given", *obj.MaxLength, length)
+		}
+
+		if obj.pattern != nil && !obj.pattern.MatchString(casted) {
+			return outputErrorFn("the value does not match the pattern (%s)", obj.Pattern)
+		}
+
+	case json.Number:
+		number, numberErr := casted.Float64()
+		if numberErr != nil {
+			return outputErrorFn("the number (%s) is invalid", casted.String())
+		}
+
+		if obj.Minimum != nil && number < *obj.Minimum {
+			return outputErrorFn("expected a number greater than or equal to %v, %s given", *obj.Minimum, casted.String())
+		}
+
+		if obj.Maximum != nil && number > *obj.Maximum {
+			return outputErrorFn("expected a number smaller than or equal to %v, %s given", *obj.Maximum, casted.String())
+		}
+	}
+
+	return nil
+}
+
+func (obj *schema) hasType(valueType string, value interface{}) bool {
+	for _, oneType := range obj.types {
+		if oneType == valueType {
+			return true
+		}
+
+		if oneType == numberType && valueType == integerType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch casted := value.(type) {
+	case map[string]interface{}:
+		return objectType
+	case []interface{}:
+		return arrayType
+	case string:
+		return stringType
+	case bool:
+		return booleanType
+	case json.Number:
+		number, numberErr := casted.Float64()
+		if numberErr == nil && number == math.Trunc(number) {
+			return integerType
+		}
+
+		return numberType
+	}
+
+	return nullType
+}
+
+func isInEnum(value interface{}, enum []interface{}) bool {
+	for _, oneValue := range enum {
+		if number, ok := value.(json.Number); ok {
+			if oneNumber, ok := oneValue.(float64); ok {
+				asFloat, asFloatErr := number.Float64()
+				if asFloatErr == nil && asFloat == oneNumber {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		if reflect.DeepEqual(value, oneValue) {
+			return true
+		}
+	}
+
+	return false
+}
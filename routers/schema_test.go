@@ -0,0 +1,91 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["title", "tags"],
+	"additionalProperties": false,
+	"properties": {
+		"title": {"type": "string", "minLength": 3, "maxLength": 20},
+		"kind": {"enum": ["book", "movie"]},
+		"rating": {"type": "integer", "minimum": 0, "maximum": 5},
+		"code": {"type": "string", "pattern": "^[A-Z]{3}$"},
+		"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+	}
+}`
+
+func TestSchema_Success(t *testing.T) {
+	//variables:
+	schema := SDKFunc.CreateSchema(CreateSchemaParams{
+		JS: []byte(testSchema),
+	})
+
+	data := []byte(`{"title": "Dune", "kind": "book", "rating": 5, "code": "DUN", "tags": ["sf"]}`)
+
+	//execute:
+	validateErr := schema.Validate(data)
+	if validateErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", validateErr.Error())
+		return
+	}
+}
+
+func TestSchema_withInvalidData_returnsError(t *testing.T) {
+	//variables:
+	schema := SDKFunc.CreateSchema(CreateSchemaParams{
+		JS: []byte(testSchema),
+	})
+
+	paths := map[string]string{
+		`{"tags": []}`: "$:",
+		`{"title": "Dune", "tags": [], "author": "me"}`:   "$.author:",
+		`{"title": "Du", "tags": []}`:                     "$.title:",
+		`{"title": "Dune", "tags": [], "kind": "game"}`:   "$.kind:",
+		`{"title": "Dune", "tags": [], "rating": 4.5}`:    "$.rating:",
+		`{"title": "Dune", "tags": [], "rating": 6}`:      "$.rating:",
+		`{"title": "Dune", "tags": [], "code": "dun"}`:    "$.code:",
+		`{"title": "Dune", "tags": ["sf", 3]}`:            "$.tags[1]:",
+		`{"title": "Dune", "tags": ["sf", "old", "new"]}`: "$.tags:",
+		`["title"]`:                        "$:",
+		`{"title":`:                        "$:",
+		`{"title": "Dune", "tags": []} {}`: "$:",
+		`{"title": "Dune", "tags": []}]`:   "$:",
+	}
+
+	//execute:
+	for data, path := range paths {
+		validateErr := schema.Validate([]byte(data))
+		if validateErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (data: %s)", data)
+			return
+		}
+
+		if !strings.HasPrefix(validateErr.Error(), path) {
+			t.Errorf("the returned error was expected to start with the path %s, error returned: %s", path, validateErr.Error())
+			return
+		}
+	}
+}
+
+func TestSchema_withInvalidSchema_returnsError(t *testing.T) {
+	//variables:
+	schemas := []string{
+		`{"type": "date"}`,
+		`{"type": 3}`,
+		`{"properties": {"code": {"pattern": "["}}}`,
+		`not json`,
+	}
+
+	//execute:
+	for _, oneSchema := range schemas {
+		_, schemaErr := createSchema([]byte(oneSchema))
+		if schemaErr == nil {
+			t.Errorf("the returned error was expected to be valid, nil returned (schema: %s)", oneSchema)
+			return
+		}
+	}
+}
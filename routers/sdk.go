@@ -53,6 +53,17 @@ const (
 	Retrieve
 )
 
+const (
+	// SaveTransactionDomain represents the domain in which the resources of the save transactions are signed
+	SaveTransactionDomain = "xmnsuite/routers/transaction/save"
+
+	// DeleteTransactionDomain represents the domain in which the resource pointers of the delete transactions are signed
+	DeleteTransactionDomain = "xmnsuite/routers/transaction/delete"
+
+	// QueryDomain represents the domain in which the resource pointers of the queries are signed
+	QueryDomain = "xmnsuite/routers/query"
+)
+
 // ResourcePointer represents a resource pointer
 type ResourcePointer interface {
 	From() crypto.PublicKey
@@ -72,6 +83,8 @@ type TransactionRequest interface {
 	Resource() Resource
 	Pointer() ResourcePointer
 	Signature() crypto.Signature
+	IsAuthenticated() bool
+	IsLegacyAuthenticated() bool
 }
 
 // TransactionResponse represents a transaction response
@@ -86,6 +99,8 @@ type TransactionResponse interface {
 type QueryRequest interface {
 	Pointer() ResourcePointer
 	Signature() crypto.Signature
+	IsAuthenticated() bool
+	IsLegacyAuthenticated() bool
 }
 
 // QueryResponse represents a query response
@@ -96,16 +111,24 @@ type QueryResponse interface {
 	Value() []byte
 }
 
+// Schema represents a JSON schema the data of the save transactions must conform to
+type Schema interface {
+	Validate(data []byte) error
+}
+
 // Handler represents a router handler
 type Handler interface {
 	SaveTransaction() SaveTransactionFn
 	DeleteTransaction() DeleteTransactionFn
 	Query() QueryFn
 	IsWrite() bool
+	HasSchema() bool
+	Schema() Schema
 }
 
 // PreparedHandler represents a prepated handler
 type PreparedHandler interface {
+	Pattern() string
 	Path() string
 	Params() map[string]string
 	Handler() Handler
@@ -170,12 +193,18 @@ type CreateQueryResponseParams struct {
 	JSData []byte
 }
 
-// CreateRouteParams represents the CreateRoute params
+// CreateSchemaParams represents the CreateSchema params
+type CreateSchemaParams struct {
+	JS []byte
+}
+
+// CreateRouteParams represents the CreateRoute params.  The Schema can only be set on a route with a SaveTrx
 type CreateRouteParams struct {
 	Pattern  string
 	SaveTrx  SaveTransactionFn
 	DelTrx   DeleteTransactionFn
 	QueryTrx QueryFn
+	Schema   Schema
 }
 
 // CreateRouterParams represents the CreateRouter params
@@ -193,6 +222,7 @@ var SDKFunc = struct {
 	CreateTransactionResponse func(params CreateTransactionResponseParams) TransactionResponse
 	CreateQueryRequest        func(params CreateQueryRequestParams) QueryRequest
 	CreateQueryResponse       func(params CreateQueryResponseParams) QueryResponse
+	CreateSchema              func(params CreateSchemaParams) Schema
 	CreateRouter              func(params CreateRouterParams) Router
 }{
 	CreateResourcePointer: func(params CreateResourcePointerParams) ResourcePointer {
@@ -235,7 +265,8 @@ var SDKFunc = struct {
 		panic(errors.New("the params must contain a Resource or a PointerResource"))
 	},
 	CreateTransactionResponse: func(params CreateTransactionResponseParams) TransactionResponse {
-		if params.GazUsed != 0 && params.Tags != nil {
+		// the tags are kept even when the transaction does not use any gaz:
+		if params.Tags != nil {
 			out, outErr := createTransactionResponse(params.Code, params.Log, params.GazUsed, params.Tags)
 			if outErr != nil {
 				panic(outErr)
@@ -296,6 +327,14 @@ var SDKFunc = struct {
 
 		return out
 	},
+	CreateSchema: func(params CreateSchemaParams) Schema {
+		out, outErr := createSchema(params.JS)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
 	CreateRouter: func(params CreateRouterParams) Router {
 		rtes := map[int][]Route{}
 		rols := params.DataStore.Roles()
@@ -307,6 +346,14 @@ var SDKFunc = struct {
 				panic(handlrErr)
 			}
 
+			//add the schema, if any:
+			if oneRteParams.Schema != nil {
+				handlr, handlrErr = createHandlerWithSchema(handlr, oneRteParams.Schema)
+				if handlrErr != nil {
+					panic(handlrErr)
+				}
+			}
+
 			//create route:
 			rte, rteErr := createRoute(params.RoleKey, rols, usrs, oneRteParams.Pattern, handlr)
 			if rteErr != nil {
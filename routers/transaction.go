@@ -18,32 +18,32 @@ type transactionRequest struct {
 }
 
 func createTransactionRequestWithResource(res Resource, sig crypto.Signature) (TransactionRequest, error) {
-	if !res.Pointer().From().Equals(sig.PublicKey(res.Hash())) {
-		str := fmt.Sprintf("the signature and resource hash could not be validated by the resource pointer's public key")
-		return nil, errors.New(str)
-	}
-
 	out := transactionRequest{
 		Res: res,
 		Ptr: nil,
 		Sig: sig,
 	}
 
+	if !out.IsAuthenticated() && !out.IsLegacyAuthenticated() {
+		str := fmt.Sprintf("the signature and resource hash could not be validated by the resource pointer's public key")
+		return nil, errors.New(str)
+	}
+
 	return &out, nil
 }
 
 func createTransactionRequestWithResourcePointer(ptr ResourcePointer, sig crypto.Signature) (TransactionRequest, error) {
-	if !ptr.From().Equals(sig.PublicKey(ptr.Hash())) {
-		str := fmt.Sprintf("the signature and resource pointer hash could not be validated by the resource pointer's public key")
-		return nil, errors.New(str)
-	}
-
 	out := transactionRequest{
 		Res: nil,
 		Ptr: ptr,
 		Sig: sig,
 	}
 
+	if !out.IsAuthenticated() && !out.IsLegacyAuthenticated() {
+		str := fmt.Sprintf("the signature and resource pointer hash could not be validated by the resource pointer's public key")
+		return nil, errors.New(str)
+	}
+
 	return &out, nil
 }
 
@@ -62,6 +62,40 @@ func (obj *transactionRequest) Signature() crypto.Signature {
 	return obj.Sig
 }
 
+// IsAuthenticated returns true if the signature has been made by the resource pointer's public key, false otherwise
+func (obj *transactionRequest) IsAuthenticated() bool {
+	if obj.Sig == nil {
+		return false
+	}
+
+	if obj.Res != nil {
+		return obj.Sig.VerifyBytes(SaveTransactionDomain, []byte(obj.Res.Hash()), obj.Res.Pointer().From())
+	}
+
+	if obj.Ptr != nil {
+		return obj.Sig.VerifyBytes(DeleteTransactionDomain, []byte(obj.Ptr.Hash()), obj.Ptr.From())
+	}
+
+	return false
+}
+
+// IsLegacyAuthenticated returns true if the signature has been made by the resource pointer's public key, without domain, false otherwise
+func (obj *transactionRequest) IsLegacyAuthenticated() bool {
+	if obj.Sig == nil {
+		return false
+	}
+
+	if obj.Res != nil {
+		return obj.Res.Pointer().From().Equals(obj.Sig.PublicKey(obj.Res.Hash()))
+	}
+
+	if obj.Ptr != nil {
+		return obj.Ptr.From().Equals(obj.Sig.PublicKey(obj.Ptr.Hash()))
+	}
+
+	return false
+}
+
 /*
  * TransactionResponse
  */
@@ -0,0 +1,55 @@
+package signer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/xmnservices/xmnsuite/crypto"
+)
+
+type autoApprover struct {
+}
+
+func createAutoApprover() Approver {
+	out := autoApprover{}
+	return &out
+}
+
+// Approve approves every signing request
+func (app *autoApprover) Approve(keyName string, pubKey crypto.PublicKey, domain string, msg []byte) bool {
+	return true
+}
+
+type promptApprover struct {
+	in  *bufio.Reader
+	out io.Writer
+	mut *sync.Mutex
+}
+
+func createPromptApprover(in io.Reader, out io.Writer) Approver {
+	app := promptApprover{
+		in:  bufio.NewReader(in),
+		out: out,
+		mut: &sync.Mutex{},
+	}
+
+	return &app
+}
+
+// Approve asks the operator to approve the signing request, one request at a time
+func (app *promptApprover) Approve(keyName string, pubKey crypto.PublicKey, domain string, msg []byte) bool {
+	app.mut.Lock()
+	defer app.mut.Unlock()
+
+	fmt.Fprintf(app.out, "Signing request (key: %s, PublicKey: %s)\nDomain: %s\nMessage: %q\nApprove? [y/N]: ", keyName, pubKey.String(), domain, msg)
+	line, lineErr := app.in.ReadString('\n')
+	if lineErr != nil && line == "" {
+		return false
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
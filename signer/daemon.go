@@ -0,0 +1,160 @@
+package signer
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/xmnservices/xmnsuite/crypto"
+)
+
+type daemon struct {
+	network  string
+	address  string
+	token    string
+	keys     map[string]crypto.PrivateKey
+	approver Approver
+	listener net.Listener
+}
+
+func createDaemon(address string, token string, keys map[string]crypto.PrivateKey, approver Approver) (Daemon, error) {
+	network, addr, addrErr := fromAddressToNetwork(address)
+	if addrErr != nil {
+		return nil, addrErr
+	}
+
+	// a tcp daemon is reachable by other users and machines, so its clients must authenticate:
+	if network == "tcp" && token == "" {
+		return nil, errors.New("the token is mandatory in order to create a signer Daemon that listens on a tcp address")
+	}
+
+	if len(keys) <= 0 {
+		return nil, errors.New("at least one key is mandatory in order to create a signer Daemon instance")
+	}
+
+	out := daemon{
+		network:  network,
+		address:  addr,
+		token:    token,
+		keys:     keys,
+		approver: approver,
+		listener: nil,
+	}
+
+	return &out, nil
+}
+
+// Start starts listening for signing requests, in the background
+func (app *daemon) Start() error {
+	if app.listener != nil {
+		return errors.New("the signer daemon is already started")
+	}
+
+	if app.network == "unix" {
+		// remove the socket left by a daemon that was not stopped properly:
+		os.Remove(app.address)
+	}
+
+	listener, listenerErr := net.Listen(app.network, app.address)
+	if listenerErr != nil {
+		return listenerErr
+	}
+
+	if app.network == "unix" {
+		// only the owner of the daemon can talk to it:
+		chmodErr := os.Chmod(app.address, 0600)
+		if chmodErr != nil {
+			listener.Close()
+			return chmodErr
+		}
+	}
+
+	app.listener = listener
+	go app.serve(listener)
+	return nil
+}
+
+// Stop stops the signer daemon
+func (app *daemon) Stop() error {
+	if app.listener == nil {
+		return errors.New("the signer daemon is not started")
+	}
+
+	closeErr := app.listener.Close()
+	app.listener = nil
+	if app.network == "unix" {
+		os.Remove(app.address)
+	}
+
+	return closeErr
+}
+
+func (app *daemon) serve(listener net.Listener) {
+	for {
+		conn, connErr := listener.Accept()
+		if connErr != nil {
+			return
+		}
+
+		go app.handle(conn)
+	}
+}
+
+func (app *daemon) handle(conn net.Conn) {
+	defer conn.Close()
+
+	req := new(signerRequest)
+	decErr := json.NewDecoder(conn).Decode(req)
+	if decErr != nil {
+		json.NewEncoder(conn).Encode(signerResponse{
+			Error: fmt.Sprintf("the signing request is invalid: %s", decErr.Error()),
+		})
+
+		return
+	}
+
+	resp, respErr := app.execute(req)
+	if respErr != nil {
+		resp = &signerResponse{
+			Error: respErr.Error(),
+		}
+	}
+
+	json.NewEncoder(conn).Encode(resp)
+}
+
+func (app *daemon) execute(req *signerRequest) (*signerResponse, error) {
+	if subtle.ConstantTimeCompare([]byte(req.Token), []byte(app.token)) != 1 {
+		return nil, errors.New("the token is invalid")
+	}
+
+	pk, ok := app.keys[req.Key]
+	if !ok {
+		str := fmt.Sprintf("the key (%s) is not held by the signer daemon", req.Key)
+		return nil, errors.New(str)
+	}
+
+	switch req.Action {
+	case signerActionPubKey:
+		return &signerResponse{
+			PubKey: pk.PublicKey().String(),
+		}, nil
+	case signerActionSign:
+		if !app.approver.Approve(req.Key, pk.PublicKey(), req.Domain, req.Msg) {
+			str := fmt.Sprintf("the signing request (key: %s, domain: %s) has been denied", req.Key, req.Domain)
+			return nil, errors.New(str)
+		}
+
+		sig := pk.SignBytes(req.Domain, req.Msg)
+		return &signerResponse{
+			PubKey: pk.PublicKey().String(),
+			Sig:    sig.String(),
+		}, nil
+	}
+
+	str := fmt.Sprintf("the signing action (%s) is not supported", req.Action)
+	return nil, errors.New(str)
+}
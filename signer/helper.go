@@ -0,0 +1,40 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	signerActionPubKey = "pubkey"
+	signerActionSign   = "sign"
+)
+
+type signerRequest struct {
+	Action string `json:"action"`
+	Token  string `json:"token"`
+	Key    string `json:"key"`
+	Domain string `json:"domain"`
+	Msg    []byte `json:"msg"`
+}
+
+type signerResponse struct {
+	Error  string `json:"error"`
+	PubKey string `json:"pubkey"`
+	Sig    string `json:"signature"`
+}
+
+// fromAddressToNetwork splits an address (unix:///path/to/socket or tcp://host:port) into its network and address
+func fromAddressToNetwork(address string) (string, string, error) {
+	if strings.HasPrefix(address, "unix://") {
+		return "unix", strings.TrimPrefix(address, "unix://"), nil
+	}
+
+	if strings.HasPrefix(address, "tcp://") {
+		return "tcp", strings.TrimPrefix(address, "tcp://"), nil
+	}
+
+	str := fmt.Sprintf("the signer address (%s) must either be a unix (unix:///path/to/socket) or a tcp (tcp://host:port) address", address)
+	return "", "", errors.New(str)
+}
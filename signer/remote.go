@@ -0,0 +1,146 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/xmnservices/xmnsuite/crypto"
+)
+
+// the duration a signing request can wait, which includes the time an operator takes to approve it:
+const remoteTimeout = 5 * time.Minute
+
+type remote struct {
+	network string
+	address string
+	token   string
+	key     string
+	pubKey  crypto.PublicKey
+}
+
+func createRemote(address string, token string, key string) (crypto.Signer, error) {
+	network, addr, addrErr := fromAddressToNetwork(address)
+	if addrErr != nil {
+		return nil, addrErr
+	}
+
+	out := remote{
+		network: network,
+		address: addr,
+		token:   token,
+		key:     key,
+		pubKey:  nil,
+	}
+
+	// retrieve the PublicKey once, since it never changes:
+	resp, respErr := out.call(&signerRequest{
+		Action: signerActionPubKey,
+		Token:  token,
+		Key:    key,
+	})
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	pubKey, pubKeyErr := fromStringToPubKey(resp.PubKey)
+	if pubKeyErr != nil {
+		return nil, pubKeyErr
+	}
+
+	out.pubKey = pubKey
+	return &out, nil
+}
+
+// PublicKey returns the PublicKey of the remote key
+func (app *remote) PublicKey() crypto.PublicKey {
+	return app.pubKey
+}
+
+// Sign asks the signer daemon to sign the message, separated by the given domain
+func (app *remote) Sign(domain string, msg []byte) (crypto.Signature, error) {
+	resp, respErr := app.call(&signerRequest{
+		Action: signerActionSign,
+		Token:  app.token,
+		Key:    app.key,
+		Domain: domain,
+		Msg:    msg,
+	})
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	sig, sigErr := fromStringToSig(resp.Sig)
+	if sigErr != nil {
+		return nil, sigErr
+	}
+
+	// never trust the daemon blindly:
+	if !sig.VerifyBytes(domain, msg, app.pubKey) {
+		str := fmt.Sprintf("the signature returned by the signer daemon (key: %s) is invalid", app.key)
+		return nil, errors.New(str)
+	}
+
+	return sig, nil
+}
+
+func (app *remote) call(req *signerRequest) (*signerResponse, error) {
+	conn, connErr := net.DialTimeout(app.network, app.address, time.Second*5)
+	if connErr != nil {
+		str := fmt.Sprintf("the signer daemon (%s://%s) could not be reached: %s", app.network, app.address, connErr.Error())
+		return nil, errors.New(str)
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(remoteTimeout))
+	encErr := json.NewEncoder(conn).Encode(req)
+	if encErr != nil {
+		return nil, encErr
+	}
+
+	resp := new(signerResponse)
+	decErr := json.NewDecoder(conn).Decode(resp)
+	if decErr != nil {
+		return nil, decErr
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	return resp, nil
+}
+
+func fromStringToPubKey(str string) (out crypto.PublicKey, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the PublicKey returned by the signer daemon is invalid: %v", r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreatePubKey(crypto.CreatePubKeyParams{
+		PubKeyAsString: str,
+	})
+
+	return out, nil
+}
+
+func fromStringToSig(str string) (out crypto.Signature, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("the Signature returned by the signer daemon is invalid: %v", r)
+		}
+	}()
+
+	out = crypto.SDKFunc.CreateSig(crypto.CreateSigParams{
+		SigAsString: str,
+	})
+
+	return out, nil
+}
@@ -0,0 +1,84 @@
+package signer
+
+import (
+	"io"
+	"os"
+
+	"github.com/xmnservices/xmnsuite/crypto"
+)
+
+// Approver represents an approver of the signing requests received by a Daemon
+type Approver interface {
+	Approve(keyName string, pubKey crypto.PublicKey, domain string, msg []byte) bool
+}
+
+// Daemon represents a signer daemon that holds keys and signs messages on behalf of its clients
+type Daemon interface {
+	Start() error
+	Stop() error
+}
+
+// CreateDaemonParams represents the CreateDaemon params
+type CreateDaemonParams struct {
+	Address  string
+	Token    string
+	Keys     map[string]crypto.PrivateKey
+	Approver Approver
+}
+
+// CreateRemoteParams represents the CreateRemote params
+type CreateRemoteParams struct {
+	Address string
+	Token   string
+	Key     string
+}
+
+// CreatePromptApproverParams represents the CreatePromptApprover params
+type CreatePromptApproverParams struct {
+	In  io.Reader
+	Out io.Writer
+}
+
+// SDKFunc represents the signer SDK func
+var SDKFunc = struct {
+	CreateDaemon         func(params CreateDaemonParams) Daemon
+	CreateRemote         func(params CreateRemoteParams) crypto.Signer
+	CreateAutoApprover   func() Approver
+	CreatePromptApprover func(params CreatePromptApproverParams) Approver
+}{
+	CreateDaemon: func(params CreateDaemonParams) Daemon {
+		if params.Approver == nil {
+			params.Approver = createAutoApprover()
+		}
+
+		out, outErr := createDaemon(params.Address, params.Token, params.Keys, params.Approver)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateRemote: func(params CreateRemoteParams) crypto.Signer {
+		out, outErr := createRemote(params.Address, params.Token, params.Key)
+		if outErr != nil {
+			panic(outErr)
+		}
+
+		return out
+	},
+	CreateAutoApprover: func() Approver {
+		return createAutoApprover()
+	},
+	CreatePromptApprover: func(params CreatePromptApproverParams) Approver {
+		if params.In == nil {
+			params.In = os.Stdin
+		}
+
+		if params.Out == nil {
+			params.Out = os.Stdout
+		}
+
+		out := createPromptApprover(params.In, params.Out)
+		return out
+	},
+}
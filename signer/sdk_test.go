@@ -0,0 +1,143 @@
+package signer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xmnservices/xmnsuite/crypto"
+)
+
+func TestDaemon_remoteSign_Success(t *testing.T) {
+	// variables:
+	dirPath := "test_files"
+	address := fmt.Sprintf("unix://%s", filepath.Join(dirPath, "signer.sock"))
+	token := "this-is-a-token"
+	domain := "xmnsuite/signer/tests"
+	msg := []byte("this is a message to sign")
+	pk := crypto.SDKFunc.GenPK()
+	os.MkdirAll(dirPath, os.ModePerm)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	// start the daemon:
+	daemon := SDKFunc.CreateDaemon(CreateDaemonParams{
+		Address: address,
+		Token:   token,
+		Keys: map[string]crypto.PrivateKey{
+			"first": pk,
+		},
+	})
+
+	startErr := daemon.Start()
+	if startErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", startErr.Error())
+		return
+	}
+	defer daemon.Stop()
+
+	// create the remote signer:
+	remote := SDKFunc.CreateRemote(CreateRemoteParams{
+		Address: address,
+		Token:   token,
+		Key:     "first",
+	})
+
+	if !remote.PublicKey().Equals(pk.PublicKey()) {
+		t.Errorf("the remote PublicKey was expected to match the PublicKey of the daemon's key")
+		return
+	}
+
+	// sign:
+	sig, sigErr := remote.Sign(domain, msg)
+	if sigErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", sigErr.Error())
+		return
+	}
+
+	if !sig.VerifyBytes(domain, msg, pk.PublicKey()) {
+		t.Errorf("the signature was expected to be valid")
+		return
+	}
+
+	// an invalid token must fail:
+	_, invalidErr := createRemote(address, "not-the-token", "first")
+	if invalidErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	// an unknown key must fail:
+	_, unknownErr := createRemote(address, token, "unknown")
+	if unknownErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestDaemon_promptApprover_denied_returnsError(t *testing.T) {
+	// variables:
+	dirPath := "test_files"
+	address := fmt.Sprintf("unix://%s", filepath.Join(dirPath, "signer.sock"))
+	out := new(bytes.Buffer)
+	os.MkdirAll(dirPath, os.ModePerm)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	// start the daemon, the operator approves the first request then denies the second:
+	daemon := SDKFunc.CreateDaemon(CreateDaemonParams{
+		Address: address,
+		Keys: map[string]crypto.PrivateKey{
+			"first": crypto.SDKFunc.GenPK(),
+		},
+		Approver: SDKFunc.CreatePromptApprover(CreatePromptApproverParams{
+			In:  strings.NewReader("y\nn\n"),
+			Out: out,
+		}),
+	})
+
+	startErr := daemon.Start()
+	if startErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", startErr.Error())
+		return
+	}
+	defer daemon.Stop()
+
+	remote := SDKFunc.CreateRemote(CreateRemoteParams{
+		Address: address,
+		Key:     "first",
+	})
+
+	_, approvedErr := remote.Sign("xmnsuite/signer/tests", []byte("first"))
+	if approvedErr != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", approvedErr.Error())
+		return
+	}
+
+	_, deniedErr := remote.Sign("xmnsuite/signer/tests", []byte("second"))
+	if deniedErr == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+
+	if !strings.Contains(out.String(), "xmnsuite/signer/tests") {
+		t.Errorf("the operator was expected to be shown the domain of the signing request")
+		return
+	}
+}
+
+func TestDaemon_tcpWithoutToken_returnsError(t *testing.T) {
+	_, err := createDaemon("tcp://127.0.0.1:0", "", map[string]crypto.PrivateKey{
+		"first": crypto.SDKFunc.GenPK(),
+	}, createAutoApprover())
+
+	if err == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}